@@ -1,6 +1,7 @@
 package application
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -16,15 +17,25 @@ type Client struct {
 // NewClient returns a new client for the application resources managed by Oracle
 func NewClient(c *opc.Config) (*Client, error) {
 	appClient := &Client{}
-	client, err := client.NewClient(c)
+	opcClient, err := client.NewClient(c)
 	if err != nil {
 		return nil, err
 	}
-	appClient.client = client
+	appClient.client = opcClient.WithAuthenticator(&client.BasicAuthenticator{Credentials: opcClient.Credentials()})
 
 	return appClient, nil
 }
 
+// WithContext returns a copy of the application client bound to the given context.
+// Container clients obtained from the returned client will abort their requests
+// and WaitFor polling once the context is done.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	c2 := new(Client)
+	*c2 = *c
+	c2.client = c.client.WithContext(ctx)
+	return c2
+}
+
 func (c *Client) executeCreateUpdateApplicationContainer(method, path string, files map[string][]byte, additionalParams map[string]interface{}) (*http.Response, error) {
 	req, err := c.client.BuildMultipartFormRequest(method, path, files, additionalParams)
 	if err != nil {
@@ -37,8 +48,7 @@ func (c *Client) executeCreateUpdateApplicationContainer(method, path string, fi
 	c.client.DebugLogString(debugReqString)
 	c.client.DebugLogString(fmt.Sprintf("Req (%+v)", req))
 
-	// Set the authentication headers
-	req.SetBasicAuth(*c.client.UserName, *c.client.Password)
+	// Set the tenant header
 	req.Header.Add("X-ID-TENANT-NAME", *c.client.IdentityDomain)
 
 	resp, err := c.client.ExecuteRequest(req)
@@ -67,8 +77,7 @@ func (c *Client) executeRequest(method, path string, body interface{}) (*http.Re
 	c.client.DebugLogString(debugReqString)
 	c.client.DebugLogString(fmt.Sprintf("Req (%+v)", req))
 
-	// Set the authentiation headers
-	req.SetBasicAuth(*c.client.UserName, *c.client.Password)
+	// Set the tenant header
 	req.Header.Add("X-ID-TENANT-NAME", *c.client.IdentityDomain)
 
 	resp, err := c.client.ExecuteRequest(req)
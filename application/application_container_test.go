@@ -1,8 +1,13 @@
 package application
 
 import (
+	"encoding/json"
+	"fmt"
 	"log"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-oracle-terraform/helper"
 	"github.com/hashicorp/go-oracle-terraform/opc"
@@ -342,3 +347,35 @@ func getApplicationContainerTestClients() (*ContainerClient, error) {
 	}
 	return client.ContainerClient(), nil
 }
+
+// TestContainerClient_Concurrent waits for several application containers in parallel through a single
+// client, checking each request is sent to the path of its own container.
+// Run with -race to check the client isn't modified by its operations.
+func TestContainerClient_Concurrent(t *testing.T) {
+	const appsPath = "/paas/service/apaas/api/v1.1/apps/test-domain/"
+	client := getStubTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, appsPath)
+		if r.Method != "GET" || name == r.URL.Path || r.Header.Get("X-ID-TENANT-NAME") != "test-domain" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{
+			"name":   name,
+			"status": string(applicationContainerStatusRunning),
+		})
+	})
+
+	containers := client.ContainerClient()
+	helper.RunConcurrently(t, 20, func(i int) error {
+		name := fmt.Sprintf("test-app-%d", i)
+		input := &GetApplicationContainerInput{Name: name}
+		container, err := containers.WaitForApplicationContainerRunning(input, 10*time.Millisecond, 5*time.Second)
+		if err != nil {
+			return err
+		}
+		if container.Name != name {
+			return fmt.Errorf("Expected application container %s, got %s", name, container.Name)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-oracle-terraform/opc"
+)
+
+// Authenticator adds authentication to each request attempt made by a Client.
+// Implementations must be safe for concurrent use.
+type Authenticator interface {
+	// Authenticate adds credentials to the request
+	Authenticate(req *http.Request) error
+	// Invalidate discards the credentials added to the request after they were
+	// rejected with a 401, before the request is authenticated and sent once more
+	Invalidate(req *http.Request)
+}
+
+// BasicAuthenticator authenticates requests with HTTP basic authentication
+type BasicAuthenticator struct {
+	Credentials *opc.CredentialsCache
+}
+
+// Authenticate sets the basic authentication header of the request
+func (a *BasicAuthenticator) Authenticate(req *http.Request) error {
+	creds, err := a.Credentials.Retrieve(req.Context())
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(creds.Username, creds.Password)
+	return nil
+}
+
+// Invalidate discards the credentials the request was authenticated with
+func (a *BasicAuthenticator) Invalidate(req *http.Request) {
+	if username, password, ok := req.BasicAuth(); ok {
+		a.Credentials.Invalidate(&opc.Credentials{Username: username, Password: password})
+	}
+}
+
+// Token is an authentication token issued by a service in exchange for credentials
+type Token struct {
+	// The value sent in the TokenManager's Header
+	Value string
+	// The time the token must be refreshed by
+	Expires time.Time
+}
+
+func (t *Token) expired() bool {
+	return !t.Expires.IsZero() && !time.Now().Before(t.Expires)
+}
+
+// TokenManager authenticates requests with a token fetched in exchange for credentials. The token
+// is cached until it expires or is rejected, and concurrent requests share a single refresh.
+type TokenManager struct {
+	// The header the token is sent in
+	Header string
+	// The credentials exchanged for a token
+	Credentials *opc.CredentialsCache
+	// Fetches a new token. It must not be called through a Client using this TokenManager.
+	Fetch func(ctx context.Context, creds *opc.Credentials) (*Token, error)
+
+	mu    sync.Mutex
+	token *Token
+}
+
+// Token returns the current token, fetching a new one if there is no current token or it has
+// expired. If the credentials are rejected they're retrieved again and exchanged once more.
+func (m *TokenManager) Token(ctx context.Context) (*Token, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.token != nil && !m.token.expired() {
+		return m.token, nil
+	}
+	m.token = nil
+
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		var creds *opc.Credentials
+		if creds, err = m.Credentials.Retrieve(ctx); err != nil {
+			return nil, err
+		}
+		var token *Token
+		if token, err = m.Fetch(ctx, creds); err == nil {
+			if token == nil || token.Value == "" {
+				return nil, fmt.Errorf("No authentication token returned")
+			}
+			m.token = token
+			return token, nil
+		}
+		if !opc.IsAuthFailure(err) {
+			return nil, err
+		}
+		m.Credentials.Invalidate(creds)
+	}
+	return nil, err
+}
+
+// Authenticate sets the token header of the request
+func (m *TokenManager) Authenticate(req *http.Request) error {
+	token, err := m.Token(req.Context())
+	if err != nil {
+		return err
+	}
+	req.Header.Set(m.Header, token.Value)
+	return nil
+}
+
+// Invalidate discards the token the request was authenticated with, unless it has
+// already been replaced by a concurrent refresh
+func (m *TokenManager) Invalidate(req *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.token != nil && req.Header.Get(m.Header) == m.token.Value {
+		m.token = nil
+	}
+}
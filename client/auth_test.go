@@ -0,0 +1,261 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-oracle-terraform/opc"
+)
+
+// rotatingProvider supplies the current password, which tests can rotate
+type rotatingProvider struct {
+	mu       sync.Mutex
+	password string
+}
+
+func (p *rotatingProvider) Retrieve(ctx context.Context) (*opc.Credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return &opc.Credentials{Username: "user", Password: p.password}, nil
+}
+
+func (p *rotatingProvider) rotate(password string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.password = password
+}
+
+// tokenServer issues tokens in exchange for the current password, and only accepts the latest token
+type tokenServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	password string
+	token    string
+	issued   int32
+	bodies   []string
+}
+
+func newTokenServer(password string) *tokenServer {
+	s := &tokenServer{password: password}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if r.URL.Path == "/auth" {
+			if r.Header.Get("X-Password") != s.password {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			s.token = fmt.Sprintf("token-%d", atomic.AddInt32(&s.issued, 1))
+			w.Header().Set("X-Token", s.token)
+			return
+		}
+		if r.Header.Get("X-Token") != s.token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		s.bodies = append(s.bodies, string(body))
+	}))
+	return s
+}
+
+// expire invalidates the issued token and optionally rotates the password
+func (s *tokenServer) expire(password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = ""
+	if password != "" {
+		s.password = password
+	}
+}
+
+func getAuthTestClient(t *testing.T, server *tokenServer, provider opc.CredentialsProvider) (*Client, *TokenManager) {
+	endpoint, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := NewClient(&opc.Config{
+		IdentityDomain:      opc.String("test-domain"),
+		APIEndpoint:         endpoint,
+		HTTPClient:          server.Client(),
+		CredentialsProvider: provider,
+	})
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+
+	auth := &TokenManager{
+		Header:      "X-Token",
+		Credentials: client.Credentials(),
+		Fetch: func(ctx context.Context, creds *opc.Credentials) (*Token, error) {
+			req, err := client.WithContext(ctx).BuildRequestBody("GET", "/auth", nil)
+			if err != nil {
+				return nil, err
+			}
+			req.Header.Set("X-Password", creds.Password)
+			resp, err := client.ExecuteRequest(req)
+			if err != nil {
+				return nil, err
+			}
+			return &Token{Value: resp.Header.Get("X-Token"), Expires: time.Now().Add(time.Hour)}, nil
+		},
+	}
+	return client.WithAuthenticator(auth), auth
+}
+
+func doAuthTestRequest(t *testing.T, client *Client, body string) {
+	req, err := client.BuildRequestBody("POST", "/resource", []byte(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ExecuteRequest(req); err != nil {
+		t.Fatalf("Error executing request: %s", err)
+	}
+}
+
+func TestClient_CredentialsProviderUsername(t *testing.T) {
+	server := newTokenServer("password")
+	defer server.Close()
+
+	client, _ := getAuthTestClient(t, server, &rotatingProvider{password: "password"})
+	if client.UserName == nil || *client.UserName != "user" {
+		t.Fatalf("Expected the username to be taken from the credentials provider, got %v", client.UserName)
+	}
+}
+
+func TestTokenManager_RefreshOnUnauthorized(t *testing.T) {
+	server := newTokenServer("password")
+	defer server.Close()
+
+	client, _ := getAuthTestClient(t, server, &rotatingProvider{password: "password"})
+	doAuthTestRequest(t, client, "first")
+
+	// The rejected token is refreshed and the request, including its body, sent again
+	server.expire("")
+	doAuthTestRequest(t, client, "second")
+
+	if server.issued != 2 {
+		t.Fatalf("Expected 2 tokens to be issued, got %d", server.issued)
+	}
+	if len(server.bodies) != 2 || server.bodies[1] != "second" {
+		t.Fatalf("Unexpected request bodies: %v", server.bodies)
+	}
+}
+
+func TestTokenManager_PasswordRotation(t *testing.T) {
+	server := newTokenServer("password")
+	defer server.Close()
+
+	provider := &rotatingProvider{password: "password"}
+	client, _ := getAuthTestClient(t, server, provider)
+	doAuthTestRequest(t, client, "first")
+
+	// The cached password is rejected when fetching a token, so is retrieved again
+	server.expire("rotated")
+	provider.rotate("rotated")
+	doAuthTestRequest(t, client, "second")
+
+	if len(server.bodies) != 2 {
+		t.Fatalf("Expected 2 successful requests, got %d", len(server.bodies))
+	}
+}
+
+func TestTokenManager_BadCredentials(t *testing.T) {
+	server := newTokenServer("password")
+	defer server.Close()
+
+	client, _ := getAuthTestClient(t, server, &rotatingProvider{password: "wrong"})
+	req, err := client.BuildRequestBody("GET", "/resource", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.ExecuteRequest(req); !opc.IsAuthFailure(err) {
+		t.Fatalf("Expected an authentication failure, got %v", err)
+	}
+}
+
+func TestTokenManager_Expiry(t *testing.T) {
+	server := newTokenServer("password")
+	defer server.Close()
+
+	client, auth := getAuthTestClient(t, server, &rotatingProvider{password: "password"})
+	doAuthTestRequest(t, client, "first")
+
+	auth.mu.Lock()
+	auth.token.Expires = time.Now().Add(-time.Second)
+	auth.mu.Unlock()
+	doAuthTestRequest(t, client, "second")
+
+	if server.issued != 2 {
+		t.Fatalf("Expected the expired token to be refreshed, got %d tokens issued", server.issued)
+	}
+}
+
+func TestTokenManager_Concurrent(t *testing.T) {
+	server := newTokenServer("password")
+	defer server.Close()
+
+	client, _ := getAuthTestClient(t, server, &rotatingProvider{password: "password"})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			doAuthTestRequest(t, client, fmt.Sprintf("request-%d", i))
+		}(i)
+	}
+	wg.Wait()
+
+	if server.issued != 1 {
+		t.Fatalf("Expected concurrent requests to share a single token, got %d tokens issued", server.issued)
+	}
+}
+
+func TestBasicAuthenticator_PasswordRotation(t *testing.T) {
+	var password atomic.Value
+	password.Store("password")
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if _, p, ok := r.BasicAuth(); !ok || p != password.Load().(string) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	endpoint, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := &rotatingProvider{password: "password"}
+	client, err := NewClient(&opc.Config{
+		IdentityDomain:      opc.String("test-domain"),
+		APIEndpoint:         endpoint,
+		HTTPClient:          server.Client(),
+		CredentialsProvider: provider,
+	})
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+	client = client.WithAuthenticator(&BasicAuthenticator{Credentials: client.Credentials()})
+
+	doAuthTestRequest(t, client, "")
+	password.Store("rotated")
+	provider.rotate("rotated")
+	doAuthTestRequest(t, client, "")
+
+	if requests != 3 {
+		t.Fatalf("Expected the rejected request to be sent once more, got %d requests", requests)
+	}
+}
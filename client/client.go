@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,7 +12,7 @@ import (
 	"net/http"
 	"net/url"
 	"runtime"
-	"strings"
+	"strconv"
 	"time"
 
 	"github.com/hashicorp/go-oracle-terraform/opc"
@@ -39,9 +40,14 @@ type Client struct {
 	APIEndpoint    *url.URL
 	httpClient     *http.Client
 	MaxRetries     *int
+	RetryPolicy    *opc.RetryPolicy
 	UserAgent      *string
 	logger         opc.Logger
 	loglevel       opc.LogLevelType
+	middleware     []opc.Middleware
+	ctx            context.Context
+	credentials    *opc.CredentialsCache
+	authenticator  Authenticator
 }
 
 // NewClient returns a new client
@@ -55,7 +61,9 @@ func NewClient(c *opc.Config) (*Client, error) {
 		UserAgent:      &defaultUserAgent,
 		httpClient:     c.HTTPClient,
 		MaxRetries:     c.MaxRetries,
+		RetryPolicy:    c.RetryPolicy,
 		loglevel:       c.LogLevel,
+		middleware:     c.Middleware,
 	}
 	if c.UserAgent != nil {
 		client.UserAgent = c.UserAgent
@@ -84,9 +92,70 @@ func NewClient(c *opc.Config) (*Client, error) {
 		return nil, fmt.Errorf("No HTTP client specified in config")
 	}
 
+	// Without a provider the credentials are taken from the config
+	provider := c.CredentialsProvider
+	if provider == nil {
+		static := &opc.StaticCredentialsProvider{}
+		if c.Username != nil {
+			static.Username = *c.Username
+		}
+		if c.Password != nil {
+			static.Password = *c.Password
+		}
+		provider = static
+	}
+	client.credentials = opc.NewCredentialsCache(provider)
+
+	// The username qualifies the names of some resources, so must be known up front
+	if client.UserName == nil {
+		creds, err := client.credentials.Retrieve(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving credentials: %s", err)
+		}
+		client.UserName = opc.String(creds.Username)
+	}
+
 	return client, nil
 }
 
+// Credentials returns the cache of credentials the client authenticates with.
+// Retrieve the credentials from it rather than reading the Password, so rotated
+// credentials are picked up.
+func (c *Client) Credentials() *opc.CredentialsCache {
+	return c.credentials
+}
+
+// WithAuthenticator returns a shallow copy of the client which authenticates every
+// request attempt with the given Authenticator, or doesn't authenticate if it's nil
+func (c *Client) WithAuthenticator(a Authenticator) *Client {
+	c2 := new(Client)
+	*c2 = *c
+	c2.authenticator = a
+	return c2
+}
+
+// WithContext returns a shallow copy of the client whose requests, retry backoff
+// and WaitFor polling are bound to the supplied context. Cancelling the context
+// aborts any in-flight request and stops any pending sleep.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	if ctx == nil {
+		panic("nil context")
+	}
+	c2 := new(Client)
+	*c2 = *c
+	c2.ctx = ctx
+	return c2
+}
+
+// Context returns the client's context. The returned context is always non-nil;
+// it defaults to the background context.
+func (c *Client) Context() context.Context {
+	if c.ctx != nil {
+		return c.ctx
+	}
+	return context.Background()
+}
+
 // MarshallRequestBody marshalls the request body and returns the resulting byte slice
 // This is split out of the BuildRequestBody method so as to allow
 // the developer to print a debug string of the request body if they
@@ -119,6 +188,7 @@ func (c *Client) BuildRequestBody(method, path string, body []byte) (*http.Reque
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(c.Context())
 	// Adding UserAgent Header
 	req.Header.Add(userAgentHeader, *c.UserAgent)
 
@@ -138,6 +208,7 @@ func (c *Client) BuildNonJSONRequest(method, path string, body io.Reader) (*http
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(c.Context())
 	// Adding UserAgentHeader
 	req.Header.Add(userAgentHeader, *c.UserAgent)
 
@@ -183,9 +254,13 @@ func (c *Client) BuildMultipartFormRequest(method, path string, files map[string
 	}
 
 	req, err := http.NewRequest(method, c.formatURL(urlPath), body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(c.Context())
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 
-	return req, err
+	return req, nil
 }
 
 // ExecuteRequest executes the http.Request from the BuildRequest method.
@@ -201,21 +276,16 @@ func (c *Client) ExecuteRequest(req *http.Request) (*http.Response, error) {
 		return resp, nil
 	}
 
-	oracleErr := &opc.OracleError{
-		StatusCode: resp.StatusCode,
-	}
-
-	// Even though the returned body will be in json form, it's undocumented what
-	// fields are actually returned. Once we get documentation of the actual
-	// error fields that are possible to be returned we can have stricter error types.
+	// The shape of the returned error body differs between services, NewOracleError
+	// parses the common code, message and detail fields out of it.
+	buf := new(bytes.Buffer)
 	if resp.Body != nil {
-		buf := new(bytes.Buffer)
 		_, err = buf.ReadFrom(resp.Body)
 		if err != nil {
 			return resp, nil
 		}
-		oracleErr.Message = buf.String()
 	}
+	oracleErr := opc.NewOracleError(resp.StatusCode, resp.Header, buf.Bytes())
 
 	// Should return the response object regardless of error,
 	// some resources need to verify and check status code on errors to
@@ -224,7 +294,8 @@ func (c *Client) ExecuteRequest(req *http.Request) (*http.Response, error) {
 }
 
 // Allow retrying the request until it either returns no error,
-// or we exceed the number of max retries
+// or we exceed the number of max retries. Without a RetryPolicy every non-2xx
+// response is retried and transport errors are returned immediately.
 func (c *Client) retryRequest(req *http.Request) (*http.Response, error) {
 	// Double check maxRetries is not nil
 	var retries int
@@ -234,8 +305,8 @@ func (c *Client) retryRequest(req *http.Request) (*http.Response, error) {
 		retries = *c.MaxRetries
 	}
 
-	var statusCode int
-	var errMessage string
+	policy := c.RetryPolicy
+	var lastErr error = &opc.OracleError{}
 
 	// Cache the body content for retries.
 	// This is to allow reuse of the original request for the retries attempts
@@ -249,85 +320,208 @@ func (c *Client) retryRequest(req *http.Request) (*http.Response, error) {
 			return nil, err
 		}
 	}
+
 	// Initial sleep time between retries
 	sleep := 1 * time.Second
+	backoff := func(attempt int) time.Duration {
+		wait := sleep
+		// increase sleep time for next retry (exponential backoff with jitter)
+		// up to a maximum of ~60 seconds
+		if sleep <= 30*time.Second {
+			jitter := time.Duration(rand.Int63n(int64(sleep))) / 2
+			sleep = (sleep * 2) + jitter
+		}
+		return wait
+	}
+	if policy != nil && policy.Backoff != nil {
+		backoff = policy.Backoff
+	}
 
-	for i := retries; i > 0; i-- {
+	start := time.Now()
+	for attempt := 1; attempt <= retries; attempt++ {
 
 		// replace body with new unread Reader before each request
 		if len(body) > 0 {
 			req.Body = ioutil.NopCloser(bytes.NewBuffer(body))
 		}
 
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			return resp, err
-		}
-
-		if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		resp, err := c.roundTrip(req, body)
+		if err == nil && resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+			c.reportAttempt(&opc.RetryAttempt{Request: req, Attempt: attempt, Response: resp})
 			return resp, nil
 		}
 
-		buf := new(bytes.Buffer)
-		_, err = buf.ReadFrom(resp.Body)
+		var retry bool
 		if err != nil {
-			return resp, err
+			lastErr = err
+			c.DebugLogString(fmt.Sprintf("%s %s Encountered transport error: %s", req.Method, req.URL, err))
+			retry = policy != nil && policy.RetryNetworkErrors && policy.CanRetryMethod(req.Method) && req.Context().Err() == nil
+		} else {
+			buf := new(bytes.Buffer)
+			_, err = buf.ReadFrom(resp.Body)
+			if err != nil {
+				return resp, err
+			}
+			oracleErr := opc.NewOracleError(resp.StatusCode, resp.Header, buf.Bytes())
+			lastErr = oracleErr
+			c.DebugLogString(fmt.Sprintf("%s %s Encountered HTTP (%d) Error: %s", req.Method, req.URL, oracleErr.StatusCode, oracleErr.Message))
+			retry = policy == nil || (policy.ShouldRetryStatus(resp.StatusCode) && policy.CanRetryMethod(req.Method))
 		}
-		errMessage = buf.String()
-		statusCode = resp.StatusCode
-		c.DebugLogString(fmt.Sprintf("%s %s Encountered HTTP (%d) Error: %s", req.Method, req.URL, statusCode, errMessage))
-		if i != 1 {
-			c.DebugLogString(fmt.Sprintf("%d of %d retries remaining. Next retry in %ds", i-1, retries, sleep/time.Second))
-			time.Sleep(sleep)
-			// increase sleep time for next retry (exponential backoff with jitter)
-			// up to a maximum of ~60 seconds
-			if sleep <= 30*time.Second {
-				jitter := time.Duration(rand.Int63n(int64(sleep))) / 2
-				sleep = (sleep * 2) + jitter
+
+		var wait time.Duration
+		if retry && attempt < retries {
+			wait = backoff(attempt)
+			if policy != nil && policy.HonorRetryAfter {
+				if retryAfter, ok := parseRetryAfter(resp); ok {
+					wait = retryAfter
+				}
+			}
+			if policy != nil && policy.MaxElapsedTime > 0 && time.Since(start)+wait > policy.MaxElapsedTime {
+				c.DebugLogString(fmt.Sprintf("Retrying in %s would exceed the maximum elapsed time of %s", wait, policy.MaxElapsedTime))
+				retry = false
 			}
+		} else {
+			retry = false
+		}
+
+		c.reportAttempt(&opc.RetryAttempt{
+			Request:  req,
+			Attempt:  attempt,
+			Response: resp,
+			Err:      lastErr,
+			Retrying: retry,
+			Wait:     wait,
+		})
+
+		if !retry {
+			if err != nil {
+				return resp, err
+			}
+			break
 		}
-	}
 
-	oracleErr := &opc.OracleError{
-		StatusCode: statusCode,
-		Message:    errMessage,
+		c.DebugLogString(fmt.Sprintf("%d of %d retries remaining. Next retry in %ds", retries-attempt, retries, wait/time.Second))
+		if err := sleepContext(req.Context(), wait); err != nil {
+			return nil, err
+		}
 	}
 
 	// We ran out of retries to make, return the error and response
-	return nil, oracleErr
+	return nil, lastErr
+}
+
+// roundTrip sends a single attempt of the request through the configured middleware chain.
+// If the authentication is rejected, it's refreshed and the request is sent once more.
+func (c *Client) roundTrip(req *http.Request, body []byte) (*http.Response, error) {
+	transport := opc.Chain(opc.RoundTripperFunc(c.httpClient.Do), c.middleware...)
+	if c.authenticator == nil {
+		return transport.RoundTrip(req)
+	}
+
+	if err := c.authenticator.Authenticate(req); err != nil {
+		return nil, err
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	c.DebugLogString(fmt.Sprintf("%s %s Authentication rejected, refreshing credentials", req.Method, req.URL))
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+	c.authenticator.Invalidate(req)
+
+	if len(body) > 0 {
+		req.Body = ioutil.NopCloser(bytes.NewBuffer(body))
+	}
+	if err := c.authenticator.Authenticate(req); err != nil {
+		return nil, err
+	}
+	return transport.RoundTrip(req)
+}
+
+func (c *Client) reportAttempt(attempt *opc.RetryAttempt) {
+	if c.RetryPolicy != nil && c.RetryPolicy.OnAttempt != nil {
+		c.RetryPolicy.OnAttempt(attempt)
+	}
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date
+func parseRetryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
 }
 
 func (c *Client) formatURL(path *url.URL) string {
 	return c.APIEndpoint.ResolveReference(path).String()
 }
 
+// Sleep pauses for the given duration, returning early with the context's error
+// if the client's context is cancelled or its deadline passes.
+func (c *Client) Sleep(d time.Duration) error {
+	return sleepContext(c.Context(), d)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // WaitFor - Retry function
+// Polling stops early if the client's context is cancelled.
 func (c *Client) WaitFor(description string, pollInterval, timeout time.Duration, test func() (bool, error)) error {
 
-	timeoutSeconds := int(timeout.Seconds())
-	pollIntervalSeconds := int(pollInterval.Seconds())
+	deadline := time.Now().Add(timeout)
 
-	c.DebugLogString(fmt.Sprintf("Starting Wait For %s, polling every %d for %d seconds ", description, pollIntervalSeconds, timeoutSeconds))
+	c.DebugLogString(fmt.Sprintf("Starting Wait For %s, polling every %s for %s ", description, pollInterval, timeout))
 
-	for i := 0; i < timeoutSeconds; i += pollIntervalSeconds {
-		c.DebugLogString(fmt.Sprintf("Waiting %d seconds for %s (%d/%ds)", pollIntervalSeconds, description, i, timeoutSeconds))
-		time.Sleep(pollInterval)
+	for start := time.Now(); time.Now().Before(deadline); {
+		c.DebugLogString(fmt.Sprintf("Waiting %s for %s (%s/%s)", pollInterval, description, time.Since(start).Round(time.Second), timeout))
+		if err := c.Sleep(pollInterval); err != nil {
+			c.DebugLogString(fmt.Sprintf("Stopped waiting for %s: %s", description, err))
+			return err
+		}
 		completed, err := test()
 		if err != nil || completed {
 			return err
 		}
 	}
-	return fmt.Errorf("Timeout after %d seconds waiting for %s", timeoutSeconds, description)
+	return fmt.Errorf("Timeout after %s waiting for %s", timeout, description)
+}
+
+// SetWaitDefaults sets the poll interval and timeout passed to WaitFor to the defaults, unless they're already set
+func SetWaitDefaults(pollInterval, timeout *time.Duration, defaultPollInterval, defaultTimeout time.Duration) {
+	if *pollInterval == 0 {
+		*pollInterval = defaultPollInterval
+	}
+	if *timeout == 0 {
+		*timeout = defaultTimeout
+	}
 }
 
 // WasNotFoundError Used to determine if the checked resource was found or not.
 func WasNotFoundError(e error) bool {
-	err, ok := e.(*opc.OracleError)
-	if ok {
-		if strings.Contains(err.Error(), "No such service exits") {
-			return true
-		}
-		return err.StatusCode == 404
-	}
-	return false
+	return opc.IsNotFound(e)
 }
@@ -1,9 +1,12 @@
 package client
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-oracle-terraform/opc"
 	"gopkg.in/jarcoal/httpmock.v1"
@@ -82,3 +85,345 @@ func TestClient_userAgent(t *testing.T) {
 	}
 
 }
+
+func TestClient_retryHTTPContextCancelled(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	endpoint, err := url.Parse("http://foo.bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := Client{}
+	client.MaxRetries = opc.Int(5)
+	// Can't use a custom transport, otherwise httpmock won't catch request
+	client.httpClient = http.DefaultClient
+	client.APIEndpoint = endpoint
+	client.logger = opc.NewDefaultLogger()
+	client.loglevel = opc.LogLevel()
+	client.UserAgent = opc.String("TestUserAgent")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	httpmock.RegisterResponder("GET", "http://foo.bar/",
+		func(req *http.Request) (*http.Response, error) {
+			// Cancel during the first attempt so the backoff sleep is interrupted
+			cancel()
+			return httpmock.NewStringResponse(500, "mocked error message"), nil
+		},
+	)
+
+	req, err := client.WithContext(ctx).BuildRequestBody("GET", "http://foo.bar/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, reqErr := client.retryRequest(req)
+	if reqErr != context.Canceled {
+		t.Fatalf("Expected context.Canceled, got: %v", reqErr)
+	}
+
+	if httpmock.GetTotalCallCount() != 1 {
+		t.Fatalf("Expected 1 attempt, got: %d", httpmock.GetTotalCallCount())
+	}
+}
+
+func TestClient_WaitForContextDeadline(t *testing.T) {
+	client := Client{}
+	client.logger = opc.NewDefaultLogger()
+	client.loglevel = opc.LogLevel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	polls := 0
+	start := time.Now()
+	err := client.WithContext(ctx).WaitFor("test", 10*time.Millisecond, 1*time.Minute, func() (bool, error) {
+		polls++
+		return false, nil
+	})
+	if err != context.DeadlineExceeded {
+		t.Fatalf("Expected context.DeadlineExceeded, got: %v", err)
+	}
+	if polls == 0 {
+		t.Fatalf("Expected at least one poll before the deadline")
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Fatalf("WaitFor did not stop at the context deadline")
+	}
+}
+
+func TestClient_WaitForTimeout(t *testing.T) {
+	client := Client{}
+	client.logger = opc.NewDefaultLogger()
+	client.loglevel = opc.LogLevel()
+
+	polls := 0
+	start := time.Now()
+	err := client.WaitFor("test", 10*time.Millisecond, 50*time.Millisecond, func() (bool, error) {
+		polls++
+		return false, nil
+	})
+	if err == nil {
+		t.Fatalf("Expected a timeout error")
+	}
+	if polls == 0 {
+		t.Fatalf("Expected at least one poll before the timeout")
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Fatalf("WaitFor did not stop at the timeout")
+	}
+}
+
+func getRetryTestClient(t *testing.T, policy *opc.RetryPolicy) *Client {
+	endpoint, err := url.Parse("http://foo.bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &Client{}
+	client.MaxRetries = opc.Int(5)
+	client.RetryPolicy = policy
+	// Can't use a custom transport, otherwise httpmock won't catch request
+	client.httpClient = http.DefaultClient
+	client.APIEndpoint = endpoint
+	client.logger = opc.NewDefaultLogger()
+	client.loglevel = opc.LogLevel()
+	client.UserAgent = opc.String("TestUserAgent")
+	return client
+}
+
+func TestClient_retryPolicyStatusCodes(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	attempts := []*opc.RetryAttempt{}
+	policy := opc.DefaultRetryPolicy()
+	policy.Backoff = func(int) time.Duration { return 0 }
+	policy.OnAttempt = func(a *opc.RetryAttempt) {
+		attempts = append(attempts, a)
+	}
+	client := getRetryTestClient(t, policy)
+
+	httpmock.RegisterResponder("GET", "http://foo.bar/missing",
+		httpmock.NewStringResponder(404, "mocked error message"))
+
+	req, err := client.BuildRequestBody("GET", "/missing", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.retryRequest(req); !opc.IsNotFound(err) {
+		t.Fatalf("Expected not found error, got: %v", err)
+	}
+	if httpmock.GetTotalCallCount() != 1 {
+		t.Fatalf("Expected 404 not to be retried, got %d calls", httpmock.GetTotalCallCount())
+	}
+
+	calls := 0
+	httpmock.RegisterResponder("GET", "http://foo.bar/unavailable",
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls < 3 {
+				return httpmock.NewStringResponse(503, "mocked error message"), nil
+			}
+			return httpmock.NewStringResponse(200, "{}"), nil
+		},
+	)
+
+	req, err = client.BuildRequestBody("GET", "/unavailable", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.retryRequest(req); err != nil {
+		t.Fatalf("Expected 503 to be retried until success, got: %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("Expected 3 calls, got %d", calls)
+	}
+	if len(attempts) != 4 {
+		t.Fatalf("Expected 4 attempts to be reported, got %d", len(attempts))
+	}
+	if !attempts[1].Retrying || attempts[3].Retrying || attempts[3].Err != nil {
+		t.Fatalf("Unexpected attempts reported: %+v", attempts)
+	}
+}
+
+func TestClient_retryPolicyNonIdempotent(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	policy := opc.DefaultRetryPolicy()
+	policy.Backoff = func(int) time.Duration { return 0 }
+	client := getRetryTestClient(t, policy)
+
+	httpmock.RegisterResponder("POST", "http://foo.bar/",
+		httpmock.NewStringResponder(503, "mocked error message"))
+
+	req, err := client.BuildRequestBody("POST", "/", []byte("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.retryRequest(req); err == nil {
+		t.Fatalf("Expected error, got none")
+	}
+	if httpmock.GetTotalCallCount() != 1 {
+		t.Fatalf("Expected POST not to be retried, got %d calls", httpmock.GetTotalCallCount())
+	}
+
+	policy.RetryNonIdempotent = true
+	req, err = client.BuildRequestBody("POST", "/", []byte("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.retryRequest(req); err == nil {
+		t.Fatalf("Expected error, got none")
+	}
+	if httpmock.GetTotalCallCount() != 6 {
+		t.Fatalf("Expected POST to be retried 5 times, got %d calls", httpmock.GetTotalCallCount()-1)
+	}
+}
+
+func TestClient_retryPolicyNetworkErrors(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	calls := 0
+	httpmock.RegisterResponder("GET", "http://foo.bar/",
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				return nil, errors.New("connection reset by peer")
+			}
+			return httpmock.NewStringResponse(200, "{}"), nil
+		},
+	)
+
+	// Without a policy transport errors are returned immediately
+	client := getRetryTestClient(t, nil)
+	req, err := client.BuildRequestBody("GET", "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.retryRequest(req); err == nil {
+		t.Fatalf("Expected transport error, got none")
+	}
+
+	calls = 0
+	policy := opc.DefaultRetryPolicy()
+	policy.Backoff = func(int) time.Duration { return 0 }
+	client = getRetryTestClient(t, policy)
+	req, err = client.BuildRequestBody("GET", "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.retryRequest(req); err != nil {
+		t.Fatalf("Expected transport error to be retried, got: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("Expected 2 calls, got %d", calls)
+	}
+}
+
+func TestClient_retryPolicyRetryAfter(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	policy := opc.DefaultRetryPolicy()
+	// The backoff would stall the test if Retry-After were ignored
+	policy.Backoff = func(int) time.Duration { return time.Hour }
+	client := getRetryTestClient(t, policy)
+	client.MaxRetries = opc.Int(2)
+
+	httpmock.RegisterResponder("GET", "http://foo.bar/",
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(429, "slow down")
+			resp.Header.Set("Retry-After", "0")
+			return resp, nil
+		},
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := client.WithContext(ctx).BuildRequestBody("GET", "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.retryRequest(req); !opc.IsThrottled(err) {
+		t.Fatalf("Expected throttled error, got: %v", err)
+	}
+	if httpmock.GetTotalCallCount() != 2 {
+		t.Fatalf("Expected 2 calls, got %d", httpmock.GetTotalCallCount())
+	}
+
+	policy.MaxElapsedTime = time.Minute
+	policy.HonorRetryAfter = false
+	req, err = client.WithContext(ctx).BuildRequestBody("GET", "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.retryRequest(req); !opc.IsThrottled(err) {
+		t.Fatalf("Expected throttled error without waiting past the max elapsed time, got: %v", err)
+	}
+	if httpmock.GetTotalCallCount() != 3 {
+		t.Fatalf("Expected 3 calls, got %d", httpmock.GetTotalCallCount())
+	}
+}
+
+func TestClient_middleware(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	order := []string{}
+	tracing := func(next http.RoundTripper) http.RoundTripper {
+		return opc.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			order = append(order, "tracing")
+			req.Header.Set("X-Trace-Id", "trace-123")
+			return next.RoundTrip(req)
+		})
+	}
+	statusCodes := []int{}
+	metrics := func(next http.RoundTripper) http.RoundTripper {
+		return opc.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			order = append(order, "metrics")
+			resp, err := next.RoundTrip(req)
+			if resp != nil {
+				statusCodes = append(statusCodes, resp.StatusCode)
+			}
+			return resp, err
+		})
+	}
+
+	client := getRetryTestClient(t, nil)
+	client.MaxRetries = opc.Int(2)
+	client.middleware = []opc.Middleware{tracing, metrics}
+
+	httpmock.RegisterResponder("GET", "http://foo.bar/",
+		func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("X-Trace-Id") != "trace-123" {
+				t.Errorf("Expected tracing header to be set by middleware")
+			}
+			return httpmock.NewStringResponse(500, "mocked error message"), nil
+		},
+	)
+
+	req, err := client.BuildRequestBody("GET", "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.ExecuteRequest(req); err == nil {
+		t.Fatalf("Expected error, got none")
+	}
+
+	expectedOrder := []string{"tracing", "metrics", "tracing", "metrics"}
+	if len(order) != len(expectedOrder) {
+		t.Fatalf("Expected middleware to run for every attempt in order %v, got %v", expectedOrder, order)
+	}
+	for i := range order {
+		if order[i] != expectedOrder[i] {
+			t.Fatalf("Expected middleware to run for every attempt in order %v, got %v", expectedOrder, order)
+		}
+	}
+	if len(statusCodes) != 2 || statusCodes[0] != 500 {
+		t.Fatalf("Expected metrics middleware to record both responses, got %v", statusCodes)
+	}
+}
@@ -89,6 +89,30 @@ func (c *ACLsClient) GetACL(getInput *GetACLInput) (*ACLInfo, error) {
 	return c.success(&aclInfo)
 }
 
+// ListACLs retrieves the ACLs in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *ACLsClient) ListACLs(input *ListInput) ([]ACLInfo, error) {
+	var list struct {
+		Result []ACLInfo `json:"result"`
+	}
+	if err := c.listResource(input, &list); err != nil {
+		return nil, err
+	}
+
+	acls := []ACLInfo{}
+	for i := range list.Result {
+		info, err := c.success(&list.Result[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, info.Tags) {
+			acls = append(acls, *info)
+		}
+	}
+
+	return acls, nil
+}
+
 // UpdateACLInput describes a secruity rule to update
 type UpdateACLInput struct {
 	// Description of the ACL
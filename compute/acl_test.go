@@ -111,6 +111,53 @@ func TestAccACLsClient_CreateRule(t *testing.T) {
 	assert.False(t, info.Enabled, "Expected `info` to not be enabled.")
 }
 
+// Test that the client can list ACLs from another container.
+func TestACLsClient_ListACLs(t *testing.T) {
+	server := newAuthenticatingServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Wrong HTTP method %s, expected GET", r.Method)
+		}
+
+		expectedPath := "/network/v1/acl/Compute-test/"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Wrong HTTP URL %v, expected %v", r.URL, expectedPath)
+		}
+
+		w.Write([]byte(exampleListACLsResponse))
+	})
+
+	defer server.Close()
+	client, err := getStubACLsClient(server)
+	if err != nil {
+		t.Fatalf("error getting stub client: %s", err)
+	}
+
+	acls, err := client.ListACLs(&ListInput{
+		Container:  "/Compute-test/",
+		NamePrefix: "test/",
+	})
+	if err != nil {
+		t.Fatalf("List ACLs request failed: %s", err)
+	}
+	assert.Len(t, acls, 1)
+	assert.Equal(t, "acl-1", acls[0].Name, "Expected ACL from the user's container to be unqualified")
+}
+
+var exampleListACLsResponse = `
+{
+  "result": [
+    {
+      "name": "/Compute-test/test/acl-1",
+      "enabledFlag": true
+    },
+    {
+      "name": "/Compute-test/other-user/acl-2",
+      "enabledFlag": true
+    }
+  ]
+}
+`
+
 var exampleCreateACLResponse = `
 {
   "name": "/Compute-acme/jack.jones@example.com/es_to_videoservers_stream",
@@ -1,34 +1,46 @@
 package compute
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"time"
+
+	"github.com/hashicorp/go-oracle-terraform/client"
+	"github.com/hashicorp/go-oracle-terraform/opc"
 )
 
+// The authentication cookie expires after 30 minutes, so is refreshed before then
+const authCookieTTL = 25 * time.Minute
+
 // AuthenticationReq represents the body of an authentication request.
 type AuthenticationReq struct {
 	User     string `json:"user"`
 	Password string `json:"password"`
 }
 
-// Get a new auth cookie for the compute client
-func (c *Client) getAuthenticationCookie() error {
+// Get a new auth cookie for the compute client. The client must not authenticate its
+// own requests, the cookie is returned as a token to be sent in the Cookie header.
+func (c *Client) getAuthenticationCookie(ctx context.Context, creds *opc.Credentials) (*client.Token, error) {
 	req := AuthenticationReq{
-		User:     c.getUserName(),
-		Password: *c.client.Password,
+		User:     fmt.Sprintf(cmpUsername, *c.client.IdentityDomain, creds.Username),
+		Password: creds.Password,
 	}
 
-	rsp, err := c.executeRequest("POST", "/authenticate/", req)
+	rsp, err := c.WithContext(ctx).executeRequest("POST", "/authenticate/", req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(rsp.Cookies()) == 0 {
-		return fmt.Errorf("No authentication cookie found in response %#v", rsp)
+		return nil, fmt.Errorf("No authentication cookie found in response %#v", rsp)
 	}
 
 	c.client.DebugLogString("Successfully authenticated to OPC")
-	c.authCookie = rsp.Cookies()[0]
-	c.cookieIssued = time.Now()
-	return nil
+	// Only the name and value of the cookie are sent back
+	cookie := &http.Cookie{Name: rsp.Cookies()[0].Name, Value: rsp.Cookies()[0].Value}
+	return &client.Token{
+		Value:   cookie.String(),
+		Expires: time.Now().Add(authCookieTTL),
+	}, nil
 }
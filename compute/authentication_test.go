@@ -1,12 +1,15 @@
 package compute
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"sync/atomic"
 	"testing"
 
+	"github.com/hashicorp/go-oracle-terraform/compute/computetest"
 	"github.com/hashicorp/go-oracle-terraform/helper"
 	"github.com/hashicorp/go-oracle-terraform/opc"
 )
@@ -65,8 +68,12 @@ func TestAccObtainAuthenticationCookie(t *testing.T) {
 		t.Fatalf("Authentication failed: %s", err)
 	}
 
-	if client.authCookie == nil {
-		t.Fatal("Authentication cookie not set")
+	token, err := client.auth.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Error getting authentication cookie: %s", err)
+	}
+	if token.Value != authCookie.String() {
+		t.Fatalf("Wrong authentication cookie %s, expected %s", token.Value, authCookie.String())
 	}
 }
 
@@ -105,3 +112,51 @@ func TestAccAuthenticationCookieSentInRequestsFromAuthenticatedClient(t *testing
 		t.Fatalf("Authenticatde request failed: %s", err)
 	}
 }
+
+// TestClient_AuthenticationCookieConcurrent makes requests in parallel through a single client after
+// its authentication cookie expires, checking they share a single new cookie.
+// Run with -race to check the client isn't modified by its operations.
+func TestClient_AuthenticationCookieConcurrent(t *testing.T) {
+	server := computetest.NewServer()
+	t.Cleanup(server.Close)
+
+	var authentications int32
+	config := server.Config()
+	config.Middleware = []opc.Middleware{func(next http.RoundTripper) http.RoundTripper {
+		return opc.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/authenticate/" {
+				atomic.AddInt32(&authentications, 1)
+			}
+			return next.RoundTrip(req)
+		})
+	}}
+	client, err := NewComputeClient(config)
+	if err != nil {
+		t.Fatalf("error creating client: %s", err)
+	}
+	securityLists := client.SecurityLists()
+	if _, err := securityLists.CreateSecurityList(&CreateSecurityListInput{Name: "initial"}); err != nil {
+		t.Fatalf("Error creating security list: %s", err)
+	}
+
+	server.ExpireSessions()
+	atomic.StoreInt32(&authentications, 0)
+	helper.RunConcurrently(t, 20, func(i int) error {
+		name := fmt.Sprintf("list-%d", i)
+		if _, err := securityLists.CreateSecurityList(&CreateSecurityListInput{Name: name}); err != nil {
+			return fmt.Errorf("Error creating security list %s: %s", name, err)
+		}
+		info, err := securityLists.GetSecurityList(&GetSecurityListInput{Name: name})
+		if err != nil {
+			return fmt.Errorf("Error getting security list %s: %s", name, err)
+		}
+		if info.Name != name {
+			return fmt.Errorf("Expected security list %s, got %s", name, info.Name)
+		}
+		return nil
+	})
+
+	if n := atomic.LoadInt32(&authentications); n != 1 {
+		t.Fatalf("Expected the requests to share a single new cookie, got %d authentications", n)
+	}
+}
@@ -1,11 +1,11 @@
 package compute
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"regexp"
 	"strings"
-	"time"
 
 	"github.com/hashicorp/go-oracle-terraform/client"
 	"github.com/hashicorp/go-oracle-terraform/opc"
@@ -16,28 +16,49 @@ const cmpUsername = "/Compute-%s/%s"
 const cmpQualifiedName = "%s/%s"
 
 // Client represents an authenticated compute client, with compute credentials and an api client.
+// The client and the resource clients obtained from it can be shared between goroutines, which also share
+// its authentication cookie.
 type Client struct {
-	client       *client.Client
-	authCookie   *http.Cookie
-	cookieIssued time.Time
+	client *client.Client
+	auth   *client.TokenManager
 }
 
 // NewComputeClient returns a compute client to interact with the Oracle Compute Infrastructure - Classic APIs
 func NewComputeClient(c *opc.Config) (*Client, error) {
-	computeClient := &Client{}
-	client, err := client.NewClient(c)
+	opcClient, err := client.NewClient(c)
 	if err != nil {
 		return nil, err
 	}
-	computeClient.client = client
 
-	if err := computeClient.getAuthenticationCookie(); err != nil {
+	// Authentication requests are made by a client which doesn't authenticate itself
+	authClient := &Client{client: opcClient}
+	auth := &client.TokenManager{
+		Header:      "Cookie",
+		Credentials: opcClient.Credentials(),
+		Fetch:       authClient.getAuthenticationCookie,
+	}
+	computeClient := &Client{
+		client: opcClient.WithAuthenticator(auth),
+		auth:   auth,
+	}
+
+	if _, err := auth.Token(opcClient.Context()); err != nil {
 		return nil, err
 	}
 
 	return computeClient, nil
 }
 
+// WithContext returns a copy of the compute client bound to the given context.
+// Resource clients obtained from the returned client (e.g. Instances()) will
+// abort their requests and WaitFor polling once the context is done.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	c2 := new(Client)
+	*c2 = *c
+	c2.client = c.client.WithContext(ctx)
+	return c2
+}
+
 func (c *Client) executeRequest(method, path string, body interface{}) (*http.Response, error) {
 	reqBody, err := c.client.MarshallRequestBody(body)
 	if err != nil {
@@ -57,18 +78,8 @@ func (c *Client) executeRequest(method, path string, body interface{}) (*http.Re
 			debugReqString = fmt.Sprintf("%s:\nBody: %+v", debugReqString, string(reqBody))
 		}
 	}
-	// Log the request before the authentication cookie, so as not to leak credentials
+	// The authentication cookie is added by the client, so isn't leaked in the log
 	c.client.DebugLogString(debugReqString)
-	// If we have an authentication cookie, let's authenticate, refreshing cookie if need be
-	if c.authCookie != nil {
-		if time.Since(c.cookieIssued).Minutes() > 25 {
-			c.authCookie = nil
-			if err = c.getAuthenticationCookie(); err != nil {
-				return nil, err
-			}
-		}
-		req.AddCookie(c.authCookie)
-	}
 
 	resp, err := c.client.ExecuteRequest(req)
 	if err != nil {
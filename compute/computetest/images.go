@@ -0,0 +1,100 @@
+package computetest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// createMachineImage registers a machine image from a file in the storage account. The machine
+// image is `pending` until it settles, when it becomes `available`, or `error` if it's named
+// in MachineImageErrors.
+func (s *Server) createMachineImage(body map[string]interface{}) (interface{}, *apiError) {
+	if file, _ := body["file"].(string); file == "" {
+		return nil, errorf(http.StatusBadRequest, "A machine image file must be specified")
+	}
+
+	o, err := s.insert(machineImageRoot, body)
+	if err != nil {
+		return nil, err
+	}
+
+	name := o.body["name"].(string)
+	o.body["state"] = "pending"
+	o.body["image_format"] = "raw"
+	s.settle(o, func() {
+		if reason, ok := s.MachineImageErrors[name]; ok {
+			o.body["state"] = "error"
+			o.body["error_reason"] = reason
+			return
+		}
+		o.body["state"] = "available"
+	})
+	return o.body, nil
+}
+
+func (s *Server) createImageList(body map[string]interface{}) (interface{}, *apiError) {
+	body["entries"] = []interface{}{}
+	if version, _ := body["default"].(float64); version == 0 {
+		body["default"] = 1
+	}
+	o, err := s.insert(imageListRoot, body)
+	if err != nil {
+		return nil, err
+	}
+	return o.body, nil
+}
+
+// createImageListEntry adds an entry to an image list, from a POST to /imagelist/{name}/entry/
+func (s *Server) createImageListEntry(path string, body map[string]interface{}) (interface{}, *apiError) {
+	name := strings.TrimSuffix(path, "/entry/")
+	list := s.read(imageListRoot + name)
+	if name == path || list == nil {
+		return nil, errorf(http.StatusNotFound, "Image list %s does not exist", name)
+	}
+
+	version, ok := body["version"].(float64)
+	if !ok || version < 1 {
+		return nil, errorf(http.StatusBadRequest, "Invalid image list entry version %v", body["version"])
+	}
+	images, _ := body["machineimages"].([]interface{})
+	if len(images) == 0 {
+		return nil, errorf(http.StatusBadRequest, "An image list entry must include a machine image")
+	}
+	for _, image := range images {
+		if s.lookup(machineImageRoot, fmt.Sprint(image)) == nil {
+			return nil, errorf(http.StatusBadRequest, "Machine image %s does not exist", image)
+		}
+	}
+
+	key := fmt.Sprintf("%s%s/entry/%d", imageListRoot, name, int(version))
+	if _, ok := s.objects[key]; ok {
+		return nil, errorf(http.StatusConflict, "Conflict: version %d of image list %s already exists", int(version), name)
+	}
+	body["imagelist"] = name
+	body["uri"] = s.URL + key
+	s.objects[key] = &object{root: imageListEntryRoot, body: body}
+	list.children = append(list.children, key)
+	list.body["entries"] = append(list.body["entries"].([]interface{}), body)
+	return body, nil
+}
+
+// deleteImageList deletes an image list along with its entries, or a single entry of an image list
+func (s *Server) deleteImageList(o *object, _ url.Values) *apiError {
+	if o.root != imageListEntryRoot {
+		s.remove(imageListRoot + o.body["name"].(string))
+		return nil
+	}
+
+	list := s.objects[imageListRoot+o.body["imagelist"].(string)]
+	entries := []interface{}{}
+	for _, entry := range list.body["entries"].([]interface{}) {
+		if entry.(map[string]interface{})["version"] != o.body["version"] {
+			entries = append(entries, entry)
+		}
+	}
+	list.body["entries"] = entries
+	s.remove(strings.TrimPrefix(o.body["uri"].(string), s.URL))
+	return nil
+}
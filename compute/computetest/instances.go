@@ -0,0 +1,305 @@
+package computetest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// createLaunchPlan launches the instances in a launch plan. Each instance is
+// created in the `queued` state, and becomes `running` (or `shutdown`, if that's
+// its desired state) once it settles, unless it's one of the InstanceErrors.
+func (s *Server) createLaunchPlan(body map[string]interface{}) (interface{}, *apiError) {
+	instances, _ := body["instances"].([]interface{})
+	if len(instances) == 0 {
+		return nil, errorf(http.StatusBadRequest, "A launch plan must contain at least one instance")
+	}
+
+	// Validate the whole plan before launching anything
+	inputs := []map[string]interface{}{}
+	for _, i := range instances {
+		input, ok := i.(map[string]interface{})
+		if !ok {
+			return nil, errorf(http.StatusBadRequest, "Invalid instance in launch plan: %v", i)
+		}
+		if err := s.checkInstance(input); err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, input)
+	}
+
+	launched := []interface{}{}
+	for _, input := range inputs {
+		o := s.launchInstance(input)
+		launched = append(launched, o.body)
+	}
+	return map[string]interface{}{"instances": launched}, nil
+}
+
+func (s *Server) checkInstance(input map[string]interface{}) *apiError {
+	name, _ := input["name"].(string)
+	if err := s.checkName(name); err != nil {
+		return err
+	}
+	if desired, ok := input["desired_state"].(string); ok && desired != "running" && desired != "shutdown" {
+		return errorf(http.StatusBadRequest, "Invalid desired_state %q", desired)
+	}
+
+	storage, _ := input["storage_attachments"].([]interface{})
+	for _, a := range storage {
+		attachment, _ := a.(map[string]interface{})
+		volume, _ := attachment["volume"].(string)
+		if s.lookup(storageVolumeRoot, volume) == nil {
+			return errorf(http.StatusBadRequest, "Storage volume %s does not exist", volume)
+		}
+		if s.volumeAttachment(volume) != nil {
+			return errorf(http.StatusConflict, "Storage volume %s is already attached", volume)
+		}
+	}
+	return nil
+}
+
+// launchInstance creates an instance from a validated launch plan entry. The
+// instance is named after the requested name and a generated ID, i.e. /Compute-domain/user/name/id
+func (s *Server) launchInstance(input map[string]interface{}) *object {
+	id := newID()
+	name := input["name"].(string)
+
+	desired, _ := input["desired_state"].(string)
+	if desired == "" {
+		desired = "running"
+	}
+
+	storage, _ := input["storage_attachments"].([]interface{})
+	delete(input, "storage_attachments")
+
+	body := input
+	body["name"] = fmt.Sprintf("%s/%s", name, id)
+	body["id"] = id
+	body["desired_state"] = desired
+	body["state"] = "queued"
+	body["vcable_id"] = s.generateName(name)
+	body["ip"] = s.nextIPAddress()
+	body["storage_attachments"] = []interface{}{}
+	if hostname, _ := body["hostname"].(string); hostname == "" {
+		body["hostname"] = fmt.Sprintf("%s.compute-%s.oraclecloud.internal.", id, s.IdentityDomain)
+	}
+
+	o, _ := s.insert(instanceRoot, body)
+	for _, a := range storage {
+		attachment := a.(map[string]interface{})
+		s.attach(o, attachment["volume"].(string), attachment["index"])
+	}
+	s.createConsole(o)
+
+	s.settle(o, func() {
+		if reason, ok := s.InstanceErrors[name]; ok {
+			o.body["state"] = "error"
+			o.body["error_reason"] = reason
+			s.logConsole(o, "Kernel panic - not syncing: "+reason)
+			return
+		}
+		s.setInstanceState(o, desired)
+	})
+	return o
+}
+
+// setInstanceState sets the state of an instance, logging a login prompt to its console once it's running
+func (s *Server) setInstanceState(o *object, state string) {
+	o.body["state"] = state
+	if state == "running" {
+		s.logConsole(o, fmt.Sprintf("%s login:", o.body["hostname"]))
+	}
+}
+
+// createConsole creates the serial console of an instance, which is removed along with the instance
+func (s *Server) createConsole(instance *object) {
+	name := instance.body["name"].(string)
+	s.objects[instanceConsoleRoot+name] = &object{
+		root: instanceConsoleRoot,
+		body: map[string]interface{}{
+			"name":   name,
+			"output": "",
+			"uri":    s.URL + instanceConsoleRoot + name,
+		},
+	}
+	instance.children = append(instance.children, instanceConsoleRoot+name)
+	s.logConsole(instance, fmt.Sprintf("Booting %s", instance.body["hostname"]))
+}
+
+// logConsole appends a line to the serial console output of an instance
+func (s *Server) logConsole(instance *object, line string) {
+	console, ok := s.objects[instanceConsoleRoot+instance.body["name"].(string)]
+	if !ok {
+		return
+	}
+	console.body["output"] = fmt.Sprintf("%s%s\n", console.body["output"], line)
+	console.body["timestamp"] = time.Now().UTC().Format(time.RFC3339)
+}
+
+// createRebootRequest reboots a running instance. The request is `active` until it settles, when it's
+// `complete` and the instance is running again.
+func (s *Server) createRebootRequest(body map[string]interface{}) (interface{}, *apiError) {
+	name, _ := body["instance"].(string)
+	instance := s.lookup(instanceRoot, name)
+	if instance == nil {
+		return nil, errorf(http.StatusBadRequest, "Instance %s does not exist", name)
+	}
+	if instance.body["state"] != "running" {
+		return nil, errorf(http.StatusConflict, "Instance %s must be running to be rebooted, but is %s", name, instance.body["state"])
+	}
+
+	body["name"] = s.generateName(name)
+	body["instance_id"] = instance.body["id"]
+	body["request_state"] = "active"
+	o, err := s.insert(rebootRequestRoot, body)
+	if err != nil {
+		return nil, err
+	}
+
+	instance.body["state"] = "starting"
+	hard, _ := body["hard"].(bool)
+	if hard {
+		s.logConsole(instance, "Resetting")
+	} else {
+		s.logConsole(instance, "Rebooting")
+	}
+	s.settle(o, func() {
+		o.body["request_state"] = "complete"
+		s.setInstanceState(instance, "running")
+	})
+	return o.body, nil
+}
+
+func (s *Server) nextIPAddress() string {
+	s.addresses++
+	return fmt.Sprintf("10.%d.%d.%d", (s.addresses>>16)&0xff, (s.addresses>>8)&0xff, s.addresses&0xff)
+}
+
+// updateInstance starts or stops an instance, depending on the requested desired state
+func (s *Server) updateInstance(o *object, body map[string]interface{}) *apiError {
+	if tags, ok := body["tags"]; ok {
+		o.body["tags"] = tags
+	}
+	if shape, ok := body["shape"].(string); ok && shape != o.body["shape"] {
+		if o.body["state"] != "shutdown" {
+			return errorf(http.StatusConflict, "Instance %s must be shutdown to change its shape", o.body["name"])
+		}
+		o.body["shape"] = shape
+	}
+
+	desired, _ := body["desired_state"].(string)
+	switch desired {
+	case "":
+		return nil
+	case "running", "shutdown":
+	default:
+		return errorf(http.StatusBadRequest, "Invalid desired_state %q", desired)
+	}
+
+	o.body["desired_state"] = desired
+	if o.body["state"] == desired {
+		return nil
+	}
+	if desired == "running" {
+		o.body["state"] = "starting"
+	} else {
+		o.body["state"] = "stopping"
+	}
+	s.settle(o, func() {
+		s.setInstanceState(o, desired)
+	})
+	return nil
+}
+
+// deleteInstance stops an instance, removing it and its storage attachments once it settles.
+// Any snapshots of the instance delayed until shutdown are then completed.
+func (s *Server) deleteInstance(o *object, _ url.Values) *apiError {
+	name := o.body["name"].(string)
+	o.body["state"] = "stopping"
+	s.settle(o, func() {
+		s.remove(instanceRoot + name)
+		s.completeDelayedSnapshots(name)
+	})
+	return nil
+}
+
+// attach records a storage attachment on an instance, creating the attachment resource
+// in the `attached` state
+func (s *Server) attach(instance *object, volume string, index interface{}) *object {
+	instanceName := instance.body["name"].(string)
+	attachment, _ := s.insert(storageAttachmentRoot, map[string]interface{}{
+		"name":                s.generateName(instanceName),
+		"index":               index,
+		"instance_name":       instanceName,
+		"storage_volume_name": volume,
+		"state":               "attached",
+	})
+	s.addAttachment(instance, attachment)
+	return attachment
+}
+
+func (s *Server) addAttachment(instance *object, attachment *object) {
+	name := attachment.body["name"].(string)
+	storage, _ := instance.body["storage_attachments"].([]interface{})
+	instance.body["storage_attachments"] = append(storage, map[string]interface{}{
+		"index":               attachment.body["index"],
+		"name":                name,
+		"storage_volume_name": attachment.body["storage_volume_name"],
+	})
+	instance.children = append(instance.children, storageAttachmentRoot+name)
+}
+
+func (s *Server) removeAttachment(instance *object, attachment *object) {
+	name := attachment.body["name"].(string)
+
+	storage := []interface{}{}
+	existing, _ := instance.body["storage_attachments"].([]interface{})
+	for _, a := range existing {
+		if a.(map[string]interface{})["name"] != name {
+			storage = append(storage, a)
+		}
+	}
+	instance.body["storage_attachments"] = storage
+
+	children := []string{}
+	for _, child := range instance.children {
+		if child != storageAttachmentRoot+name {
+			children = append(children, child)
+		}
+	}
+	instance.children = children
+}
+
+// instanceAttachments returns the storage attachments of the instance with the given name
+func (s *Server) instanceAttachments(instance string) []*object {
+	attachments := []*object{}
+	for _, o := range s.objects {
+		if o.root == storageAttachmentRoot && o.body["instance_name"] == instance {
+			attachments = append(attachments, o)
+		}
+	}
+	return attachments
+}
+
+// volumeAttachment returns the attachment of the storage volume with the given name, if any
+func (s *Server) volumeAttachment(volume string) *object {
+	for _, o := range s.objects {
+		if o.root == storageAttachmentRoot && o.body["storage_volume_name"] == volume {
+			return o
+		}
+	}
+	return nil
+}
+
+// instanceNamed returns the instance created with the given name, excluding its ID
+func (s *Server) instanceNamed(name string) *object {
+	for key, o := range s.objects {
+		if o.root == instanceRoot && strings.HasPrefix(key, instanceRoot+name+"/") {
+			return o
+		}
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package computetest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// The transitional and final status of an orchestration, by desired state
+var orchestrationStatuses = map[string][2]string{
+	"active":   {"activating", "active"},
+	"inactive": {"deactivating", "inactive"},
+	"suspend":  {"suspending", "suspended"},
+}
+
+// createOrchestration adds an orchestration, which moves towards its desired state once it settles
+func (s *Server) createOrchestration(body map[string]interface{}) (interface{}, *apiError) {
+	desired, _ := body["desired_state"].(string)
+	if _, ok := orchestrationStatuses[desired]; !ok {
+		return nil, errorf(http.StatusBadRequest, "Invalid desired_state %q", desired)
+	}
+	if err := s.checkOrchestrationObjects(body); err != nil {
+		return nil, err
+	}
+
+	body["id"] = newID()
+	body["version"] = 1
+	body["user"] = s.userContainer()
+
+	o, err := s.insert(orchestrationRoot, body)
+	if err != nil {
+		return nil, err
+	}
+	s.transitionOrchestration(o, desired)
+	return o.body, nil
+}
+
+// updateOrchestration replaces the description, tags and objects of an orchestration,
+// moving it towards its new desired state
+func (s *Server) updateOrchestration(o *object, body map[string]interface{}) *apiError {
+	desired, _ := body["desired_state"].(string)
+	if _, ok := orchestrationStatuses[desired]; !ok {
+		return errorf(http.StatusBadRequest, "Invalid desired_state %q", desired)
+	}
+	if err := s.checkOrchestrationObjects(body); err != nil {
+		return err
+	}
+
+	for _, k := range []string{"description", "desired_state", "objects", "tags"} {
+		if v, ok := body[k]; ok {
+			o.body[k] = v
+		}
+	}
+	version, _ := o.body["version"].(int)
+	o.body["version"] = version + 1
+
+	s.transitionOrchestration(o, desired)
+	return nil
+}
+
+// deleteOrchestration stops an orchestration, removing it and the objects it created once
+// it settles. Orchestrations which are not inactive are only deleted when terminated.
+func (s *Server) deleteOrchestration(o *object, query url.Values) *apiError {
+	name := o.body["name"].(string)
+	if !strings.EqualFold(query.Get("terminate"), "true") && o.body["status"] != "inactive" {
+		return errorf(http.StatusConflict, "Orchestration %s must be inactive to be deleted", name)
+	}
+
+	s.setOrchestrationStatus(o, "stopping")
+	s.settle(o, func() {
+		s.remove(orchestrationRoot + name)
+	})
+	return nil
+}
+
+func (s *Server) checkOrchestrationObjects(body map[string]interface{}) *apiError {
+	objects, _ := body["objects"].([]interface{})
+	for _, obj := range objects {
+		m, ok := obj.(map[string]interface{})
+		if !ok {
+			return errorf(http.StatusBadRequest, "Invalid orchestration object: %v", obj)
+		}
+		if label, _ := m["label"].(string); label == "" {
+			return errorf(http.StatusBadRequest, "Orchestration objects require a label")
+		}
+		if m["type"] != "Instance" {
+			continue
+		}
+		template, ok := m["template"].(map[string]interface{})
+		if !ok {
+			return errorf(http.StatusBadRequest, "Instance object %s requires a template", m["label"])
+		}
+		if err := s.checkName(fmt.Sprint(template["name"])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Server) transitionOrchestration(o *object, desired string) {
+	statuses := orchestrationStatuses[desired]
+	s.setOrchestrationStatus(o, statuses[0])
+	s.settle(o, func() {
+		if desired == "active" {
+			s.activateOrchestration(o)
+		} else {
+			s.deactivateOrchestration(o, desired == "suspend")
+		}
+		s.setOrchestrationStatus(o, statuses[1])
+	})
+}
+
+// activateOrchestration launches any of the orchestration's instances which don't already exist
+func (s *Server) activateOrchestration(o *object) {
+	objects, _ := o.body["objects"].([]interface{})
+	for _, obj := range objects {
+		m := obj.(map[string]interface{})
+		if m["type"] != "Instance" {
+			continue
+		}
+		template := m["template"].(map[string]interface{})
+		if s.instanceNamed(template["name"].(string)) != nil {
+			continue
+		}
+
+		instance := s.launchInstance(copyBody(template))
+		instance.pending = nil
+		instance.body["state"] = instance.body["desired_state"]
+		o.children = append(o.children, instanceRoot+instance.body["name"].(string))
+	}
+}
+
+// deactivateOrchestration removes the objects created by the orchestration,
+// keeping persistent objects if the orchestration is being suspended
+func (s *Server) deactivateOrchestration(o *object, suspend bool) {
+	persistent := map[string]bool{}
+	if suspend {
+		objects, _ := o.body["objects"].([]interface{})
+		for _, obj := range objects {
+			m := obj.(map[string]interface{})
+			if p, _ := m["persistent"].(bool); p {
+				if template, ok := m["template"].(map[string]interface{}); ok {
+					persistent[fmt.Sprint(template["name"])] = true
+				}
+			}
+		}
+	}
+
+	children := []string{}
+	for _, child := range o.children {
+		name := child
+		if c, ok := s.objects[child]; ok {
+			name = strings.TrimSuffix(c.body["name"].(string), "/"+fmt.Sprint(c.body["id"]))
+		}
+		if persistent[name] {
+			children = append(children, child)
+			continue
+		}
+		s.remove(child)
+	}
+	o.children = children
+}
+
+// setOrchestrationStatus sets the status of the orchestration and the health of its objects
+func (s *Server) setOrchestrationStatus(o *object, status string) {
+	o.body["status"] = status
+	objects, _ := o.body["objects"].([]interface{})
+	for _, obj := range objects {
+		if m, ok := obj.(map[string]interface{}); ok {
+			m["health"] = map[string]interface{}{"status": status}
+		}
+	}
+}
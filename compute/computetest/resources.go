@@ -0,0 +1,190 @@
+package computetest
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+)
+
+// Resource root paths, as used by the compute package's resource clients
+const (
+	instanceRoot          = "/instance"
+	instanceConsoleRoot   = "/instanceconsole"
+	rebootRequestRoot     = "/rebootinstancerequest"
+	storageVolumeRoot     = "/storage/volume"
+	storageAttachmentRoot = "/storage/attachment"
+	storageSnapshotRoot   = "/storage/snapshot"
+	snapshotRoot          = "/snapshot"
+	machineImageRoot      = "/machineimage"
+	imageListRoot         = "/imagelist"
+	orchestrationRoot     = "/platform/v1/orchestration"
+	ipNetworkRoot         = "/network/v1/ipnetwork"
+	ipNetworkExchangeRoot = "/network/v1/ipnetworkexchange"
+	routeRoot             = "/network/v1/route"
+	vnicSetRoot           = "/network/v1/vnicset"
+	prefixSetRoot         = "/network/v1/ipaddressprefixset"
+	aclRoot               = "/network/v1/acl"
+	securityRuleRoot      = "/network/v1/secrule"
+	securityProtocolRoot  = "/network/v1/secprotocol"
+	secRuleRoot           = "/secrule"
+	secListRoot           = "/seclist"
+	secApplicationRoot    = "/secapplication"
+	secIPListRoot         = "/seciplist"
+	secAssociationRoot    = "/secassociation"
+	sshKeyRoot            = "/sshkey"
+
+	// Image list entries are stored under their image list, at /imagelist/{name}/entry/{version}.
+	// This root only identifies them, so they aren't listed as image lists.
+	imageListEntryRoot = "/imagelist/entry"
+)
+
+// collection describes how the server handles a single resource type.
+// Resources are created by a POST to createPath, and read, updated and deleted
+// at their fully qualified name under root, e.g. /storage/volume/Compute-domain/user/volume1.
+// Updates merge the request body into the stored resource, and deletes remove it
+// immediately, unless the collection overrides them.
+type collection struct {
+	description string
+	root        string
+	createPath  string
+	create      func(body map[string]interface{}) (interface{}, *apiError)
+	// Handles a POST under a resource's path, e.g. /imagelist/{name}/entry/, if set
+	createChild func(path string, body map[string]interface{}) (interface{}, *apiError)
+	update      func(o *object, body map[string]interface{}) *apiError
+	delete      func(o *object, query url.Values) *apiError
+}
+
+func (s *Server) newCollections() []*collection {
+	collections := []*collection{
+		{
+			description: "instance",
+			root:        instanceRoot,
+			createPath:  "/launchplan/",
+			create:      s.createLaunchPlan,
+			update:      s.updateInstance,
+			delete:      s.deleteInstance,
+		},
+		s.readOnlyCollection("instance console", instanceConsoleRoot),
+		{
+			description: "reboot instance request",
+			root:        rebootRequestRoot,
+			create:      s.createRebootRequest,
+		},
+		{
+			description: "storage volume",
+			root:        storageVolumeRoot,
+			create:      s.createStorageVolume,
+			update:      s.updateStorageVolume,
+			delete:      s.deleteStorageVolume,
+		},
+		{
+			description: "storage volume attachment",
+			root:        storageAttachmentRoot,
+			create:      s.createStorageAttachment,
+			delete:      s.deleteStorageAttachment,
+		},
+		{
+			description: "storage volume snapshot",
+			root:        storageSnapshotRoot,
+			create:      s.createStorageSnapshot,
+			delete:      s.deleteStorageSnapshot,
+		},
+		{
+			description: "snapshot",
+			root:        snapshotRoot,
+			create:      s.createSnapshot,
+		},
+		{
+			description: "orchestration",
+			root:        orchestrationRoot,
+			create:      s.createOrchestration,
+			update:      s.updateOrchestration,
+			delete:      s.deleteOrchestration,
+		},
+		{
+			description: "ip network",
+			root:        ipNetworkRoot,
+			create:      s.createIPNetwork,
+		},
+		{
+			description: "machine image",
+			root:        machineImageRoot,
+			create:      s.createMachineImage,
+		},
+		{
+			description: "image list",
+			root:        imageListRoot,
+			create:      s.createImageList,
+			createChild: s.createImageListEntry,
+			delete:      s.deleteImageList,
+		},
+		s.simpleCollection("ip network exchange", ipNetworkExchangeRoot),
+		s.simpleCollection("route", routeRoot),
+		s.simpleCollection("virtual nic set", vnicSetRoot),
+		s.simpleCollection("ip address prefix set", prefixSetRoot),
+		s.simpleCollection("acl", aclRoot),
+		s.simpleCollection("security rule", securityRuleRoot),
+		s.simpleCollection("security protocol", securityProtocolRoot),
+		s.simpleCollection("sec rule", secRuleRoot),
+		s.simpleCollection("security list", secListRoot),
+		s.simpleCollection("security application", secApplicationRoot),
+		s.simpleCollection("security ip list", secIPListRoot),
+		s.simpleCollection("security association", secAssociationRoot),
+		s.simpleCollection("ssh key", sshKeyRoot),
+	}
+
+	for _, c := range collections {
+		if c.createPath == "" {
+			c.createPath = c.root + "/"
+		}
+	}
+	return collections
+}
+
+// simpleCollection returns a collection whose resources are created synchronously,
+// without any further validation
+func (s *Server) simpleCollection(description, root string) *collection {
+	return &collection{
+		description: description,
+		root:        root,
+		create: func(body map[string]interface{}) (interface{}, *apiError) {
+			o, err := s.insert(root, body)
+			if err != nil {
+				return nil, err
+			}
+			return o.body, nil
+		},
+	}
+}
+
+// readOnlyCollection returns a collection whose resources are created and removed on behalf
+// of others, e.g. the console of an instance, and can't be changed through the API
+func (s *Server) readOnlyCollection(description, root string) *collection {
+	readOnly := errorf(http.StatusMethodNotAllowed, "%s resources can't be changed", description)
+	return &collection{
+		description: description,
+		root:        root,
+		create: func(map[string]interface{}) (interface{}, *apiError) {
+			return nil, readOnly
+		},
+		update: func(*object, map[string]interface{}) *apiError {
+			return readOnly
+		},
+		delete: func(*object, url.Values) *apiError {
+			return readOnly
+		},
+	}
+}
+
+func (s *Server) createIPNetwork(body map[string]interface{}) (interface{}, *apiError) {
+	prefix, _ := body["ipAddressPrefix"].(string)
+	if _, _, err := net.ParseCIDR(prefix); err != nil {
+		return nil, errorf(http.StatusBadRequest, "Invalid ipAddressPrefix %q: %s", prefix, err)
+	}
+
+	o, err := s.insert(ipNetworkRoot, body)
+	if err != nil {
+		return nil, err
+	}
+	return o.body, nil
+}
@@ -0,0 +1,433 @@
+// Package computetest provides an in-memory fake of the Oracle Compute Cloud Service
+// (Classic) API, for testing code built on the compute package without a cloud account.
+//
+// The fake authenticates clients with a session cookie, qualifies and validates
+// three-part object names (/Compute-identity_domain/user/object) like the real service,
+// and models the asynchronous state transitions polled for by the compute package's
+// WaitFor helpers:
+//
+//	server := computetest.NewServer()
+//	defer server.Close()
+//
+//	client, err := compute.NewComputeClient(server.Config())
+package computetest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-oracle-terraform/opc"
+)
+
+const (
+	// DefaultIdentityDomain is the identity domain a new Server accepts
+	DefaultIdentityDomain = "test-domain"
+	// DefaultUsername is the user name a new Server accepts
+	DefaultUsername = "test-user"
+	// DefaultPassword is the password a new Server accepts
+	DefaultPassword = "test-password"
+
+	authCookieName = "nimbula"
+	contentType    = "application/oracle-compute-v3+json"
+)
+
+// Server is a fake Compute API server. The identity domain, credentials, SettleReads,
+// InstanceErrors and MachineImageErrors may be changed after the server is created, but not while requests are in flight.
+type Server struct {
+	*httptest.Server
+
+	// The identity domain the server hosts
+	IdentityDomain string
+	// The user name accepted by /authenticate/
+	Username string
+	// The password accepted by /authenticate/
+	Password string
+	// The number of reads a resource in a transitional state (e.g. an instance which is
+	// starting) is returned for, before reaching its target state. Defaults to 1.
+	SettleReads int
+	// Instances launched with a three-part name in InstanceErrors settle in the `error`
+	// state rather than their desired state, with the value as the error reason.
+	InstanceErrors map[string]string
+	// Machine images created with a three-part name in MachineImageErrors settle in the
+	// `error` state rather than becoming available, with the value as the error reason.
+	MachineImageErrors map[string]string
+
+	mu          sync.Mutex
+	objects     map[string]*object
+	sessions    map[string]bool
+	collections []*collection
+	addresses   int
+}
+
+// object is a stored resource, kept in its JSON form
+type object struct {
+	root string
+	body map[string]interface{}
+	// The state transition to apply once the object has been read SettleReads times
+	pending *transition
+	// Keys of objects created on behalf of this one, e.g. an orchestration's instances
+	children []string
+}
+
+type transition struct {
+	reads int
+	apply func()
+}
+
+// apiError is an error response returned by the fake
+type apiError struct {
+	status  int
+	message string
+}
+
+func errorf(status int, format string, args ...interface{}) *apiError {
+	return &apiError{status: status, message: fmt.Sprintf(format, args...)}
+}
+
+// NewServer starts and returns a new fake Compute API server, accepting the
+// DefaultIdentityDomain, DefaultUsername and DefaultPassword credentials.
+// The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		IdentityDomain: DefaultIdentityDomain,
+		Username:       DefaultUsername,
+		Password:       DefaultPassword,
+		SettleReads:    1,
+		objects:        map[string]*object{},
+		sessions:       map[string]bool{},
+	}
+	s.collections = s.newCollections()
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// Config returns an opc.Config for a client authenticating against the server
+func (s *Server) Config() *opc.Config {
+	endpoint, err := url.Parse(s.URL)
+	if err != nil {
+		panic(err)
+	}
+	return &opc.Config{
+		IdentityDomain: opc.String(s.IdentityDomain),
+		Username:       opc.String(s.Username),
+		Password:       opc.String(s.Password),
+		APIEndpoint:    endpoint,
+		HTTPClient:     s.Client(),
+	}
+}
+
+// Resource returns a copy of the stored resource at the given path, e.g.
+// /storage/volume/Compute-test-domain/test-user/volume1, without advancing its state.
+func (s *Server) Resource(path string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.objects[path]
+	if !ok {
+		return nil, false
+	}
+	return copyBody(o.body), true
+}
+
+// SetResource overwrites the given fields of the stored resource at the given path,
+// cancelling any pending state transition. This can be used to simulate failures,
+// e.g. setting an instance's `state` to `error`.
+func (s *Server) SetResource(path string, fields map[string]interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.objects[path]
+	if !ok {
+		return fmt.Errorf("No resource found at %s", path)
+	}
+	for k, v := range fields {
+		o.body[k] = v
+	}
+	o.pending = nil
+	return nil
+}
+
+// ExpireSessions invalidates every authentication cookie issued by the server,
+// as if they had expired
+func (s *Server) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions = map[string]bool{}
+}
+
+// SetPassword changes the password accepted by the server, e.g. to simulate a
+// password rotation. Existing sessions remain valid until they're expired.
+func (s *Server) SetPassword(password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Password = password
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.URL.Path == "/authenticate/" {
+		s.authenticate(w, r)
+		return
+	}
+
+	cookie, err := r.Cookie(authCookieName)
+	if err != nil || !s.sessions[cookie.Value] {
+		writeError(w, errorf(http.StatusUnauthorized, "Unauthorized"))
+		return
+	}
+
+	var body map[string]interface{}
+	if r.Method == http.MethodPost || r.Method == http.MethodPut {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, errorf(http.StatusBadRequest, "Invalid request body: %s", err))
+			return
+		}
+	}
+
+	for _, c := range s.collections {
+		if r.URL.Path == c.createPath && r.Method == http.MethodPost {
+			s.respond(w, http.StatusCreated)(c.create(body))
+			return
+		}
+		if !strings.HasPrefix(r.URL.Path, c.root+"/") {
+			continue
+		}
+
+		name := strings.TrimPrefix(r.URL.Path, c.root)
+		switch {
+		case r.Method == http.MethodPost && c.createChild != nil:
+			s.respond(w, http.StatusCreated)(c.createChild(name, body))
+		case r.Method == http.MethodGet && strings.HasSuffix(name, "/"):
+			s.respond(w, http.StatusOK)(s.list(c, name), nil)
+		case r.Method == http.MethodGet:
+			s.respond(w, http.StatusOK)(s.get(c, name))
+		case r.Method == http.MethodPut:
+			s.respond(w, http.StatusOK)(s.update(c, name, body))
+		case r.Method == http.MethodDelete:
+			if err := s.delete(c, name, r.URL.Query()); err != nil {
+				writeError(w, err)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, errorf(http.StatusMethodNotAllowed, "Method %s not allowed", r.Method))
+		}
+		return
+	}
+
+	writeError(w, errorf(http.StatusNotFound, "No resource found at %s", r.URL.Path))
+}
+
+func (s *Server) authenticate(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		User     string `json:"user"`
+		Password string `json:"password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, errorf(http.StatusBadRequest, "Invalid request body: %s", err))
+		return
+	}
+	if req.User != s.userContainer() || req.Password != s.Password {
+		writeError(w, errorf(http.StatusUnauthorized, "Incorrect username or password"))
+		return
+	}
+
+	token := newID()
+	s.sessions[token] = true
+	http.SetCookie(w, &http.Cookie{Name: authCookieName, Value: token, Path: "/"})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) respond(w http.ResponseWriter, status int) func(interface{}, *apiError) {
+	return func(body interface{}, err *apiError) {
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(body)
+	}
+}
+
+func writeError(w http.ResponseWriter, err *apiError) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(err.status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"message": err.message})
+}
+
+func (s *Server) get(c *collection, name string) (interface{}, *apiError) {
+	o := s.read(c.root + name)
+	if o == nil {
+		return nil, errorf(http.StatusNotFound, "%s %s does not exist", c.description, name)
+	}
+	return o.body, nil
+}
+
+func (s *Server) list(c *collection, container string) interface{} {
+	keys := []string{}
+	for k, o := range s.objects {
+		if o.root == c.root && strings.HasPrefix(k, c.root+container) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	result := []interface{}{}
+	for _, k := range keys {
+		if o := s.read(k); o != nil {
+			result = append(result, o.body)
+		}
+	}
+	return map[string]interface{}{"result": result}
+}
+
+func (s *Server) update(c *collection, name string, body map[string]interface{}) (interface{}, *apiError) {
+	o := s.read(c.root + name)
+	if o == nil {
+		return nil, errorf(http.StatusNotFound, "%s %s does not exist", c.description, name)
+	}
+	if c.update != nil {
+		if err := c.update(o, body); err != nil {
+			return nil, err
+		}
+	} else {
+		for k, v := range body {
+			if k != "name" {
+				o.body[k] = v
+			}
+		}
+	}
+	return o.body, nil
+}
+
+func (s *Server) delete(c *collection, name string, query url.Values) *apiError {
+	key := c.root + name
+	o := s.read(key)
+	if o == nil {
+		return errorf(http.StatusNotFound, "%s %s does not exist", c.description, name)
+	}
+	if c.delete != nil {
+		return c.delete(o, query)
+	}
+	s.remove(key)
+	return nil
+}
+
+// read returns the object stored under the given key, first advancing its pending
+// state transition. nil is returned if the object doesn't exist, or the transition removed it.
+func (s *Server) read(key string) *object {
+	o, ok := s.objects[key]
+	if !ok {
+		return nil
+	}
+	if p := o.pending; p != nil {
+		if p.reads > 0 {
+			p.reads--
+		} else {
+			o.pending = nil
+			p.apply()
+		}
+	}
+	return s.objects[key]
+}
+
+// settle schedules apply to be run once the object has been read SettleReads times
+func (s *Server) settle(o *object, apply func()) {
+	o.pending = &transition{reads: s.SettleReads, apply: apply}
+}
+
+// insert validates the name of a new object and stores it, returning a conflict
+// if an object of the same type and name already exists.
+func (s *Server) insert(root string, body map[string]interface{}) (*object, *apiError) {
+	name, _ := body["name"].(string)
+	if err := s.checkName(name); err != nil {
+		return nil, err
+	}
+	key := root + name
+	if _, ok := s.objects[key]; ok {
+		return nil, errorf(http.StatusConflict, "Conflict: %s already exists", name)
+	}
+
+	body["uri"] = s.URL + key
+	o := &object{root: root, body: body}
+	s.objects[key] = o
+	return o, nil
+}
+
+// remove deletes the object stored under the given key, along with any objects created on its behalf
+func (s *Server) remove(key string) {
+	o, ok := s.objects[key]
+	if !ok {
+		return
+	}
+	delete(s.objects, key)
+	for _, child := range o.children {
+		s.remove(child)
+	}
+}
+
+// lookup returns the object of the given type and fully qualified name
+func (s *Server) lookup(root, name string) *object {
+	return s.objects[root+name]
+}
+
+// checkName validates that a name is a multi-part name in the server's identity domain,
+// e.g. /Compute-identity_domain/user/object
+func (s *Server) checkName(name string) *apiError {
+	parts := strings.Split(name, "/")
+	if len(parts) < 4 || parts[0] != "" || parts[2] == "" || parts[len(parts)-1] == "" {
+		return errorf(http.StatusBadRequest, "Invalid name %q: expected /Compute-identity_domain/user/object", name)
+	}
+	if parts[1] != "Compute-"+s.IdentityDomain {
+		return errorf(http.StatusBadRequest, "Invalid name %q: identity domain must be %s", name, s.IdentityDomain)
+	}
+	return nil
+}
+
+func (s *Server) userContainer() string {
+	return fmt.Sprintf("/Compute-%s/%s", s.IdentityDomain, s.Username)
+}
+
+// generateName returns a new name in the container of the given qualified name
+func (s *Server) generateName(owner string) string {
+	parts := strings.Split(owner, "/")
+	if len(parts) < 3 {
+		return fmt.Sprintf("%s/%s", s.userContainer(), newID())
+	}
+	return fmt.Sprintf("/%s/%s/%s", parts[1], parts[2], newID())
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	h := hex.EncodeToString(b)
+	return fmt.Sprintf("%s-%s-%s-%s-%s", h[0:8], h[8:12], h[12:16], h[16:20], h[20:])
+}
+
+func copyBody(body map[string]interface{}) map[string]interface{} {
+	b, err := json.Marshal(body)
+	if err != nil {
+		panic(err)
+	}
+	var c map[string]interface{}
+	if err := json.Unmarshal(b, &c); err != nil {
+		panic(err)
+	}
+	return c
+}
@@ -0,0 +1,442 @@
+package computetest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-oracle-terraform/compute"
+	"github.com/hashicorp/go-oracle-terraform/compute/computetest"
+	"github.com/hashicorp/go-oracle-terraform/opc"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	pollInterval = 10 * time.Millisecond
+	timeout      = 5 * time.Second
+)
+
+func getTestClient(t *testing.T) (*compute.Client, *computetest.Server) {
+	server := computetest.NewServer()
+	client, err := compute.NewComputeClient(server.Config())
+	if err != nil {
+		server.Close()
+		t.Fatalf("error creating client: %s", err)
+	}
+	return client, server
+}
+
+func TestServer_Authentication(t *testing.T) {
+	server := computetest.NewServer()
+	defer server.Close()
+
+	config := server.Config()
+	config.Password = opc.String("incorrect")
+	_, err := compute.NewComputeClient(config)
+	if !opc.IsAuthFailure(err) {
+		t.Fatalf("Expected authentication failure, got: %v", err)
+	}
+
+	// Requests without the authentication cookie are rejected
+	resp, err := server.Client().Get(server.URL + "/storage/volume/Compute-test-domain/test-user/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	assert.Equal(t, 401, resp.StatusCode)
+}
+
+func TestServer_PasswordRotation(t *testing.T) {
+	server := computetest.NewServer()
+	defer server.Close()
+
+	password := server.Password
+	config := server.Config()
+	config.Password = nil
+	config.CredentialsProvider = opc.CredentialsProviderFunc(func(ctx context.Context) (*opc.Credentials, error) {
+		return &opc.Credentials{Username: server.Username, Password: password}, nil
+	})
+	client, err := compute.NewComputeClient(config)
+	if err != nil {
+		t.Fatalf("error creating client: %s", err)
+	}
+	volumes := client.StorageVolumes()
+
+	input := &compute.CreateStorageVolumeInput{
+		Name:         "volume1",
+		Size:         "10",
+		PollInterval: pollInterval,
+		Timeout:      timeout,
+	}
+	if _, err := volumes.CreateStorageVolume(input); err != nil {
+		t.Fatalf("error creating storage volume: %s", err)
+	}
+
+	// The expired cookie is rejected, and the cached password when authenticating again
+	password = "rotated-password"
+	server.SetPassword(password)
+	server.ExpireSessions()
+
+	volume, err := volumes.GetStorageVolume(&compute.GetStorageVolumeInput{Name: "volume1"})
+	if err != nil {
+		t.Fatalf("error getting storage volume after password rotation: %s", err)
+	}
+	assert.Equal(t, "volume1", volume.Name)
+}
+
+func TestServer_StorageVolumeLifecycle(t *testing.T) {
+	client, server := getTestClient(t)
+	defer server.Close()
+	volumes := client.StorageVolumes()
+
+	created, err := volumes.CreateStorageVolume(&compute.CreateStorageVolumeInput{
+		Name:         "volume1",
+		Size:         "10",
+		Tags:         []string{"test"},
+		PollInterval: pollInterval,
+		Timeout:      timeout,
+	})
+	if err != nil {
+		t.Fatalf("error creating storage volume: %s", err)
+	}
+	assert.Equal(t, "volume1", created.Name)
+	assert.Equal(t, "/Compute-test-domain/test-user/volume1", created.FQDN)
+	assert.Equal(t, "Online", created.Status)
+	assert.Equal(t, "10", created.Size)
+
+	stored, ok := server.Resource("/storage/volume/Compute-test-domain/test-user/volume1")
+	if !ok {
+		t.Fatal("Expected storage volume to be stored")
+	}
+	assert.Equal(t, "10737418240", stored["size"])
+
+	_, err = volumes.CreateStorageVolume(&compute.CreateStorageVolumeInput{
+		Name:         "volume1",
+		Size:         "10",
+		PollInterval: pollInterval,
+		Timeout:      timeout,
+	})
+	if !opc.IsConflict(err) {
+		t.Fatalf("Expected conflict creating duplicate storage volume, got: %v", err)
+	}
+
+	_, err = volumes.CreateStorageVolume(&compute.CreateStorageVolumeInput{
+		Name: "/Compute-other-domain/test-user/volume2",
+		Size: "10",
+	})
+	if err == nil {
+		t.Fatal("Expected error creating storage volume in another identity domain")
+	}
+
+	updated, err := volumes.UpdateStorageVolume(&compute.UpdateStorageVolumeInput{
+		Name:         "volume1",
+		Size:         "20",
+		PollInterval: pollInterval,
+		Timeout:      timeout,
+	})
+	if err != nil {
+		t.Fatalf("error updating storage volume: %s", err)
+	}
+	assert.Equal(t, "20", updated.Size)
+
+	listed, err := volumes.ListStorageVolumes(&compute.ListInput{Tags: []string{"test"}})
+	if err != nil {
+		t.Fatalf("error listing storage volumes: %s", err)
+	}
+	assert.Len(t, listed, 1)
+
+	err = volumes.DeleteStorageVolume(&compute.DeleteStorageVolumeInput{
+		Name:         "volume1",
+		PollInterval: pollInterval,
+		Timeout:      timeout,
+	})
+	if err != nil {
+		t.Fatalf("error deleting storage volume: %s", err)
+	}
+
+	deleted, err := volumes.GetStorageVolume(&compute.GetStorageVolumeInput{Name: "volume1"})
+	if err != nil {
+		t.Fatalf("error getting storage volume: %s", err)
+	}
+	if deleted != nil {
+		t.Fatal("Expected storage volume to be deleted")
+	}
+}
+
+func TestServer_InstanceLifecycle(t *testing.T) {
+	client, server := getTestClient(t)
+	defer server.Close()
+	instances := client.Instances()
+	volumes := client.StorageVolumes()
+	attachments := client.StorageAttachments()
+
+	for _, name := range []string{"boot", "data"} {
+		_, err := volumes.CreateStorageVolume(&compute.CreateStorageVolumeInput{
+			Name:         name,
+			Size:         "10",
+			PollInterval: pollInterval,
+			Timeout:      timeout,
+		})
+		if err != nil {
+			t.Fatalf("error creating storage volume: %s", err)
+		}
+	}
+
+	instance, err := instances.CreateInstance(&compute.CreateInstanceInput{
+		Name:      "instance1",
+		Label:     "instance1",
+		Shape:     "oc3",
+		ImageList: "/oracle/public/OL_7.2_UEKR4_x86_64",
+		Storage: []compute.StorageAttachmentInput{
+			{Index: 1, Volume: "boot"},
+		},
+		BootOrder:    []int{1},
+		PollInterval: pollInterval,
+		Timeout:      timeout,
+	})
+	if err != nil {
+		t.Fatalf("error creating instance: %s", err)
+	}
+	assert.Equal(t, "instance1", instance.Name)
+	assert.Equal(t, compute.InstanceRunning, instance.State)
+	assert.Len(t, instance.Storage, 1)
+	assert.Equal(t, "boot", instance.Storage[0].StorageVolumeName)
+
+	attachment, err := attachments.CreateStorageAttachment(&compute.CreateStorageAttachmentInput{
+		Index:             2,
+		InstanceName:      instance.Name + "/" + instance.ID,
+		StorageVolumeName: "data",
+		PollInterval:      pollInterval,
+		Timeout:           timeout,
+	})
+	if err != nil {
+		t.Fatalf("error creating storage attachment: %s", err)
+	}
+	assert.Equal(t, compute.Attached, attachment.State)
+
+	err = volumes.DeleteStorageVolume(&compute.DeleteStorageVolumeInput{Name: "data"})
+	if !opc.IsConflict(err) {
+		t.Fatalf("Expected conflict deleting attached storage volume, got: %v", err)
+	}
+
+	stopped, err := instances.UpdateInstance(&compute.UpdateInstanceInput{
+		Name:         instance.Name,
+		ID:           instance.ID,
+		DesiredState: compute.InstanceDesiredShutdown,
+		PollInterval: pollInterval,
+		Timeout:      timeout,
+	})
+	if err != nil {
+		t.Fatalf("error stopping instance: %s", err)
+	}
+	assert.Equal(t, compute.InstanceShutdown, stopped.State)
+	assert.Len(t, stopped.Storage, 2)
+
+	err = attachments.DeleteStorageAttachment(&compute.DeleteStorageAttachmentInput{
+		Name:         attachment.Name,
+		PollInterval: pollInterval,
+		Timeout:      timeout,
+	})
+	if err != nil {
+		t.Fatalf("error deleting storage attachment: %s", err)
+	}
+
+	found, err := instances.GetInstanceFromName(&compute.GetInstanceIDInput{Name: "instance1"})
+	if err != nil {
+		t.Fatalf("error getting instance from name: %s", err)
+	}
+	assert.Equal(t, instance.ID, found.ID)
+	assert.Len(t, found.Storage, 1)
+
+	err = instances.DeleteInstance(&compute.DeleteInstanceInput{
+		Name:         instance.Name,
+		ID:           instance.ID,
+		PollInterval: pollInterval,
+		Timeout:      timeout,
+	})
+	if err != nil {
+		t.Fatalf("error deleting instance: %s", err)
+	}
+
+	// Deleting the instance removes its attachments, freeing the boot volume
+	err = volumes.DeleteStorageVolume(&compute.DeleteStorageVolumeInput{
+		Name:         "boot",
+		PollInterval: pollInterval,
+		Timeout:      timeout,
+	})
+	if err != nil {
+		t.Fatalf("error deleting storage volume: %s", err)
+	}
+}
+
+func TestServer_InstanceError(t *testing.T) {
+	client, server := getTestClient(t)
+	defer server.Close()
+	server.SettleReads = 3
+	instances := client.Instances()
+
+	info, err := instances.CreateInstance(&compute.CreateInstanceInput{
+		Name:         "instance1",
+		Label:        "instance1",
+		Shape:        "oc3",
+		PollInterval: pollInterval,
+		Timeout:      timeout,
+	})
+	if err != nil {
+		t.Fatalf("error creating instance: %s", err)
+	}
+
+	path := "/instance/Compute-test-domain/test-user/instance1/" + info.ID
+	if err := server.SetResource(path, map[string]interface{}{"state": "error", "error_reason": "failed"}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = instances.WaitForInstanceRunning(&compute.GetInstanceInput{Name: info.Name, ID: info.ID}, pollInterval, timeout)
+	if err == nil {
+		t.Fatal("Expected error waiting for failed instance")
+	}
+}
+
+func TestServer_Snapshots(t *testing.T) {
+	client, server := getTestClient(t)
+	defer server.Close()
+
+	instance, err := client.Instances().CreateInstance(&compute.CreateInstanceInput{
+		Name:         "instance1",
+		Label:        "instance1",
+		Shape:        "oc3",
+		PollInterval: pollInterval,
+		Timeout:      timeout,
+	})
+	if err != nil {
+		t.Fatalf("error creating instance: %s", err)
+	}
+
+	snapshot, err := client.Snapshots().CreateSnapshot(&compute.CreateSnapshotInput{
+		Instance:     instance.Name + "/" + instance.ID,
+		MachineImage: "image1",
+		PollInterval: pollInterval,
+		Timeout:      timeout,
+	})
+	if err != nil {
+		t.Fatalf("error creating snapshot: %s", err)
+	}
+	assert.Equal(t, compute.SnapshotComplete, snapshot.State)
+	assert.Equal(t, "image1", snapshot.MachineImage)
+
+	image, err := client.MachineImages().GetMachineImage(&compute.GetMachineImageInput{Name: "image1"})
+	if err != nil {
+		t.Fatalf("error getting machine image: %s", err)
+	}
+	assert.Equal(t, "available", image.State)
+
+	err = client.Snapshots().DeleteSnapshot(client.MachineImages(), &compute.DeleteSnapshotInput{
+		Snapshot:     snapshot.Name,
+		MachineImage: "image1",
+		PollInterval: pollInterval,
+		Timeout:      timeout,
+	})
+	if err != nil {
+		t.Fatalf("error deleting snapshot: %s", err)
+	}
+
+	_, ok := server.Resource("/machineimage/Compute-test-domain/test-user/image1")
+	assert.False(t, ok)
+}
+
+func TestServer_Networking(t *testing.T) {
+	client, server := getTestClient(t)
+	defer server.Close()
+
+	_, err := client.IPNetworks().CreateIPNetwork(&compute.CreateIPNetworkInput{
+		Name:            "network1",
+		IPAddressPrefix: "not-a-cidr",
+	})
+	if err == nil {
+		t.Fatal("Expected error creating IP network with an invalid prefix")
+	}
+
+	network, err := client.IPNetworks().CreateIPNetwork(&compute.CreateIPNetworkInput{
+		Name:            "network1",
+		IPAddressPrefix: "192.168.0.0/24",
+	})
+	if err != nil {
+		t.Fatalf("error creating ip network: %s", err)
+	}
+	assert.Equal(t, "network1", network.Name)
+	assert.Equal(t, "192.168.0.0/24", network.IPAddressPrefix)
+
+	rule, err := client.SecurityRules().CreateSecurityRule(&compute.CreateSecurityRuleInput{
+		Name:          "rule1",
+		FlowDirection: "ingress",
+		Enabled:       true,
+	})
+	if err != nil {
+		t.Fatalf("error creating security rule: %s", err)
+	}
+	assert.Equal(t, "rule1", rule.Name)
+
+	rules, err := client.SecurityRules().ListSecurityRules(nil)
+	if err != nil {
+		t.Fatalf("error listing security rules: %s", err)
+	}
+	assert.Len(t, rules, 1)
+
+	err = client.SecurityRules().DeleteSecurityRule(&compute.DeleteSecurityRuleInput{Name: "rule1"})
+	if err != nil {
+		t.Fatalf("error deleting security rule: %s", err)
+	}
+	_, err = client.SecurityRules().GetSecurityRule(&compute.GetSecurityRuleInput{Name: "rule1"})
+	if !opc.IsNotFound(err) {
+		t.Fatalf("Expected security rule to be deleted, got: %v", err)
+	}
+}
+
+func TestServer_Orchestrations(t *testing.T) {
+	client, server := getTestClient(t)
+	defer server.Close()
+	orchestrations := client.Orchestrations()
+
+	orchestration, err := orchestrations.CreateOrchestration(&compute.CreateOrchestrationInput{
+		Name:         "orchestration1",
+		DesiredState: compute.OrchestrationDesiredStateActive,
+		Objects: []compute.Object{
+			{
+				Label:         "instance1",
+				Orchestration: "orchestration1",
+				Type:          compute.OrchestrationTypeInstance,
+				Template: &compute.CreateInstanceInput{
+					Name:  "orchestrated",
+					Label: "orchestrated",
+					Shape: "oc3",
+				},
+			},
+		},
+		PollInterval: pollInterval,
+		Timeout:      timeout,
+	})
+	if err != nil {
+		t.Fatalf("error creating orchestration: %s", err)
+	}
+	assert.Equal(t, compute.OrchestrationStatusActive, orchestration.Status)
+
+	instance, err := client.Instances().GetInstanceFromName(&compute.GetInstanceIDInput{Name: "orchestrated"})
+	if err != nil {
+		t.Fatalf("error getting orchestrated instance: %s", err)
+	}
+	assert.Equal(t, compute.InstanceRunning, instance.State)
+
+	err = orchestrations.DeleteOrchestration(&compute.DeleteOrchestrationInput{
+		Name:         "orchestration1",
+		PollInterval: pollInterval,
+		Timeout:      timeout,
+	})
+	if err != nil {
+		t.Fatalf("error deleting orchestration: %s", err)
+	}
+
+	list, err := client.Instances().ListInstances(nil)
+	if err != nil {
+		t.Fatalf("error listing instances: %s", err)
+	}
+	assert.Len(t, list, 0)
+}
@@ -0,0 +1,64 @@
+package computetest
+
+import (
+	"net/http"
+	"time"
+)
+
+// createSnapshot snapshots an instance. The snapshot is `queued` until it settles,
+// when it becomes `complete` and its machine image is created. Snapshots delayed until
+// shutdown remain `active` until their instance is deleted.
+func (s *Server) createSnapshot(body map[string]interface{}) (interface{}, *apiError) {
+	instanceName, _ := body["instance"].(string)
+	if s.lookup(instanceRoot, instanceName) == nil {
+		return nil, errorf(http.StatusBadRequest, "Instance %s does not exist", instanceName)
+	}
+
+	name := s.generateName(instanceName)
+	body["name"] = name
+	if machineImage, _ := body["machineimage"].(string); machineImage == "" {
+		body["machineimage"] = name
+	}
+	if s.lookup(machineImageRoot, body["machineimage"].(string)) != nil {
+		return nil, errorf(http.StatusConflict, "Conflict: machine image %s already exists", body["machineimage"])
+	}
+	body["creation_time"] = time.Now().UTC().Format(time.RFC3339)
+
+	o, err := s.insert(snapshotRoot, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if body["delay"] == "shutdown" {
+		o.body["state"] = "active"
+		return o.body, nil
+	}
+
+	o.body["state"] = "queued"
+	s.settle(o, func() {
+		s.completeSnapshot(o)
+	})
+	return o.body, nil
+}
+
+// completeSnapshot completes a snapshot, creating its machine image
+func (s *Server) completeSnapshot(o *object) {
+	o.body["state"] = "complete"
+	_, _ = s.insert(machineImageRoot, map[string]interface{}{
+		"name":         o.body["machineimage"],
+		"account":      o.body["account"],
+		"state":        "available",
+		"image_format": "raw",
+		"no_upload":    true,
+	})
+}
+
+// completeDelayedSnapshots completes the snapshots of the given instance which were
+// delayed until it shut down
+func (s *Server) completeDelayedSnapshots(instance string) {
+	for _, o := range s.objects {
+		if o.root == snapshotRoot && o.body["instance"] == instance && o.body["state"] == "active" {
+			s.completeSnapshot(o)
+		}
+	}
+}
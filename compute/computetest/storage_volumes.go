@@ -0,0 +1,171 @@
+package computetest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// createStorageVolume creates a storage volume in the `Initializing` status,
+// which becomes `Online` once it settles
+func (s *Server) createStorageVolume(body map[string]interface{}) (interface{}, *apiError) {
+	size, err := volumeSize(body)
+	if err != nil {
+		return nil, err
+	}
+	body["size"] = strconv.FormatInt(size, 10)
+	body["status"] = "Initializing"
+
+	o, err := s.insert(storageVolumeRoot, body)
+	if err != nil {
+		return nil, err
+	}
+	s.settle(o, func() {
+		o.body["status"] = "Online"
+	})
+	return o.body, nil
+}
+
+// updateStorageVolume updates a storage volume, which is `Updating` until it settles.
+// Volumes can only be grown.
+func (s *Server) updateStorageVolume(o *object, body map[string]interface{}) *apiError {
+	if _, ok := body["size"]; ok {
+		size, err := volumeSize(body)
+		if err != nil {
+			return err
+		}
+		current, _ := strconv.ParseInt(o.body["size"].(string), 10, 64)
+		if size < current {
+			return errorf(http.StatusBadRequest, "Storage volume size can only be increased")
+		}
+		body["size"] = strconv.FormatInt(size, 10)
+	}
+
+	for k, v := range body {
+		if k != "name" {
+			o.body[k] = v
+		}
+	}
+	o.body["status"] = "Updating"
+	s.settle(o, func() {
+		o.body["status"] = "Online"
+	})
+	return nil
+}
+
+// deleteStorageVolume deletes an unattached storage volume once it settles
+func (s *Server) deleteStorageVolume(o *object, _ url.Values) *apiError {
+	name := o.body["name"].(string)
+	if s.volumeAttachment(name) != nil {
+		return errorf(http.StatusConflict, "Storage volume %s is attached to an instance", name)
+	}
+
+	o.body["status"] = "Deleting"
+	s.settle(o, func() {
+		s.remove(storageVolumeRoot + name)
+	})
+	return nil
+}
+
+// volumeSize returns the size in bytes requested for a storage volume
+func volumeSize(body map[string]interface{}) (int64, *apiError) {
+	var size int64
+	var err error
+	switch v := body["size"].(type) {
+	case string:
+		size, err = strconv.ParseInt(v, 10, 64)
+	case float64:
+		size = int64(v)
+	default:
+		err = fmt.Errorf("size is required")
+	}
+	if err != nil || size <= 0 {
+		return 0, errorf(http.StatusBadRequest, "Invalid storage volume size %v", body["size"])
+	}
+	return size, nil
+}
+
+// createStorageAttachment attaches a storage volume to an instance. The attachment
+// is `attaching` until it settles.
+func (s *Server) createStorageAttachment(body map[string]interface{}) (interface{}, *apiError) {
+	instanceName, _ := body["instance_name"].(string)
+	volume, _ := body["storage_volume_name"].(string)
+
+	instance := s.lookup(instanceRoot, instanceName)
+	if instance == nil {
+		return nil, errorf(http.StatusBadRequest, "Instance %s does not exist", instanceName)
+	}
+	if s.lookup(storageVolumeRoot, volume) == nil {
+		return nil, errorf(http.StatusBadRequest, "Storage volume %s does not exist", volume)
+	}
+	index, _ := body["index"].(float64)
+	if index < 1 || index > 10 {
+		return nil, errorf(http.StatusBadRequest, "Invalid index %v: must be between 1 and 10", body["index"])
+	}
+	if s.volumeAttachment(volume) != nil {
+		return nil, errorf(http.StatusConflict, "Storage volume %s is already attached", volume)
+	}
+	for _, a := range s.instanceAttachments(instanceName) {
+		if a.body["index"] == index {
+			return nil, errorf(http.StatusConflict, "Index %v is already in use on instance %s", index, instanceName)
+		}
+	}
+
+	attachment := s.attach(instance, volume, index)
+	attachment.body["state"] = "attaching"
+	s.settle(attachment, func() {
+		attachment.body["state"] = "attached"
+	})
+	return attachment.body, nil
+}
+
+// deleteStorageAttachment detaches a storage volume once the attachment settles
+func (s *Server) deleteStorageAttachment(o *object, _ url.Values) *apiError {
+	name := o.body["name"].(string)
+	o.body["state"] = "detaching"
+	s.settle(o, func() {
+		if instance := s.lookup(instanceRoot, o.body["instance_name"].(string)); instance != nil {
+			s.removeAttachment(instance, o)
+		}
+		s.remove(storageAttachmentRoot + name)
+	})
+	return nil
+}
+
+// createStorageSnapshot snapshots a storage volume. The snapshot is named after its
+// volume if no name is given, and is `inprogress` until it settles.
+func (s *Server) createStorageSnapshot(body map[string]interface{}) (interface{}, *apiError) {
+	volumeName, _ := body["volume"].(string)
+	volume := s.lookup(storageVolumeRoot, volumeName)
+	if volume == nil {
+		return nil, errorf(http.StatusBadRequest, "Storage volume %s does not exist", volumeName)
+	}
+
+	id := newID()
+	if name, _ := body["name"].(string); name == "" {
+		body["name"] = fmt.Sprintf("%s/%s", volumeName, id)
+	}
+	body["snapshot_id"] = id
+	body["size"] = volume.body["size"]
+	body["status"] = "inprogress"
+
+	o, err := s.insert(storageSnapshotRoot, body)
+	if err != nil {
+		return nil, err
+	}
+	s.settle(o, func() {
+		o.body["status"] = "completed"
+	})
+	return o.body, nil
+}
+
+// deleteStorageSnapshot deletes a storage volume snapshot once it settles
+func (s *Server) deleteStorageSnapshot(o *object, _ url.Values) *apiError {
+	name := o.body["name"].(string)
+	o.body["status"] = "deleting"
+	s.settle(o, func() {
+		s.remove(storageSnapshotRoot + name)
+	})
+	return nil
+}
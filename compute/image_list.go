@@ -120,6 +120,30 @@ func (c *ImageListClient) GetImageList(getInput *GetImageListInput) (*ImageList,
 	return c.success(&imageList)
 }
 
+// ListImageLists retrieves the image lists in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *ImageListClient) ListImageLists(input *ListInput) ([]ImageList, error) {
+	var list struct {
+		Result []ImageList `json:"result"`
+	}
+	if err := c.listResource(input, &list); err != nil {
+		return nil, err
+	}
+
+	imageLists := []ImageList{}
+	for i := range list.Result {
+		info, err := c.success(&list.Result[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, nil) {
+			imageLists = append(imageLists, *info)
+		}
+	}
+
+	return imageLists, nil
+}
+
 // UpdateImageListInput defines an Image List to be updated
 type UpdateImageListInput struct {
 	// The image list entry to be used, by default, when launching instances using this image list.
@@ -67,9 +67,9 @@ type CreateImageListEntryInput struct {
 // CreateImageListEntry creates a new Image List Entry from an ImageListEntriesClient and an input struct.
 // Returns a populated Info struct for the Image List Entry, and any errors
 func (c *ImageListEntriesClient) CreateImageListEntry(input *CreateImageListEntryInput) (*ImageListEntryInfo, error) {
-	c.updateClientPaths(input.Name, -1)
+	entries := c.entryPaths(input.Name, -1)
 	var imageListEntryInfo ImageListEntryInfo
-	if err := c.createResource(&input, &imageListEntryInfo); err != nil {
+	if err := entries.createResource(&input, &imageListEntryInfo); err != nil {
 		return nil, err
 	}
 	return c.success(&imageListEntryInfo)
@@ -85,9 +85,9 @@ type GetImageListEntryInput struct {
 
 // GetImageListEntry returns a populated ImageListEntryInfo struct from an input struct
 func (c *ImageListEntriesClient) GetImageListEntry(input *GetImageListEntryInput) (*ImageListEntryInfo, error) {
-	c.updateClientPaths(input.Name, input.Version)
+	entries := c.entryPaths(input.Name, input.Version)
 	var imageListEntryInfo ImageListEntryInfo
-	if err := c.getResource("", &imageListEntryInfo); err != nil {
+	if err := entries.getResource("", &imageListEntryInfo); err != nil {
 		return nil, err
 	}
 	return c.success(&imageListEntryInfo)
@@ -103,11 +103,13 @@ type DeleteImageListEntryInput struct {
 
 // DeleteImageListEntry deletes the specified image list entry
 func (c *ImageListEntriesClient) DeleteImageListEntry(input *DeleteImageListEntryInput) error {
-	c.updateClientPaths(input.Name, input.Version)
-	return c.deleteResource("")
+	entries := c.entryPaths(input.Name, input.Version)
+	return entries.deleteResource("")
 }
 
-func (c *ImageListEntriesClient) updateClientPaths(name string, version int) {
+// entryPaths returns a resource client addressing the entries of the given image list, or a single entry
+// if the version isn't -1
+func (c *ImageListEntriesClient) entryPaths(name string, version int) *ResourceClient {
 	var containerPath, resourcePath string
 	name = c.getQualifiedName(name)
 	containerPath = imageListEntryContainerPath + name + "/entry/"
@@ -116,8 +118,12 @@ func (c *ImageListEntriesClient) updateClientPaths(name string, version int) {
 		containerPath = fmt.Sprintf("%s%d", containerPath, version)
 		resourcePath = fmt.Sprintf("%s/%d", resourcePath, version)
 	}
-	c.ContainerPath = containerPath
-	c.ResourceRootPath = resourcePath
+	return &ResourceClient{
+		Client:              c.Client,
+		ResourceDescription: c.ResourceDescription,
+		ContainerPath:       containerPath,
+		ResourceRootPath:    resourcePath,
+	}
 }
 
 // Unqualifies any qualified fields in the IPNetworkInfo struct
@@ -1,8 +1,10 @@
 package compute
 
 import (
+	"fmt"
 	"log"
 	"reflect"
+	"sync"
 	"testing"
 
 	"github.com/hashicorp/go-oracle-terraform/helper"
@@ -81,3 +83,64 @@ func getImageListEntriesClient() (*ImageListEntriesClient, error) {
 	}
 	return client.ImageListEntries(), nil
 }
+
+// TestImageListEntriesClient_Concurrent adds entries to several image lists in parallel through a single
+// client, checking each entry is added to its own image list. Run with -race to check the client isn't
+// modified by its operations.
+func TestImageListEntriesClient_Concurrent(t *testing.T) {
+	client, _ := newFakeComputeClient(t)
+	names := make([]string, 10)
+	for i := range names {
+		names[i] = fmt.Sprintf("images-%d", i)
+		if _, err := client.ImageList().CreateImageList(&CreateImageListInput{Name: names[i], Default: 1}); err != nil {
+			t.Fatalf("error creating image list: %s", err)
+		}
+		_, err := client.MachineImages().CreateMachineImage(&CreateMachineImageInput{
+			Account: "/Compute-test-domain/cloud_storage",
+			Name:    names[i],
+			File:    names[i] + ".tar.gz",
+		})
+		if err != nil {
+			t.Fatalf("error creating machine image: %s", err)
+		}
+	}
+
+	entries := client.ImageListEntries()
+	var wg sync.WaitGroup
+	errs := make(chan error, len(names))
+	for _, name := range names {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			for version := 1; version <= 3; version++ {
+				_, err := entries.CreateImageListEntry(&CreateImageListEntryInput{
+					Name:          name,
+					MachineImages: []string{"/Compute-test-domain/test-user/" + name},
+					Version:       version,
+				})
+				if err != nil {
+					errs <- err
+					return
+				}
+				entry, err := entries.GetImageListEntry(&GetImageListEntryInput{Name: name, Version: version})
+				if err != nil {
+					errs <- err
+					return
+				}
+				if entry.Name != entries.getQualifiedName(name) || entry.Version != version {
+					errs <- fmt.Errorf("Expected version %d of %s, got version %d of %s", version, name, entry.Version, entry.Name)
+					return
+				}
+			}
+		}(name)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if entries.ContainerPath != imageListEntryContainerPath || entries.ResourceRootPath != imageListEntryResourcePath {
+		t.Fatalf("Expected the shared client's paths to be left unchanged, got %s and %s", entries.ContainerPath, entries.ResourceRootPath)
+	}
+}
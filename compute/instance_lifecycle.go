@@ -0,0 +1,313 @@
+package compute
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const waitForRebootRequestPollInterval = 10 * time.Second
+const waitForRebootRequestTimeout = 3600 * time.Second
+const waitForConsoleOutputPollInterval = 10 * time.Second
+const waitForConsoleOutputTimeout = 600 * time.Second
+
+// RebootRequestState specifies the constants that a reboot instance request state can be in
+type RebootRequestState string
+
+const (
+	// RebootRequestQueued - queued
+	RebootRequestQueued RebootRequestState = "queued"
+	// RebootRequestActive - active
+	RebootRequestActive RebootRequestState = "active"
+	// RebootRequestComplete - complete
+	RebootRequestComplete RebootRequestState = "complete"
+	// RebootRequestError - error
+	RebootRequestError RebootRequestState = "error"
+)
+
+// RebootInstanceRequest describes a request to reboot an instance
+type RebootInstanceRequest struct {
+	// Timestamp when this request was created
+	CreationTime string `json:"creation_time"`
+	// A description of the reason this request entered "error" state
+	ErrorReason string `json:"error_reason"`
+	// Fully Qualified Domain Name
+	FQDN string `json:"name"`
+	// Whether the instance was reset, rather than rebooted
+	Hard bool `json:"hard"`
+	// Multipart name of the instance being rebooted, including its ID
+	Instance string `json:"instance"`
+	// The ID of the instance being rebooted
+	InstanceID string `json:"instance_id"`
+	// The name of the reboot instance request
+	Name string
+	// The state of the request
+	State RebootRequestState `json:"request_state"`
+	// Uniform Resource Identifier
+	URI string `json:"uri"`
+}
+
+// RebootInstanceInput specifies the parameters needed to reboot an instance
+type RebootInstanceInput struct {
+	// The Unqualified Name of this Instance
+	// Required
+	Name string
+	// The Unqualified ID of this Instance
+	// Required
+	ID string
+	// Reset the instance, as if it was powered off and on, rather than rebooting it gracefully.
+	// Use this when the instance is unresponsive.
+	// Optional
+	Hard bool
+	// Time to wait between polls to check the reboot status
+	PollInterval time.Duration
+	// Time to wait for the instance to be running again
+	Timeout time.Duration
+}
+
+// rebootInstanceRequests returns a resource client for the reboot requests of instances
+func (c *InstancesClient) rebootInstanceRequests() *ResourceClient {
+	return &ResourceClient{
+		Client:              c.Client,
+		ResourceDescription: "reboot instance request",
+		ContainerPath:       "/rebootinstancerequest/",
+		ResourceRootPath:    "/rebootinstancerequest",
+	}
+}
+
+// RebootInstance reboots a running instance, waiting for the reboot request to complete and
+// the instance to be running again.
+func (c *InstancesClient) RebootInstance(input *RebootInstanceInput) (*InstanceInfo, error) {
+	if input.Name == "" || input.ID == "" {
+		return nil, errors.New("Both instance name and ID need to be specified")
+	}
+	requestBody := struct {
+		Hard     bool   `json:"hard"`
+		Instance string `json:"instance"`
+	}{
+		Hard:     input.Hard,
+		Instance: c.getQualifiedName(fmt.Sprintf(cmpQualifiedName, input.Name, input.ID)),
+	}
+
+	var request RebootInstanceRequest
+	if err := c.rebootInstanceRequests().createResource(&requestBody, &request); err != nil {
+		return nil, fmt.Errorf("Error rebooting instance %s: %w", input.Name, err)
+	}
+
+	if input.PollInterval == 0 {
+		input.PollInterval = waitForRebootRequestPollInterval
+	}
+	if input.Timeout == 0 {
+		input.Timeout = waitForRebootRequestTimeout
+	}
+
+	if _, err := c.WaitForRebootInstanceRequest(request.FQDN, input.PollInterval, input.Timeout); err != nil {
+		return nil, err
+	}
+
+	getInput := &GetInstanceInput{
+		Name: input.Name,
+		ID:   input.ID,
+	}
+	return c.WaitForInstanceRunning(getInput, input.PollInterval, input.Timeout)
+}
+
+// ResetInstance hard resets a running instance, as if it was powered off and on. This is the
+// same as RebootInstance with Hard set.
+func (c *InstancesClient) ResetInstance(input *RebootInstanceInput) (*InstanceInfo, error) {
+	input.Hard = true
+	return c.RebootInstance(input)
+}
+
+// GetRebootInstanceRequest retrieves the reboot instance request with the given name
+func (c *InstancesClient) GetRebootInstanceRequest(name string) (*RebootInstanceRequest, error) {
+	var request RebootInstanceRequest
+	if err := c.rebootInstanceRequests().getResource(name, &request); err != nil {
+		return nil, err
+	}
+	request.Name = c.getUnqualifiedName(request.FQDN)
+	request.Instance = c.getUnqualifiedName(request.Instance)
+	return &request, nil
+}
+
+// WaitForRebootInstanceRequest waits for a reboot instance request to complete
+func (c *InstancesClient) WaitForRebootInstanceRequest(name string, pollInterval, timeout time.Duration) (*RebootInstanceRequest, error) {
+	var info *RebootInstanceRequest
+	var getErr error
+	err := c.client.WaitFor("reboot instance request to complete", pollInterval, timeout, func() (bool, error) {
+		info, getErr = c.GetRebootInstanceRequest(name)
+		if getErr != nil {
+			return false, getErr
+		}
+		switch s := info.State; s {
+		case RebootRequestError:
+			return false, fmt.Errorf("Error rebooting instance %s: %s", info.Instance, info.ErrorReason)
+		case RebootRequestComplete: // Target State
+			c.client.DebugLogString("Reboot Request Complete")
+			return true, nil
+		case RebootRequestQueued:
+			c.client.DebugLogString("Reboot Request Queued")
+			return false, nil
+		case RebootRequestActive:
+			c.client.DebugLogString("Reboot Request Active")
+			return false, nil
+		default:
+			c.client.DebugLogString(fmt.Sprintf("Unknown reboot request state: %s, waiting", s))
+			return false, nil
+		}
+	})
+	return info, err
+}
+
+// ResizeInstanceInput specifies the parameters needed to change the shape of an instance
+type ResizeInstanceInput struct {
+	// The Unqualified Name of this Instance
+	// Required
+	Name string
+	// The Unqualified ID of this Instance
+	// Required
+	ID string
+	// The new shape of the instance, e.g. oc4
+	// Required
+	Shape string
+	// Time to wait between polls for instance state
+	PollInterval time.Duration
+	// Time to wait for each of the instance to shutdown, and to be running again
+	Timeout time.Duration
+}
+
+// ResizeInstance changes the shape of an instance. The shape can only be changed while the instance
+// is shutdown, so a running instance is shutdown, updated, then started again. An instance which is
+// already shutdown is left shutdown. Nothing is done if the instance already has the shape. If the
+// shape can't be changed, a running instance is still started again.
+func (c *InstancesClient) ResizeInstance(input *ResizeInstanceInput) (*InstanceInfo, error) {
+	if input.Name == "" || input.ID == "" {
+		return nil, errors.New("Both instance name and ID need to be specified")
+	}
+	if input.Shape == "" {
+		return nil, errors.New("A shape must be specified to resize an instance")
+	}
+
+	info, err := c.GetInstance(&GetInstanceInput{Name: input.Name, ID: input.ID})
+	if err != nil {
+		return nil, err
+	}
+	if info.Shape == input.Shape {
+		return info, nil
+	}
+	restart := info.DesiredState == InstanceDesiredRunning
+
+	// UpdateInstance qualifies the name of its input, so each update gets a new one
+	update := func(shape string, state InstanceDesiredState) (*InstanceInfo, error) {
+		return c.UpdateInstance(&UpdateInstanceInput{
+			Name:         input.Name,
+			ID:           input.ID,
+			Shape:        shape,
+			DesiredState: state,
+			PollInterval: input.PollInterval,
+			Timeout:      input.Timeout,
+		})
+	}
+
+	if restart {
+		if _, err := update("", InstanceDesiredShutdown); err != nil {
+			return nil, fmt.Errorf("Error shutting down instance %s to resize it: %w", input.Name, err)
+		}
+	}
+	info, err = update(input.Shape, InstanceDesiredShutdown)
+	if err != nil {
+		err = fmt.Errorf("Error resizing instance %s to %s: %w", input.Name, input.Shape, err)
+		// Don't leave an instance shutdown which was running before the resize
+		if restart {
+			if _, startErr := update("", InstanceDesiredRunning); startErr != nil {
+				return nil, fmt.Errorf("%w, and error starting it again: %s", err, startErr)
+			}
+		}
+		return nil, err
+	}
+	if !restart {
+		return info, nil
+	}
+	info, err = update("", InstanceDesiredRunning)
+	if err != nil {
+		return nil, fmt.Errorf("Error starting instance %s after resizing it: %w", input.Name, err)
+	}
+	return info, nil
+}
+
+// InstanceConsole describes the serial console output of an instance
+type InstanceConsole struct {
+	// Multipart name of the instance, including its ID
+	Name string `json:"name"`
+	// The serial console output of the instance, from when it was last booted
+	Output string `json:"output"`
+	// Timestamp when the output was last updated
+	Timestamp string `json:"timestamp"`
+}
+
+// instanceConsoles returns a resource client for the serial consoles of instances
+func (c *InstancesClient) instanceConsoles() *ResourceClient {
+	return &ResourceClient{
+		Client:              c.Client,
+		ResourceDescription: "instance console",
+		ContainerPath:       "/instanceconsole/",
+		ResourceRootPath:    "/instanceconsole",
+	}
+}
+
+// GetInstanceConsoleOutput retrieves the serial console output of an instance, useful to
+// find out why an instance fails to boot.
+func (c *InstancesClient) GetInstanceConsoleOutput(input *GetInstanceInput) (*InstanceConsole, error) {
+	if input.ID == "" || input.Name == "" {
+		return nil, errors.New("Both instance name and ID need to be specified")
+	}
+
+	var console InstanceConsole
+	if err := c.instanceConsoles().getResource(input.String(), &console); err != nil {
+		return nil, err
+	}
+	console.Name = c.getUnqualifiedName(console.Name)
+	return &console, nil
+}
+
+// WaitForInstanceConsoleOutput waits for the serial console output of an instance to contain the given text,
+// e.g. a login prompt showing the instance has booted.
+func (c *InstancesClient) WaitForInstanceConsoleOutput(input *GetInstanceInput, contains string, pollInterval, timeout time.Duration) (*InstanceConsole, error) {
+	if pollInterval == 0 {
+		pollInterval = waitForConsoleOutputPollInterval
+	}
+	if timeout == 0 {
+		timeout = waitForConsoleOutputTimeout
+	}
+
+	var console *InstanceConsole
+	var getErr error
+	err := c.client.WaitFor(fmt.Sprintf("instance console output to contain %q", contains), pollInterval, timeout, func() (bool, error) {
+		console, getErr = c.GetInstanceConsoleOutput(input)
+		if getErr != nil {
+			return false, getErr
+		}
+		return strings.Contains(console.Output, contains), nil
+	})
+	return console, err
+}
+
+// VNCAddress returns the host and port of the instance's VNC console, e.g. to tunnel to it through an SSH
+// connection to the instance. An error is returned if the instance has no VNC console, or its address is invalid.
+func (i *InstanceInfo) VNCAddress() (string, int, error) {
+	if i.VNC == "" {
+		return "", 0, fmt.Errorf("Instance %s has no VNC console", i.Name)
+	}
+	host, port, err := net.SplitHostPort(i.VNC)
+	if err != nil {
+		return "", 0, fmt.Errorf("Invalid VNC console address %q for instance %s: %w", i.VNC, i.Name, err)
+	}
+	portNumber, err := strconv.Atoi(port)
+	if err != nil {
+		return "", 0, fmt.Errorf("Invalid VNC console port %q for instance %s: %w", port, i.Name, err)
+	}
+	return host, portNumber, nil
+}
@@ -0,0 +1,164 @@
+package compute
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-oracle-terraform/compute/computetest"
+	"github.com/hashicorp/go-oracle-terraform/opc"
+)
+
+func launchLifecycleTestInstance(t *testing.T, instances *InstancesClient) *InstanceInfo {
+	info, err := instances.CreateInstance(&CreateInstanceInput{
+		Name:         "node1",
+		Label:        "node1",
+		Shape:        "oc3",
+		PollInterval: 10 * time.Millisecond,
+		Timeout:      5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("error creating instance: %s", err)
+	}
+	return info
+}
+
+func TestInstancesClient_RebootInstance(t *testing.T) {
+	client, server := newFakeComputeClient(t)
+	instances := client.Instances()
+	info := launchLifecycleTestInstance(t, instances)
+	getInput := &GetInstanceInput{Name: info.Name, ID: info.ID}
+
+	rebooted, err := instances.ResetInstance(&RebootInstanceInput{
+		Name:         info.Name,
+		ID:           info.ID,
+		PollInterval: 10 * time.Millisecond,
+		Timeout:      5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("error resetting instance: %s", err)
+	}
+	if rebooted.State != InstanceRunning {
+		t.Fatalf("Expected the instance to be running after a reset, got %s", rebooted.State)
+	}
+
+	console, err := instances.WaitForInstanceConsoleOutput(getInput, "Resetting", 10*time.Millisecond, 5*time.Second)
+	if err != nil {
+		t.Fatalf("error waiting for console output: %s", err)
+	}
+	if !strings.HasPrefix(console.Output, "Booting") || strings.Count(console.Output, "login:") != 2 {
+		t.Fatalf("Expected the console to show the instance booting twice, got %q", console.Output)
+	}
+	if console.Name != info.Name+"/"+info.ID {
+		t.Fatalf("Expected the console of %s/%s, got %s", info.Name, info.ID, console.Name)
+	}
+
+	// An instance which isn't running can't be rebooted
+	path := "/instance/Compute-test-domain/test-user/" + info.Name + "/" + info.ID
+	if err := server.SetResource(path, map[string]interface{}{"state": "shutdown"}); err != nil {
+		t.Fatalf("error shutting down instance: %s", err)
+	}
+	if _, err := instances.RebootInstance(&RebootInstanceInput{Name: info.Name, ID: info.ID}); err == nil {
+		t.Fatal("Expected an error rebooting an instance which is shutdown")
+	}
+}
+
+func TestInstancesClient_ResizeInstance(t *testing.T) {
+	client, server := newFakeComputeClient(t)
+	instances := client.Instances()
+	info := launchLifecycleTestInstance(t, instances)
+
+	input := &ResizeInstanceInput{
+		Name:         info.Name,
+		ID:           info.ID,
+		Shape:        "oc5",
+		PollInterval: 10 * time.Millisecond,
+		Timeout:      5 * time.Second,
+	}
+	resized, err := instances.ResizeInstance(input)
+	if err != nil {
+		t.Fatalf("error resizing instance: %s", err)
+	}
+	if resized.Shape != "oc5" || resized.State != InstanceRunning {
+		t.Fatalf("Expected a running oc5 instance, got %s %s", resized.State, resized.Shape)
+	}
+
+	// The shape of a running instance can't be changed directly
+	if _, err := instances.UpdateInstance(&UpdateInstanceInput{Name: info.Name, ID: info.ID, Shape: "oc3"}); err == nil {
+		t.Fatal("Expected an error changing the shape of a running instance")
+	}
+
+	path := "/instance/Compute-test-domain/test-user/" + info.Name + "/" + info.ID
+	if err := server.SetResource(path, map[string]interface{}{"vnc": "192.0.2.10:5900"}); err != nil {
+		t.Fatalf("error setting VNC address: %s", err)
+	}
+	info, err = instances.GetInstance(&GetInstanceInput{Name: info.Name, ID: info.ID})
+	if err != nil {
+		t.Fatalf("error getting instance: %s", err)
+	}
+	host, port, err := info.VNCAddress()
+	if err != nil || host != "192.0.2.10" || port != 5900 {
+		t.Fatalf("Expected VNC console at 192.0.2.10:5900, got %s:%d (%v)", host, port, err)
+	}
+}
+
+func TestInstancesClient_ResizeInstanceRestartsOnError(t *testing.T) {
+	server := computetest.NewServer()
+	t.Cleanup(server.Close)
+
+	// Reject any change of shape, as the service does for shapes which aren't available
+	config := server.Config()
+	config.Middleware = []opc.Middleware{func(next http.RoundTripper) http.RoundTripper {
+		return opc.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Method != "PUT" || req.Body == nil {
+				return next.RoundTrip(req)
+			}
+			body, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			var update map[string]interface{}
+			if err := json.Unmarshal(body, &update); err != nil {
+				return nil, err
+			}
+			if shape, _ := update["shape"].(string); shape == "" {
+				req.Body = ioutil.NopCloser(strings.NewReader(string(body)))
+				return next.RoundTrip(req)
+			}
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       ioutil.NopCloser(strings.NewReader(`{"message": "Shape oc5 is not available"}`)),
+				Request:    req,
+			}, nil
+		})
+	}}
+	client, err := NewComputeClient(config)
+	if err != nil {
+		t.Fatalf("error creating client: %s", err)
+	}
+	instances := client.Instances()
+	info := launchLifecycleTestInstance(t, instances)
+
+	_, err = instances.ResizeInstance(&ResizeInstanceInput{
+		Name:         info.Name,
+		ID:           info.ID,
+		Shape:        "oc5",
+		PollInterval: 10 * time.Millisecond,
+		Timeout:      5 * time.Second,
+	})
+	if err == nil || !strings.Contains(err.Error(), "Shape oc5 is not available") {
+		t.Fatalf("Expected an error resizing the instance, got %v", err)
+	}
+
+	info, err = instances.GetInstance(&GetInstanceInput{Name: info.Name, ID: info.ID})
+	if err != nil {
+		t.Fatalf("error getting instance: %s", err)
+	}
+	if info.Shape != "oc3" || info.DesiredState != InstanceDesiredRunning {
+		t.Fatalf("Expected the oc3 instance to be started again, got %s %s", info.DesiredState, info.Shape)
+	}
+}
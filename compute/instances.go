@@ -236,6 +236,8 @@ type CreateInstanceInput struct {
 	// A list of tags to be supplied to the instance
 	// Optional
 	Tags []string `json:"tags"`
+	// Time to wait between polls to check whether the instance is ready
+	PollInterval time.Duration `json:"-"`
 	// Time to wait for an instance to be ready
 	Timeout time.Duration `json:"-"`
 }
@@ -337,6 +339,32 @@ type LaunchPlanResponse struct {
 
 // CreateInstance creates and submits a LaunchPlan to launch a new instance.
 func (c *InstancesClient) CreateInstance(input *CreateInstanceInput) (*InstanceInfo, error) {
+	c.qualifyInstanceInput(input)
+
+	plan := LaunchPlanInput{
+		Instances:    []CreateInstanceInput{*input},
+		PollInterval: input.PollInterval,
+		Timeout:      input.Timeout,
+	}
+
+	var (
+		instanceInfo  *InstanceInfo
+		instanceError error
+	)
+	for i := 0; i < *c.Client.client.MaxRetries; i++ {
+		c.client.DebugLogString(fmt.Sprintf("(Iteration: %d of %d) Creating instance with name %s\n Plan: %+v", i, *c.Client.client.MaxRetries, input.Name, plan))
+
+		instanceInfo, instanceError = c.startInstance(input.Name, plan)
+		if instanceError == nil {
+			c.client.DebugLogString(fmt.Sprintf("(Iteration: %d of %d) Finished creating instance with name %s\n Info: %+v", i, *c.Client.client.MaxRetries, input.Name, instanceInfo))
+			return instanceInfo, nil
+		}
+	}
+	return nil, instanceError
+}
+
+// qualifyInstanceInput qualifies the names of the instance, and the objects it references, in a launch plan entry
+func (c *InstancesClient) qualifyInstanceInput(input *CreateInstanceInput) {
 	qualifiedSSHKeys := []string{}
 	for _, key := range input.SSHKeys {
 		qualifiedSSHKeys = append(qualifiedSSHKeys, c.getQualifiedName(key))
@@ -356,26 +384,6 @@ func (c *InstancesClient) CreateInstance(input *CreateInstanceInput) (*InstanceI
 	input.Networking = c.qualifyNetworking(input.Networking)
 
 	input.Name = c.getQualifiedName(input.Name)
-
-	plan := LaunchPlanInput{
-		Instances: []CreateInstanceInput{*input},
-		Timeout:   input.Timeout,
-	}
-
-	var (
-		instanceInfo  *InstanceInfo
-		instanceError error
-	)
-	for i := 0; i < *c.Client.client.MaxRetries; i++ {
-		c.client.DebugLogString(fmt.Sprintf("(Iteration: %d of %d) Creating instance with name %s\n Plan: %+v", i, *c.Client.client.MaxRetries, input.Name, plan))
-
-		instanceInfo, instanceError = c.startInstance(input.Name, plan)
-		if instanceError == nil {
-			c.client.DebugLogString(fmt.Sprintf("(Iteration: %d of %d) Finished creating instance with name %s\n Info: %+v", i, *c.Client.client.MaxRetries, input.Name, instanceInfo))
-			return instanceInfo, nil
-		}
-	}
-	return nil, instanceError
 }
 
 func (c *InstancesClient) startInstance(name string, plan LaunchPlanInput) (*InstanceInfo, error) {
@@ -449,29 +457,55 @@ func (c *InstancesClient) GetInstance(input *GetInstanceInput) (*InstanceInfo, e
 		return nil, fmt.Errorf("Empty response body when requesting instance %s", input.Name)
 	}
 
+	return c.success(&responseBody)
+}
+
+// ListInstances retrieves the instances in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *InstancesClient) ListInstances(input *ListInput) ([]InstanceInfo, error) {
+	var instancesInfo InstancesInfo
+	if err := c.listResource(input, &instancesInfo); err != nil {
+		return nil, err
+	}
+
+	instances := []InstanceInfo{}
+	for i := range instancesInfo.Instances {
+		info, err := c.success(&instancesInfo.Instances[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, info.Tags) {
+			instances = append(instances, *info)
+		}
+	}
+
+	return instances, nil
+}
+
+func (c *InstancesClient) success(info *InstanceInfo) (*InstanceInfo, error) {
 	// The returned 'Name' attribute is the fully qualified instance name + "/" + ID
 	// Split these out to accurately populate the fields
-	nID := strings.Split(c.getUnqualifiedName(responseBody.FQDN), "/")
-	responseBody.Name = strings.Join(nID[0:len(nID)-1], "/")
-	responseBody.ID = nID[len(nID)-1]
+	nID := strings.Split(c.getUnqualifiedName(info.FQDN), "/")
+	info.Name = strings.Join(nID[0:len(nID)-1], "/")
+	info.ID = nID[len(nID)-1]
 
-	c.unqualify(&responseBody.VCableID)
+	c.unqualify(&info.VCableID)
 
 	// Unqualify SSH Key names
 	sshKeyNames := []string{}
-	for _, sshKeyRef := range responseBody.SSHKeys {
+	for _, sshKeyRef := range info.SSHKeys {
 		sshKeyNames = append(sshKeyNames, c.getUnqualifiedName(sshKeyRef))
 	}
-	responseBody.SSHKeys = sshKeyNames
+	info.SSHKeys = sshKeyNames
 
 	var networkingErr error
-	responseBody.Networking, networkingErr = c.unqualifyNetworking(responseBody.Networking)
+	info.Networking, networkingErr = c.unqualifyNetworking(info.Networking)
 	if networkingErr != nil {
 		return nil, networkingErr
 	}
-	responseBody.Storage = c.unqualifyStorage(responseBody.Storage)
+	info.Storage = c.unqualifyStorage(info.Storage)
 
-	return &responseBody, nil
+	return info, nil
 }
 
 // InstancesInfo specifies a list of instances
@@ -501,29 +535,7 @@ func (c *InstancesClient) GetInstanceFromName(input *GetInstanceIDInput) (*Insta
 				return nil, fmt.Errorf("Empty response body when requesting instance %s", input.Name)
 			}
 
-			// The returned 'Name' attribute is the fully qualified instance name + "/" + ID
-			// Split these out to accurately populate the fields
-			nID := strings.Split(c.getUnqualifiedName(i.FQDN), "/")
-			i.Name = strings.Join(nID[0:len(nID)-1], "/")
-			i.ID = nID[len(nID)-1]
-
-			c.unqualify(&i.VCableID)
-
-			// Unqualify SSH Key names
-			sshKeyNames := []string{}
-			for _, sshKeyRef := range i.SSHKeys {
-				sshKeyNames = append(sshKeyNames, c.getUnqualifiedName(sshKeyRef))
-			}
-			i.SSHKeys = sshKeyNames
-
-			var networkingErr error
-			i.Networking, networkingErr = c.unqualifyNetworking(i.Networking)
-			if networkingErr != nil {
-				return nil, networkingErr
-			}
-			i.Storage = c.unqualifyStorage(i.Storage)
-
-			return &i, nil
+			return c.success(&i)
 		}
 	}
 
@@ -545,6 +557,10 @@ type UpdateInstanceInput struct {
 	// A list of tags to be supplied to the instance
 	// Optional
 	Tags []string `json:"tags,omitempty"`
+	// The new shape of the instance. Can only be changed while the instance is shutdown,
+	// see ResizeInstance.
+	// Optional
+	Shape string `json:"shape,omitempty"`
 	// Time to wait between polls for instance state
 	PollInterval time.Duration `json:"-"`
 	// Time to wait for instance to be ready, or shutdown depending on desired state
@@ -198,6 +198,50 @@ func TestInstanceClient_RetrieveInstance(t *testing.T) {
 	}
 }
 
+// Test that the client can list and filter instances.
+func TestInstanceClient_ListInstances(t *testing.T) {
+	server := newAuthenticatingServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Wrong HTTP method %s, expected GET", r.Method)
+		}
+
+		expectedPath := "/instance/Compute-test/test/"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Wrong HTTP URL %v, expected %v", r.URL, expectedPath)
+		}
+
+		w.Write([]byte(exampleListInstancesResponse))
+	})
+
+	defer server.Close()
+	iv, err := getStubInstancesClient(server)
+	if err != nil {
+		t.Fatalf("err getting stub client: %s", err)
+	}
+
+	all, err := iv.ListInstances(nil)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("Expected 2 instances, got %d", len(all))
+	}
+	if all[0].Name != "web-1" || all[0].ID != "id-1" {
+		t.Errorf("Expected name 'web-1' and ID 'id-1', got %s and %s", all[0].Name, all[0].ID)
+	}
+
+	filtered, err := iv.ListInstances(&ListInput{
+		NamePrefix: "db",
+		Tags:       []string{"prod"},
+	})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(filtered) != 1 || filtered[0].Name != "db-1" {
+		t.Fatalf("Expected only instance 'db-1', got %+v", filtered)
+	}
+}
+
 func getStubInstancesClient(server *httptest.Server) (*InstancesClient, error) {
 	endpoint, err := url.Parse(server.URL)
 	if err != nil {
@@ -467,3 +511,22 @@ var exampleErrorRetrieveResponse = `
 "boot_order": []
 }
 `
+
+var exampleListInstancesResponse = `
+{
+  "result": [
+    {
+      "name": "/Compute-test/test/web-1/id-1",
+      "state": "running",
+      "shape": "oc3",
+      "tags": ["prod"]
+    },
+    {
+      "name": "/Compute-test/test/db-1/id-2",
+      "state": "running",
+      "shape": "oc4",
+      "tags": ["prod", "db"]
+    }
+  ]
+}
+`
@@ -101,6 +101,30 @@ func (c *IPAddressAssociationsClient) GetIPAddressAssociation(input *GetIPAddres
 	return c.success(&ipInfo)
 }
 
+// ListIPAddressAssociations retrieves the IP address associations in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *IPAddressAssociationsClient) ListIPAddressAssociations(input *ListInput) ([]IPAddressAssociationInfo, error) {
+	var list struct {
+		Result []IPAddressAssociationInfo `json:"result"`
+	}
+	if err := c.listResource(input, &list); err != nil {
+		return nil, err
+	}
+
+	associations := []IPAddressAssociationInfo{}
+	for i := range list.Result {
+		info, err := c.success(&list.Result[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, info.Tags) {
+			associations = append(associations, *info)
+		}
+	}
+
+	return associations, nil
+}
+
 // DeleteIPAddressAssociationInput details the parameters neccessary to delete an ip address association
 type DeleteIPAddressAssociationInput struct {
 	// The name of the IP Address Association to query for. Case-sensitive
@@ -93,6 +93,30 @@ func (c *IPAddressPrefixSetsClient) GetIPAddressPrefixSet(input *GetIPAddressPre
 	return c.success(&ipInfo)
 }
 
+// ListIPAddressPrefixSets retrieves the IP address prefix sets in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *IPAddressPrefixSetsClient) ListIPAddressPrefixSets(input *ListInput) ([]IPAddressPrefixSetInfo, error) {
+	var list struct {
+		Result []IPAddressPrefixSetInfo `json:"result"`
+	}
+	if err := c.listResource(input, &list); err != nil {
+		return nil, err
+	}
+
+	prefixSets := []IPAddressPrefixSetInfo{}
+	for i := range list.Result {
+		info, err := c.success(&list.Result[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, info.Tags) {
+			prefixSets = append(prefixSets, *info)
+		}
+	}
+
+	return prefixSets, nil
+}
+
 // UpdateIPAddressPrefixSetInput defines what to update in a ip address prefix set
 type UpdateIPAddressPrefixSetInput struct {
 	// The name of the IP Address Prefix Set to create. Object names can only contain alphanumeric,
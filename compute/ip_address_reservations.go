@@ -122,6 +122,30 @@ func (c *IPAddressReservationsClient) GetIPAddressReservation(input *GetIPAddres
 	return c.success(&ipAddrRes)
 }
 
+// ListIPAddressReservations retrieves the IP address reservations in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *IPAddressReservationsClient) ListIPAddressReservations(input *ListInput) ([]IPAddressReservation, error) {
+	var list struct {
+		Result []IPAddressReservation `json:"result"`
+	}
+	if err := c.listResource(input, &list); err != nil {
+		return nil, err
+	}
+
+	reservations := []IPAddressReservation{}
+	for i := range list.Result {
+		info, err := c.success(&list.Result[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, info.Tags) {
+			reservations = append(reservations, *info)
+		}
+	}
+
+	return reservations, nil
+}
+
 // UpdateIPAddressReservationInput details the parameters to update an IP Address reservation
 type UpdateIPAddressReservationInput struct {
 	// Description of the IP Address Reservation
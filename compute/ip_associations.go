@@ -90,6 +90,30 @@ func (c *IPAssociationsClient) GetIPAssociation(input *GetIPAssociationInput) (*
 	return c.success(&assocInfo)
 }
 
+// ListIPAssociations retrieves the IP associations in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *IPAssociationsClient) ListIPAssociations(input *ListInput) ([]IPAssociationInfo, error) {
+	var list struct {
+		Result []IPAssociationInfo `json:"result"`
+	}
+	if err := c.listResource(input, &list); err != nil {
+		return nil, err
+	}
+
+	associations := []IPAssociationInfo{}
+	for i := range list.Result {
+		info, err := c.success(&list.Result[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, nil) {
+			associations = append(associations, *info)
+		}
+	}
+
+	return associations, nil
+}
+
 // DeleteIPAssociationInput details the attributes neccessary to delete an ip association
 type DeleteIPAssociationInput struct {
 	// The three-part name of the IP Association
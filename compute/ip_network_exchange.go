@@ -87,6 +87,30 @@ func (c *IPNetworkExchangesClient) GetIPNetworkExchange(input *GetIPNetworkExcha
 	return c.success(&ipInfo)
 }
 
+// ListIPNetworkExchanges retrieves the IP network exchanges in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *IPNetworkExchangesClient) ListIPNetworkExchanges(input *ListInput) ([]IPNetworkExchangeInfo, error) {
+	var list struct {
+		Result []IPNetworkExchangeInfo `json:"result"`
+	}
+	if err := c.listResource(input, &list); err != nil {
+		return nil, err
+	}
+
+	exchanges := []IPNetworkExchangeInfo{}
+	for i := range list.Result {
+		info, err := c.success(&list.Result[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, info.Tags) {
+			exchanges = append(exchanges, *info)
+		}
+	}
+
+	return exchanges, nil
+}
+
 // DeleteIPNetworkExchangeInput details the attributes neccessary to delete an ip network exchange
 type DeleteIPNetworkExchangeInput struct {
 	// The name of the IP Network Exchange to query for. Case-sensitive
@@ -120,6 +120,30 @@ func (c *IPNetworksClient) GetIPNetwork(input *GetIPNetworkInput) (*IPNetworkInf
 	return c.success(&ipInfo)
 }
 
+// ListIPNetworks retrieves the IP networks in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *IPNetworksClient) ListIPNetworks(input *ListInput) ([]IPNetworkInfo, error) {
+	var list struct {
+		Result []IPNetworkInfo `json:"result"`
+	}
+	if err := c.listResource(input, &list); err != nil {
+		return nil, err
+	}
+
+	networks := []IPNetworkInfo{}
+	for i := range list.Result {
+		info, err := c.success(&list.Result[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, info.Tags) {
+			networks = append(networks, *info)
+		}
+	}
+
+	return networks, nil
+}
+
 // UpdateIPNetworkInput details the attributes needed to update an ip network
 type UpdateIPNetworkInput struct {
 	// The name of the IP Network to update. Object names can only contain alphanumeric,
@@ -103,6 +103,30 @@ func (c *IPReservationsClient) GetIPReservation(input *GetIPReservationInput) (*
 	return c.success(&ipInput)
 }
 
+// ListIPReservations retrieves the IP reservations in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *IPReservationsClient) ListIPReservations(input *ListInput) ([]IPReservation, error) {
+	var list struct {
+		Result []IPReservation `json:"result"`
+	}
+	if err := c.listResource(input, &list); err != nil {
+		return nil, err
+	}
+
+	reservations := []IPReservation{}
+	for i := range list.Result {
+		info, err := c.success(&list.Result[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, info.Tags) {
+			reservations = append(reservations, *info)
+		}
+	}
+
+	return reservations, nil
+}
+
 // UpdateIPReservationInput defines an IP Reservation to be updated
 type UpdateIPReservationInput struct {
 	// The name of the object
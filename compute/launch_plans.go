@@ -0,0 +1,235 @@
+package compute
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LaunchPlanFailurePolicy specifies what to do with the instances of a launch plan which
+// launched successfully, when others in the plan fail
+type LaunchPlanFailurePolicy string
+
+const (
+	// LaunchPlanPartialSuccess - keep the instances which launched successfully, reporting which failed
+	LaunchPlanPartialSuccess LaunchPlanFailurePolicy = "partial"
+	// LaunchPlanRollback - delete the instances which launched successfully, so either all instances launch or none do
+	LaunchPlanRollback LaunchPlanFailurePolicy = "rollback"
+)
+
+// LaunchInstancesInput defines a launch plan of several instances, to be launched together
+type LaunchInstancesInput struct {
+	// The instances to launch. Each must have a unique name.
+	// Required
+	Instances []CreateInstanceInput
+	// What to do with the instances which launched successfully if others fail.
+	// Optional - Defaults to LaunchPlanPartialSuccess
+	OnFailure LaunchPlanFailurePolicy
+	// Time to wait between polls to check whether the instances are ready, or deleted
+	PollInterval time.Duration
+	// Time to wait for the instances to be ready, or deleted
+	Timeout time.Duration
+}
+
+// LaunchInstanceResult details the outcome of launching a single instance of a launch plan
+type LaunchInstanceResult struct {
+	// The Unqualified Name of the instance, as given in the launch plan
+	Name string
+	// The ID of the launched instance, empty if the instance was never launched
+	ID string
+	// The running instance, nil if the instance failed to launch or was rolled back
+	Instance *InstanceInfo
+	// The error launching the instance, nil if it launched successfully
+	Error error
+	// Whether the instance was deleted after launching, either because it failed or was rolled back
+	Deleted bool
+}
+
+// LaunchInstancesOutput details the outcome of launching each instance of a launch plan
+type LaunchInstancesOutput struct {
+	// The result of each instance, in the order of the launch plan
+	Results []LaunchInstanceResult
+	// Whether the instances which launched successfully were deleted, as others in the plan failed
+	RolledBack bool
+}
+
+// Failed returns the results of the instances which failed to launch
+func (o *LaunchInstancesOutput) Failed() []LaunchInstanceResult {
+	failed := []LaunchInstanceResult{}
+	for _, result := range o.Results {
+		if result.Error != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Running returns the instances which launched successfully and are still running
+func (o *LaunchInstancesOutput) Running() []InstanceInfo {
+	running := []InstanceInfo{}
+	for _, result := range o.Results {
+		if result.Instance != nil {
+			running = append(running, *result.Instance)
+		}
+	}
+	return running
+}
+
+// LaunchPlanError is returned when some of the instances of a launch plan fail to launch.
+// The LaunchInstancesOutput is returned alongside it, with the result of every instance.
+type LaunchPlanError struct {
+	// The results of the instances which failed to launch
+	Failed []LaunchInstanceResult
+	// The number of instances in the launch plan
+	Total int
+	// Whether the instances which launched successfully were deleted
+	RolledBack bool
+}
+
+func (e *LaunchPlanError) Error() string {
+	failures := []string{}
+	for _, result := range e.Failed {
+		failures = append(failures, fmt.Sprintf("%s: %s", result.Name, result.Error))
+	}
+	msg := fmt.Sprintf("%d of %d instances failed to launch", len(e.Failed), e.Total)
+	if e.RolledBack {
+		msg += ", the remaining instances were deleted"
+	}
+	return fmt.Sprintf("%s: %s", msg, strings.Join(failures, "; "))
+}
+
+// LaunchInstances submits a single launch plan for all the instances in the input, then waits for
+// them to be ready concurrently. Instances which fail to launch are deleted. If any instance fails, the
+// result of every instance is returned along with a *LaunchPlanError, and the instances which launched
+// successfully are either kept or deleted, depending on the OnFailure policy of the input.
+func (c *InstancesClient) LaunchInstances(input *LaunchInstancesInput) (*LaunchInstancesOutput, error) {
+	if len(input.Instances) == 0 {
+		return nil, errors.New("A launch plan must contain at least one instance")
+	}
+	switch input.OnFailure {
+	case "":
+		input.OnFailure = LaunchPlanPartialSuccess
+	case LaunchPlanPartialSuccess, LaunchPlanRollback:
+	default:
+		return nil, fmt.Errorf("Unknown launch plan failure policy %q", input.OnFailure)
+	}
+	if input.PollInterval == 0 {
+		input.PollInterval = waitForInstanceReadyPollInterval
+	}
+	if input.Timeout == 0 {
+		input.Timeout = waitForInstanceReadyTimeout
+	}
+
+	output := &LaunchInstancesOutput{
+		Results: make([]LaunchInstanceResult, len(input.Instances)),
+	}
+	plan := LaunchPlanInput{
+		Instances:    make([]CreateInstanceInput, len(input.Instances)),
+		PollInterval: input.PollInterval,
+		Timeout:      input.Timeout,
+	}
+	indexes := map[string]int{}
+	for i, instance := range input.Instances {
+		if _, ok := indexes[instance.Name]; ok {
+			return nil, fmt.Errorf("Instance %q appears more than once in the launch plan", instance.Name)
+		}
+		indexes[instance.Name] = i
+		output.Results[i].Name = instance.Name
+
+		c.qualifyInstanceInput(&instance)
+		plan.Instances[i] = instance
+	}
+
+	var responseBody LaunchPlanResponse
+	if err := c.createResource(&plan, &responseBody); err != nil {
+		return nil, err
+	}
+
+	// Match the launched instances to the plan by name, as the launched instance's name includes its ID.
+	// success sets the name and ID before it can fail, so an instance it fails for is still waited for,
+	// and deleted if it doesn't become ready.
+	for i := range responseBody.Instances {
+		launched := &responseBody.Instances[i]
+		_, err := c.success(launched)
+		if j, ok := indexes[launched.Name]; ok {
+			output.Results[j].ID = launched.ID
+			output.Results[j].Error = err
+		}
+	}
+
+	deleteInput := func(result *LaunchInstanceResult) *DeleteInstanceInput {
+		return &DeleteInstanceInput{
+			Name:         result.Name,
+			ID:           result.ID,
+			PollInterval: input.PollInterval,
+			Timeout:      input.Timeout,
+		}
+	}
+	c.forEachLaunched(output, func(result *LaunchInstanceResult) {
+		getInput := &GetInstanceInput{
+			Name: result.Name,
+			ID:   result.ID,
+		}
+		result.Instance, result.Error = c.WaitForInstanceRunning(getInput, input.PollInterval, input.Timeout)
+		if result.Error == nil {
+			return
+		}
+		// The instance has failed, so delete it rather than leave it behind
+		result.Instance = nil
+		if err := c.DeleteInstance(deleteInput(result)); err != nil {
+			result.Error = fmt.Errorf("%s, and deleting the instance failed: %s", result.Error, err)
+			return
+		}
+		result.Deleted = true
+	})
+	for i := range output.Results {
+		if output.Results[i].ID == "" {
+			output.Results[i].Error = errors.New("The instance was not launched")
+		}
+	}
+
+	failed := output.Failed()
+	if len(failed) == 0 {
+		return output, nil
+	}
+
+	if input.OnFailure == LaunchPlanRollback {
+		c.forEachLaunched(output, func(result *LaunchInstanceResult) {
+			if result.Instance == nil {
+				return
+			}
+			if err := c.DeleteInstance(deleteInput(result)); err != nil {
+				result.Error = fmt.Errorf("Error rolling back instance: %s", err)
+				return
+			}
+			result.Instance = nil
+			result.Deleted = true
+		})
+		output.RolledBack = true
+		failed = output.Failed()
+	}
+
+	return output, &LaunchPlanError{
+		Failed:     failed,
+		Total:      len(output.Results),
+		RolledBack: output.RolledBack,
+	}
+}
+
+// forEachLaunched calls fn concurrently for the result of each instance which was launched, returning once all have finished
+func (c *InstancesClient) forEachLaunched(output *LaunchInstancesOutput, fn func(result *LaunchInstanceResult)) {
+	var wg sync.WaitGroup
+	for i := range output.Results {
+		if output.Results[i].ID == "" {
+			continue
+		}
+		wg.Add(1)
+		go func(result *LaunchInstanceResult) {
+			defer wg.Done()
+			fn(result)
+		}(&output.Results[i])
+	}
+	wg.Wait()
+}
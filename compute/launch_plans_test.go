@@ -0,0 +1,141 @@
+package compute
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-oracle-terraform/compute/computetest"
+	"github.com/hashicorp/go-oracle-terraform/opc"
+)
+
+func launchPlanTestInput(onFailure LaunchPlanFailurePolicy) *LaunchInstancesInput {
+	return &LaunchInstancesInput{
+		Instances: []CreateInstanceInput{
+			{Name: "node1", Label: "node1", Shape: "oc3"},
+			{Name: "node2", Label: "node2", Shape: "oc3"},
+			{Name: "node3", Label: "node3", Shape: "oc3"},
+		},
+		OnFailure:    onFailure,
+		PollInterval: 10 * time.Millisecond,
+		Timeout:      5 * time.Second,
+	}
+}
+
+func TestInstancesClient_LaunchInstances(t *testing.T) {
+	client, _ := newFakeComputeClient(t)
+	instances := client.Instances()
+
+	output, err := instances.LaunchInstances(launchPlanTestInput(""))
+	if err != nil {
+		t.Fatalf("error launching instances: %s", err)
+	}
+	if len(output.Running()) != 3 {
+		t.Fatalf("Expected 3 running instances, got %+v", output.Results)
+	}
+	for i, result := range output.Results {
+		if result.Instance.Name != launchPlanTestInput("").Instances[i].Name || result.Instance.State != InstanceRunning {
+			t.Fatalf("Unexpected result for instance %d: %+v", i, result)
+		}
+	}
+}
+
+func TestInstancesClient_LaunchInstancesPartialFailure(t *testing.T) {
+	client, server := newFakeComputeClient(t)
+	instances := client.Instances()
+	server.InstanceErrors = map[string]string{"/Compute-test-domain/test-user/node2": "Out of capacity"}
+
+	output, err := instances.LaunchInstances(launchPlanTestInput(LaunchPlanPartialSuccess))
+	var planErr *LaunchPlanError
+	if !errors.As(err, &planErr) || len(planErr.Failed) != 1 || planErr.Failed[0].Name != "node2" {
+		t.Fatalf("Expected node2 to fail, got %v", err)
+	}
+	if !output.Results[1].Deleted || output.RolledBack {
+		t.Fatalf("Expected only the failed instance to be deleted, got %+v", output)
+	}
+	if len(output.Running()) != 2 {
+		t.Fatalf("Expected 2 running instances, got %+v", output.Results)
+	}
+
+	list, err := instances.ListInstances(&ListInput{})
+	if err != nil {
+		t.Fatalf("error listing instances: %s", err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("Expected 2 instances to remain, got %d", len(list))
+	}
+}
+
+func TestInstancesClient_LaunchInstancesRollback(t *testing.T) {
+	client, server := newFakeComputeClient(t)
+	instances := client.Instances()
+	server.InstanceErrors = map[string]string{"/Compute-test-domain/test-user/node3": "Out of capacity"}
+
+	output, err := instances.LaunchInstances(launchPlanTestInput(LaunchPlanRollback))
+	var planErr *LaunchPlanError
+	if !errors.As(err, &planErr) || !planErr.RolledBack {
+		t.Fatalf("Expected the launch plan to be rolled back, got %v", err)
+	}
+	for _, result := range output.Results {
+		if !result.Deleted || result.Instance != nil {
+			t.Fatalf("Expected every instance to be deleted, got %+v", result)
+		}
+	}
+
+	list, err := instances.ListInstances(&ListInput{})
+	if err != nil {
+		t.Fatalf("error listing instances: %s", err)
+	}
+	if len(list) != 0 {
+		t.Fatalf("Expected no instances to remain, got %d", len(list))
+	}
+}
+
+func TestInstancesClient_LaunchInstancesInvalidResponse(t *testing.T) {
+	server := computetest.NewServer()
+	t.Cleanup(server.Close)
+
+	// Give node2 a NAT the client can't unqualify in the launch plan response only
+	config := server.Config()
+	config.Middleware = []opc.Middleware{func(next http.RoundTripper) http.RoundTripper {
+		return opc.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.RoundTrip(req)
+			if err != nil || req.Method != "POST" || req.URL.Path != "/launchplan/" {
+				return resp, err
+			}
+			var plan map[string][]map[string]interface{}
+			if err := json.NewDecoder(resp.Body).Decode(&plan); err != nil {
+				return nil, err
+			}
+			resp.Body.Close()
+			plan["instances"][1]["networking"] = map[string]interface{}{"eth0": map[string]interface{}{"nat": []string{"invalid"}}}
+			body, err := json.Marshal(plan)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+			resp.ContentLength = int64(len(body))
+			return resp, nil
+		})
+	}}
+	client, err := NewComputeClient(config)
+	if err != nil {
+		t.Fatalf("error creating client: %s", err)
+	}
+	instances := client.Instances()
+
+	// node2 is still waited for, and becomes ready once it's read from the server
+	output, err := instances.LaunchInstances(launchPlanTestInput(LaunchPlanRollback))
+	if err != nil {
+		t.Fatalf("error launching instances: %s", err)
+	}
+	for _, result := range output.Results {
+		if result.ID == "" || result.Instance == nil || result.Instance.State != InstanceRunning {
+			t.Fatalf("Expected every instance to be running, got %+v", result)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package compute
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ListInput specifies the parameters used to list the resources of a given type.
+// A nil ListInput lists every resource in the authenticated user's container.
+type ListInput struct {
+	// The container to list resources from, either a user container such as
+	// /Compute-identity_domain/user or the identity domain container /Compute-identity_domain.
+	// Defaults to the authenticated user's container.
+	// Optional
+	Container string
+	// Only resources whose name, relative to the container, begins with this prefix are returned.
+	// Optional
+	NamePrefix string
+	// Only resources carrying every one of these tags are returned.
+	// Resources which do not support tags never match a tag filter.
+	// Optional
+	Tags []string
+}
+
+// listResource retrieves every resource of the client's type in the container
+// described by the input. Results are decoded into responseBody, which is expected
+// to hold the returned objects in a `result` array.
+func (c *ResourceClient) listResource(input *ListInput, responseBody interface{}) error {
+	path := fmt.Sprintf("%s%s/", c.ResourceRootPath, c.getListContainer(input))
+	resp, err := c.executeRequest("GET", path, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.unmarshalResponseBody(resp, responseBody)
+}
+
+func (c *ResourceClient) getListContainer(input *ListInput) string {
+	if input == nil || input.Container == "" {
+		return c.getUserName()
+	}
+	container := strings.TrimSuffix(input.Container, "/")
+	if !strings.HasPrefix(container, "/") {
+		container = "/" + container
+	}
+	return container
+}
+
+// listMatches returns true if a listed resource satisfies the name prefix and
+// tag filters of the input.
+func (c *ResourceClient) listMatches(input *ListInput, name string, tags []string) bool {
+	if input == nil {
+		return true
+	}
+
+	if input.NamePrefix != "" {
+		// Names of resources owned by the authenticated user are unqualified by the time
+		// they're filtered, so requalify them before trimming the container.
+		relativeName := strings.TrimPrefix(c.getQualifiedName(name), c.getListContainer(input)+"/")
+		if !strings.HasPrefix(relativeName, input.NamePrefix) {
+			return false
+		}
+	}
+
+	for _, tag := range input.Tags {
+		found := false
+		for _, t := range tags {
+			if t == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
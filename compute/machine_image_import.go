@@ -0,0 +1,235 @@
+package compute
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/hashicorp/go-oracle-terraform/client"
+	"github.com/hashicorp/go-oracle-terraform/storage"
+)
+
+const waitForMachineImageAvailablePollInterval = 10 * time.Second
+const waitForMachineImageAvailableTimeout = 1800 * time.Second
+
+const (
+	// MachineImageAvailable - the machine image is available to launch instances from
+	MachineImageAvailable = "available"
+	// MachineImageError - the machine image could not be registered, see its ErrorReason
+	MachineImageError = "error"
+
+	// DefaultMachineImageContainer is the storage container machine image files are uploaded to
+	DefaultMachineImageContainer = "compute_images"
+	// defaultMachineImageAccount is the storage account, in the identity domain, of machine image files
+	defaultMachineImageAccount = "cloud_storage"
+)
+
+// ImportMachineImageInput defines a machine image to be imported from an image file,
+// optionally adding it to an image list
+type ImportMachineImageInput struct {
+	// Name of the machine image
+	// Required
+	Name string
+	// Stream to read the image file from, a .tar.gz archive of the image. The stream is
+	// read once, and uploaded in segments if it's larger than a single segment.
+	// Required
+	Body io.Reader
+	// Name of the image file in the storage container.
+	// Optional - Defaults to the name of the machine image with a `.tar.gz` suffix
+	File string
+	// Name of the storage container to upload the image file to.
+	// Optional - Defaults to DefaultMachineImageContainer
+	Container string
+	// The storage account of the container, e.g. /Compute-identity_domain/cloud_storage
+	// Optional - Defaults to the cloud_storage account of the identity domain
+	Account string
+	// Size of each segment of the image file in bytes, see storage.UploadObjectInput
+	// Optional - Defaults to storage.DefaultSegmentSize
+	SegmentSize int64
+	// The number of segments to upload in parallel
+	// Optional - Defaults to storage.DefaultUploadConcurrency
+	Concurrency int
+	// Describing the image
+	// Optional
+	Description string
+	// Dictionary of attributes to be made available to the instance
+	// Optional
+	Attributes map[string]interface{}
+	// Name of the image list to add the machine image to, as a new entry. The image list is
+	// created if it doesn't exist.
+	// Optional - The machine image isn't added to an image list if unset
+	ImageList string
+	// A description of the image list, if it's created.
+	// Optional - Defaults to the description of the machine image
+	ImageListDescription string
+	// User-defined parameters passed to instances launched from the image list entry
+	// Optional
+	EntryAttributes map[string]interface{}
+	// Make the new entry the default of the image list
+	// Optional
+	SetDefault bool
+	// Time to wait between polls to check whether the machine image is available
+	PollInterval time.Duration
+	// Time to wait for the machine image to be available
+	Timeout time.Duration
+}
+
+// ImportMachineImageOutput details the resources created by importing a machine image
+type ImportMachineImageOutput struct {
+	// The uploaded image file
+	Object *storage.ObjectInfo
+	// The machine image registered from the file
+	MachineImage *MachineImage
+	// The image list the machine image was added to, nil if no image list was given
+	ImageList *ImageList
+	// The image list entry of the machine image, nil if no image list was given
+	Entry *ImageListEntryInfo
+}
+
+// ImportMachineImage uploads an image file to storage using the given object client, registers it as a
+// machine image, and waits for the machine image to be available. If an image list is given, the machine
+// image is then added to it as a new entry, with the version after the latest entry.
+// If a step fails, the resources created by the steps before it are returned along with the error, so they
+// can be retried or deleted.
+func (c *MachineImagesClient) ImportMachineImage(objects *storage.ObjectClient, input *ImportMachineImageInput) (*ImportMachineImageOutput, error) {
+	if input.Name == "" {
+		return nil, errors.New("A machine image name must be specified")
+	}
+	if input.Body == nil {
+		return nil, errors.New("Body cannot be nil")
+	}
+	if input.File == "" {
+		input.File = input.Name + ".tar.gz"
+	}
+	if input.Container == "" {
+		input.Container = DefaultMachineImageContainer
+	}
+	if input.Account == "" {
+		input.Account = c.getQualifiedACMEName(defaultMachineImageAccount)
+	}
+	if input.PollInterval == 0 {
+		input.PollInterval = waitForMachineImageAvailablePollInterval
+	}
+	if input.Timeout == 0 {
+		input.Timeout = waitForMachineImageAvailableTimeout
+	}
+
+	output := &ImportMachineImageOutput{}
+	object, err := objects.UploadObject(&storage.UploadObjectInput{
+		Name:        input.File,
+		Container:   input.Container,
+		Body:        input.Body,
+		SegmentSize: input.SegmentSize,
+		Concurrency: input.Concurrency,
+		ContentType: "application/tar+gzip",
+	})
+	if err != nil {
+		return output, fmt.Errorf("Error uploading machine image file %s: %w", input.File, err)
+	}
+	output.Object = object
+
+	createInput := &CreateMachineImageInput{
+		Account:     input.Account,
+		Name:        input.Name,
+		File:        input.File,
+		Description: input.Description,
+		Attributes:  input.Attributes,
+		Sizes:       map[string]interface{}{"total": object.ContentLength},
+	}
+	if output.MachineImage, err = c.CreateMachineImage(createInput); err != nil {
+		return output, fmt.Errorf("Error creating machine image %s: %w", input.Name, err)
+	}
+
+	getInput := &GetMachineImageInput{
+		Account: input.Account,
+		Name:    input.Name,
+	}
+	if output.MachineImage, err = c.WaitForMachineImageAvailable(getInput, input.PollInterval, input.Timeout); err != nil {
+		return output, err
+	}
+
+	if input.ImageList == "" {
+		return output, nil
+	}
+	if err := c.addImageListEntry(input, output); err != nil {
+		return output, fmt.Errorf("Error adding machine image %s to image list %s: %w", input.Name, input.ImageList, err)
+	}
+	return output, nil
+}
+
+// addImageListEntry adds the imported machine image to the input's image list, creating the image list
+// if it doesn't exist
+func (c *MachineImagesClient) addImageListEntry(input *ImportMachineImageInput, output *ImportMachineImageOutput) error {
+	imageLists := c.Client.ImageList()
+	imageList, err := imageLists.GetImageList(&GetImageListInput{Name: input.ImageList})
+	if client.WasNotFoundError(err) {
+		description := input.ImageListDescription
+		if description == "" {
+			description = input.Description
+		}
+		imageList, err = imageLists.CreateImageList(&CreateImageListInput{
+			Name:        input.ImageList,
+			Description: description,
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	version := 1
+	for _, entry := range imageList.Entries {
+		if entry.Version >= version {
+			version = entry.Version + 1
+		}
+	}
+
+	output.Entry, err = c.Client.ImageListEntries().CreateImageListEntry(&CreateImageListEntryInput{
+		Name:          input.ImageList,
+		Attributes:    input.EntryAttributes,
+		MachineImages: []string{c.getQualifiedName(input.Name)},
+		Version:       version,
+	})
+	if err != nil {
+		return err
+	}
+
+	if input.SetDefault {
+		_, err = imageLists.UpdateImageList(&UpdateImageListInput{
+			Name:        input.ImageList,
+			Default:     version,
+			Description: imageList.Description,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	output.ImageList, err = imageLists.GetImageList(&GetImageListInput{Name: input.ImageList})
+	return err
+}
+
+// WaitForMachineImageAvailable waits for a machine image to be available, failing if it enters the
+// `error` state or reports an error reason.
+func (c *MachineImagesClient) WaitForMachineImageAvailable(input *GetMachineImageInput, pollInterval, timeout time.Duration) (*MachineImage, error) {
+	var info *MachineImage
+	var getErr error
+	err := c.client.WaitFor("machine image to be available", pollInterval, timeout, func() (bool, error) {
+		info, getErr = c.GetMachineImage(input)
+		if getErr != nil {
+			return false, getErr
+		}
+		if info.State == MachineImageError || info.ErrorReason != "" {
+			return false, fmt.Errorf("Error registering machine image %s: %s", info.Name, info.ErrorReason)
+		}
+		switch s := info.State; s {
+		case MachineImageAvailable: // Target State
+			c.client.DebugLogString("Machine Image Available")
+			return true, nil
+		default:
+			c.client.DebugLogString(fmt.Sprintf("Machine image state: %s, waiting", s))
+			return false, nil
+		}
+	})
+	return info, err
+}
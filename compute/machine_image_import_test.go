@@ -0,0 +1,142 @@
+package compute
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-oracle-terraform/opc"
+	"github.com/hashicorp/go-oracle-terraform/storage"
+)
+
+// newImageStorageServer starts a minimal fake of the Storage API, storing uploaded objects by path
+func newImageStorageServer(t *testing.T) (*storage.ObjectClient, map[string][]byte) {
+	var mu sync.Mutex
+	objects := map[string][]byte{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.URL.Path == "/auth/v1.0" {
+			w.Header().Set("X-Auth-Token", "test-token")
+			return
+		}
+		switch r.Method {
+		case http.MethodPut:
+			data, _ := ioutil.ReadAll(r.Body)
+			objects[r.URL.Path] = data
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodGet, http.MethodHead:
+			data, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			sum := md5.Sum(data)
+			w.Header().Set("ETag", hex.EncodeToString(sum[:]))
+			w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+			_, _ = w.Write(data)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	endpoint, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := storage.NewStorageClient(&opc.Config{
+		IdentityDomain: opc.String("test-domain"),
+		Username:       opc.String("test-user"),
+		Password:       opc.String("test-password"),
+		APIEndpoint:    endpoint,
+		HTTPClient:     server.Client(),
+	})
+	if err != nil {
+		t.Fatalf("error creating storage client: %s", err)
+	}
+	return client.Objects(), objects
+}
+
+func TestMachineImagesClient_ImportMachineImage(t *testing.T) {
+	client, _ := newFakeComputeClient(t)
+	objects, uploaded := newImageStorageServer(t)
+
+	importImage := func(name string) (*ImportMachineImageOutput, error) {
+		return client.MachineImages().ImportMachineImage(objects, &ImportMachineImageInput{
+			Name:         name,
+			Body:         strings.NewReader("image content"),
+			Description:  "Test image",
+			ImageList:    "images",
+			SetDefault:   true,
+			PollInterval: 10 * time.Millisecond,
+			Timeout:      5 * time.Second,
+		})
+	}
+
+	output, err := importImage("image1")
+	if err != nil {
+		t.Fatalf("error importing machine image: %s", err)
+	}
+	if !bytes.Equal(uploaded["/v1/Storage-test-domain/compute_images/image1.tar.gz"], []byte("image content")) {
+		t.Fatalf("Expected the image file to be uploaded to compute_images, got %v", uploaded)
+	}
+	if output.MachineImage.State != MachineImageAvailable || output.MachineImage.File != "image1.tar.gz" {
+		t.Fatalf("Expected an available machine image from image1.tar.gz, got %+v", output.MachineImage)
+	}
+	if output.MachineImage.Account != "/Compute-test-domain/cloud_storage" {
+		t.Fatalf("Expected the machine image to use the cloud_storage account, got %s", output.MachineImage.Account)
+	}
+	if output.ImageList.Description != "Test image" || output.Entry.Version != 1 || output.ImageList.Default != 1 {
+		t.Fatalf("Expected a new image list with image1 as version 1, got %+v", output.ImageList)
+	}
+
+	// A second import adds the next version to the existing image list
+	output, err = importImage("image2")
+	if err != nil {
+		t.Fatalf("error importing machine image: %s", err)
+	}
+	if output.Entry.Version != 2 || len(output.ImageList.Entries) != 2 || output.ImageList.Default != 2 {
+		t.Fatalf("Expected image2 to be added as the default version 2, got %+v", output.ImageList)
+	}
+	if output.Entry.MachineImages[0] != "/Compute-test-domain/test-user/image2" {
+		t.Fatalf("Expected the entry to include image2, got %v", output.Entry.MachineImages)
+	}
+}
+
+func TestMachineImagesClient_ImportMachineImageError(t *testing.T) {
+	client, server := newFakeComputeClient(t)
+	server.MachineImageErrors = map[string]string{"/Compute-test-domain/test-user/broken": "Invalid image file"}
+	objects, _ := newImageStorageServer(t)
+
+	output, err := client.MachineImages().ImportMachineImage(objects, &ImportMachineImageInput{
+		Name:         "broken",
+		Body:         strings.NewReader("not an image"),
+		ImageList:    "images",
+		PollInterval: 10 * time.Millisecond,
+		Timeout:      5 * time.Second,
+	})
+	if err == nil || !strings.Contains(err.Error(), "Invalid image file") {
+		t.Fatalf("Expected the machine image error reason to be returned, got %v", err)
+	}
+	if output.Object == nil || output.MachineImage == nil || output.Entry != nil {
+		t.Fatalf("Expected the uploaded file and machine image to be returned, but no entry, got %+v", output)
+	}
+
+	_, err = client.ImageList().GetImageList(&GetImageListInput{Name: "images"})
+	var oracleErr *opc.OracleError
+	if !errors.As(err, &oracleErr) || oracleErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("Expected the image list not to be created, got %v", err)
+	}
+}
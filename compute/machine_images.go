@@ -140,6 +140,30 @@ func (c *MachineImagesClient) GetMachineImage(getInput *GetMachineImageInput) (*
 	return c.success(&machineImage)
 }
 
+// ListMachineImages retrieves the machine images in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *MachineImagesClient) ListMachineImages(input *ListInput) ([]MachineImage, error) {
+	var list struct {
+		Result []MachineImage `json:"result"`
+	}
+	if err := c.listResource(input, &list); err != nil {
+		return nil, err
+	}
+
+	images := []MachineImage{}
+	for i := range list.Result {
+		info, err := c.success(&list.Result[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, nil) {
+			images = append(images, *info)
+		}
+	}
+
+	return images, nil
+}
+
 func (c *MachineImagesClient) success(result *MachineImage) (*MachineImage, error) {
 	result.Name = c.getUnqualifiedName(result.FQDN)
 	return result, nil
@@ -0,0 +1,541 @@
+package compute
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Flow directions of a security rule
+const (
+	// SecurityRuleIngress - the rule matches packets received by a vNIC
+	SecurityRuleIngress = "ingress"
+	// SecurityRuleEgress - the rule matches packets sent by a vNIC
+	SecurityRuleEgress = "egress"
+)
+
+// NetworkTopology is an in-memory model of the IP networks in a container, along with the IP network
+// exchanges, routes, vNIC sets, IP address prefix sets, ACLs, security rules and security protocols
+// connecting them, and the instances attached to them. Each resource is keyed by its unqualified name,
+// except instances, which are keyed by their name and ID, i.e. name/id.
+type NetworkTopology struct {
+	IPNetworks          map[string]*IPNetworkInfo
+	IPNetworkExchanges  map[string]*IPNetworkExchangeInfo
+	Routes              map[string]*RouteInfo
+	VirtualNICSets      map[string]*VirtualNICSet
+	IPAddressPrefixSets map[string]*IPAddressPrefixSetInfo
+	ACLs                map[string]*ACLInfo
+	SecurityRules       map[string]*SecurityRuleInfo
+	SecurityProtocols   map[string]*SecurityProtocolInfo
+	Instances           map[string]*InstanceInfo
+}
+
+// NewNetworkTopology returns an empty topology, for resources to be added to
+func NewNetworkTopology() *NetworkTopology {
+	return &NetworkTopology{
+		IPNetworks:          map[string]*IPNetworkInfo{},
+		IPNetworkExchanges:  map[string]*IPNetworkExchangeInfo{},
+		Routes:              map[string]*RouteInfo{},
+		VirtualNICSets:      map[string]*VirtualNICSet{},
+		IPAddressPrefixSets: map[string]*IPAddressPrefixSetInfo{},
+		ACLs:                map[string]*ACLInfo{},
+		SecurityRules:       map[string]*SecurityRuleInfo{},
+		SecurityProtocols:   map[string]*SecurityProtocolInfo{},
+		Instances:           map[string]*InstanceInfo{},
+	}
+}
+
+// LoadNetworkTopologyInput specifies where to load a network topology from
+type LoadNetworkTopologyInput struct {
+	// The container to load resources from, see ListInput.
+	// Optional - Defaults to the authenticated user's container
+	Container string
+}
+
+// LoadNetworkTopology lists every IP network resource, and every instance, in the container into a NetworkTopology
+func (c *Client) LoadNetworkTopology(input *LoadNetworkTopologyInput) (*NetworkTopology, error) {
+	listInput := &ListInput{}
+	if input != nil {
+		listInput.Container = input.Container
+	}
+	t := NewNetworkTopology()
+
+	networks, err := c.IPNetworks().ListIPNetworks(listInput)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing IP networks: %w", err)
+	}
+	for i := range networks {
+		t.IPNetworks[networks[i].Name] = &networks[i]
+	}
+
+	exchanges, err := c.IPNetworkExchanges().ListIPNetworkExchanges(listInput)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing IP network exchanges: %w", err)
+	}
+	for i := range exchanges {
+		t.IPNetworkExchanges[exchanges[i].Name] = &exchanges[i]
+	}
+
+	routes, err := c.Routes().ListRoutes(listInput)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing routes: %w", err)
+	}
+	for i := range routes {
+		t.Routes[routes[i].Name] = &routes[i]
+	}
+
+	vnicSets, err := c.VirtNICSets().ListVirtualNICSets(listInput)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing virtual NIC sets: %w", err)
+	}
+	for i := range vnicSets {
+		t.VirtualNICSets[vnicSets[i].Name] = &vnicSets[i]
+	}
+
+	prefixSets, err := c.IPAddressPrefixSets().ListIPAddressPrefixSets(listInput)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing IP address prefix sets: %w", err)
+	}
+	for i := range prefixSets {
+		t.IPAddressPrefixSets[prefixSets[i].Name] = &prefixSets[i]
+	}
+
+	acls, err := c.ACLs().ListACLs(listInput)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing ACLs: %w", err)
+	}
+	for i := range acls {
+		t.ACLs[acls[i].Name] = &acls[i]
+	}
+
+	rules, err := c.SecurityRules().ListSecurityRules(listInput)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing security rules: %w", err)
+	}
+	for i := range rules {
+		t.SecurityRules[rules[i].Name] = &rules[i]
+	}
+
+	protocols, err := c.SecurityProtocols().ListSecurityProtocols(listInput)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing security protocols: %w", err)
+	}
+	for i := range protocols {
+		t.SecurityProtocols[protocols[i].Name] = &protocols[i]
+	}
+
+	instances, err := c.Instances().ListInstances(listInput)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing instances: %w", err)
+	}
+	for i := range instances {
+		t.Instances[instances[i].Name+"/"+instances[i].ID] = &instances[i]
+	}
+
+	return t, nil
+}
+
+// TopologyProblemType specifies the kinds of problem found validating a network topology
+type TopologyProblemType string
+
+const (
+	// TopologyInvalidIPAddressPrefix - the IP address prefix of an IP network or route isn't a valid CIDR
+	TopologyInvalidIPAddressPrefix TopologyProblemType = "invalid_ip_address_prefix"
+	// TopologyOverlappingIPNetworks - IP networks connected to the same IP network exchange have overlapping prefixes
+	TopologyOverlappingIPNetworks TopologyProblemType = "overlapping_ip_networks"
+	// TopologyMissingNextHopVnicSet - a route's next hop vNIC set doesn't exist
+	TopologyMissingNextHopVnicSet TopologyProblemType = "missing_next_hop_vnic_set"
+	// TopologyDuplicateAdminDistance - routes for the same prefix have the same admin distance
+	TopologyDuplicateAdminDistance TopologyProblemType = "duplicate_admin_distance"
+)
+
+// TopologyProblem describes a problem found validating a network topology
+type TopologyProblem struct {
+	// The kind of problem
+	Type TopologyProblemType
+	// The names of the resources with the problem
+	Resources []string
+	// Describes the problem
+	Message string
+}
+
+func (p TopologyProblem) String() string {
+	return fmt.Sprintf("%s: %s", p.Type, p.Message)
+}
+
+// Validate checks the topology for IP networks with invalid or overlapping prefixes within an IP network
+// exchange, routes to vNIC sets which don't exist, and routes for the same prefix with the same admin distance,
+// between which traffic would be split using ECMP. The problems found are returned, ordered by resource name.
+func (t *NetworkTopology) Validate() []TopologyProblem {
+	problems := []TopologyProblem{}
+
+	exchanges := map[string][]string{}
+	prefixes := map[string]*net.IPNet{}
+	for _, name := range sortedKeys(t.IPNetworks) {
+		network := t.IPNetworks[name]
+		_, prefix, err := net.ParseCIDR(network.IPAddressPrefix)
+		if err != nil {
+			problems = append(problems, TopologyProblem{
+				Type:      TopologyInvalidIPAddressPrefix,
+				Resources: []string{name},
+				Message:   fmt.Sprintf("IP network %s has an invalid IP address prefix %q", name, network.IPAddressPrefix),
+			})
+			continue
+		}
+		prefixes[name] = prefix
+		if network.IPNetworkExchange != "" {
+			exchanges[network.IPNetworkExchange] = append(exchanges[network.IPNetworkExchange], name)
+		}
+	}
+	for _, exchange := range sortedKeys(exchanges) {
+		networks := exchanges[exchange]
+		for i, a := range networks {
+			for _, b := range networks[i+1:] {
+				if prefixes[a].Contains(prefixes[b].IP) || prefixes[b].Contains(prefixes[a].IP) {
+					problems = append(problems, TopologyProblem{
+						Type:      TopologyOverlappingIPNetworks,
+						Resources: []string{a, b},
+						Message: fmt.Sprintf("IP networks %s (%s) and %s (%s) overlap in IP network exchange %s",
+							a, prefixes[a], b, prefixes[b], exchange),
+					})
+				}
+			}
+		}
+	}
+
+	distances := map[string][]string{}
+	for _, name := range sortedKeys(t.Routes) {
+		route := t.Routes[name]
+		if _, ok := t.VirtualNICSets[route.NextHopVnicSet]; !ok {
+			problems = append(problems, TopologyProblem{
+				Type:      TopologyMissingNextHopVnicSet,
+				Resources: []string{name},
+				Message:   fmt.Sprintf("Route %s has next hop vNIC set %q, which doesn't exist", name, route.NextHopVnicSet),
+			})
+		}
+		_, prefix, err := net.ParseCIDR(route.IPAddressPrefix)
+		if err != nil {
+			problems = append(problems, TopologyProblem{
+				Type:      TopologyInvalidIPAddressPrefix,
+				Resources: []string{name},
+				Message:   fmt.Sprintf("Route %s has an invalid IP address prefix %q", name, route.IPAddressPrefix),
+			})
+			continue
+		}
+		key := fmt.Sprintf("%s %d", prefix, route.AdminDistance)
+		distances[key] = append(distances[key], name)
+	}
+	for _, key := range sortedKeys(distances) {
+		if routes := distances[key]; len(routes) > 1 {
+			route := t.Routes[routes[0]]
+			problems = append(problems, TopologyProblem{
+				Type:      TopologyDuplicateAdminDistance,
+				Resources: routes,
+				Message: fmt.Sprintf("Routes %s all route %s with admin distance %d, so traffic is split between them using ECMP",
+					strings.Join(routes, ", "), route.IPAddressPrefix, route.AdminDistance),
+			})
+		}
+	}
+
+	return problems
+}
+
+// TopologyInterface describes an instance's network interface on an IP network
+type TopologyInterface struct {
+	// The name and ID of the instance, i.e. name/id
+	Instance string
+	// The name of the interface, e.g. eth0
+	Interface string
+	// The IP network the interface is attached to
+	IPNetwork string
+	// The IP address of the interface, if known
+	IPAddress string
+	// The name of the interface's vNIC
+	Vnic string
+	// The vNIC sets containing the vNIC, either as specified by the instance or by the vNIC set
+	VnicSets []string
+}
+
+// InstanceInterfaces returns the IP network interfaces of an instance, given either its name and ID, i.e. name/id,
+// or just its name if no other instance has the same name. Interfaces on the shared network are ignored.
+func (t *NetworkTopology) InstanceInterfaces(instance string) ([]TopologyInterface, error) {
+	key, err := t.instanceKey(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	interfaces := []TopologyInterface{}
+	for _, name := range sortedKeys(t.Instances[key].Networking) {
+		networking := t.Instances[key].Networking[name]
+		if networking.IPNetwork == "" {
+			continue
+		}
+		iface := TopologyInterface{
+			Instance:  key,
+			Interface: name,
+			IPNetwork: networking.IPNetwork,
+			IPAddress: networking.IPAddress,
+			Vnic:      networking.Vnic,
+		}
+		iface.VnicSets = t.vnicSets(networking.Vnic, networking.VnicSets)
+		interfaces = append(interfaces, iface)
+	}
+	return interfaces, nil
+}
+
+// vnicSets returns the names of the vNIC sets containing the vNIC, along with the given vNIC sets
+func (t *NetworkTopology) vnicSets(vnic string, sets []string) []string {
+	names := map[string]bool{}
+	for _, set := range sets {
+		names[set] = true
+	}
+	for name, set := range t.VirtualNICSets {
+		if vnic != "" && containsString(set.VirtualNICs, vnic) {
+			names[name] = true
+		}
+	}
+	return sortedKeys(names)
+}
+
+func (t *NetworkTopology) instanceKey(instance string) (string, error) {
+	if _, ok := t.Instances[instance]; ok {
+		return instance, nil
+	}
+	matches := []string{}
+	for key, info := range t.Instances {
+		if info.Name == instance {
+			matches = append(matches, key)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("Instance %s does not exist", instance)
+	case 1:
+		return matches[0], nil
+	default:
+		sort.Strings(matches)
+		return "", fmt.Errorf("Instance name %s is ambiguous, specify one of %s", instance, strings.Join(matches, ", "))
+	}
+}
+
+// ReachabilityInput specifies a flow between two instances
+type ReachabilityInput struct {
+	// The instance sending the flow, either its name and ID, i.e. name/id, or just its name if it's unique
+	// Required
+	Source string
+	// The instance receiving the flow, either its name and ID, i.e. name/id, or just its name if it's unique
+	// Required
+	Destination string
+	// The IP protocol of the flow, e.g. tcp, as used by security protocols
+	// Required
+	Protocol string
+	// The destination port of the flow. Not used by protocols without ports, e.g. icmp.
+	// Optional
+	Port int
+}
+
+// Reachability details whether a flow between two instances is permitted, and why
+type Reachability struct {
+	// Whether the destination can be reached from the source
+	Reachable bool
+	// The source interface of the permitted flow, set if the destination is reachable
+	SourceInterface *TopologyInterface
+	// The destination interface of the permitted flow, set if the destination is reachable
+	DestinationInterface *TopologyInterface
+	// The security rule permitting the flow to leave the source, set if the destination is reachable
+	EgressRule string
+	// The security rule permitting the flow to reach the destination, set if the destination is reachable
+	IngressRule string
+	// Why the destination can't be reached from each of the source interfaces, if it isn't reachable
+	Reasons []string
+}
+
+// CanReach returns whether the destination instance can be reached from the source instance with the protocol and port.
+// The source and destination need interfaces on the same IP network, or on IP networks connected to the same IP network
+// exchange. Security rules only permit traffic, so the flow must be permitted by an enabled egress rule in an enabled
+// ACL applied to one of the source's vNIC sets, and by an enabled ingress rule in an enabled ACL applied to one of the
+// destination's vNIC sets. Source ports aren't known, so the source port sets of security protocols aren't checked.
+func (t *NetworkTopology) CanReach(input *ReachabilityInput) (*Reachability, error) {
+	if input.Source == "" || input.Destination == "" {
+		return nil, errors.New("Both the source and destination instances need to be specified")
+	}
+	if input.Protocol == "" {
+		return nil, errors.New("A protocol must be specified")
+	}
+	sources, err := t.InstanceInterfaces(input.Source)
+	if err != nil {
+		return nil, err
+	}
+	destinations, err := t.InstanceInterfaces(input.Destination)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Reachability{}
+	if len(sources) == 0 || len(destinations) == 0 {
+		result.Reasons = append(result.Reasons, "The source and destination instances both need interfaces on IP networks")
+		return result, nil
+	}
+
+	for i := range sources {
+		for j := range destinations {
+			src, dst := &sources[i], &destinations[j]
+			route := fmt.Sprintf("%s %s to %s %s", src.Instance, src.Interface, dst.Instance, dst.Interface)
+			if !t.connected(src.IPNetwork, dst.IPNetwork) {
+				result.Reasons = append(result.Reasons, fmt.Sprintf("%s: IP networks %s and %s aren't connected", route, src.IPNetwork, dst.IPNetwork))
+				continue
+			}
+			egress := t.permittingRule(src, SecurityRuleEgress, src, dst, input.Protocol, input.Port)
+			if egress == "" {
+				result.Reasons = append(result.Reasons, fmt.Sprintf("%s: no security rule permits egress from %s", route, src.Interface))
+				continue
+			}
+			ingress := t.permittingRule(dst, SecurityRuleIngress, src, dst, input.Protocol, input.Port)
+			if ingress == "" {
+				result.Reasons = append(result.Reasons, fmt.Sprintf("%s: no security rule permits ingress to %s", route, dst.Interface))
+				continue
+			}
+			return &Reachability{
+				Reachable:            true,
+				SourceInterface:      src,
+				DestinationInterface: dst,
+				EgressRule:           egress,
+				IngressRule:          ingress,
+			}, nil
+		}
+	}
+	return result, nil
+}
+
+// connected returns whether traffic can flow between two IP networks, either because they're the same
+// network, or they're connected to the same IP network exchange
+func (t *NetworkTopology) connected(a, b string) bool {
+	if a == b {
+		return true
+	}
+	networkA, okA := t.IPNetworks[a]
+	networkB, okB := t.IPNetworks[b]
+	return okA && okB && networkA.IPNetworkExchange != "" && networkA.IPNetworkExchange == networkB.IPNetworkExchange
+}
+
+// appliedACLs returns the enabled ACLs applied to any of the interface's vNIC sets
+func (t *NetworkTopology) appliedACLs(iface *TopologyInterface) map[string]bool {
+	acls := map[string]bool{}
+	for _, setName := range iface.VnicSets {
+		set, ok := t.VirtualNICSets[setName]
+		if !ok {
+			continue
+		}
+		for _, acl := range set.AppliedACLs {
+			if info, ok := t.ACLs[acl]; ok && info.Enabled {
+				acls[acl] = true
+			}
+		}
+	}
+	return acls
+}
+
+// permittingRule returns the name of the first enabled security rule with the flow direction, in the ACLs applied
+// to the interface, which matches the flow, or "" if no rule matches
+func (t *NetworkTopology) permittingRule(iface *TopologyInterface, direction string, src, dst *TopologyInterface, protocol string, port int) string {
+	acls := t.appliedACLs(iface)
+	for _, name := range sortedKeys(t.SecurityRules) {
+		rule := t.SecurityRules[name]
+		if !rule.Enabled || !acls[rule.ACL] || !strings.EqualFold(rule.FlowDirection, direction) {
+			continue
+		}
+		if t.securityRuleMatches(rule, src, dst, protocol, port) {
+			return name
+		}
+	}
+	return ""
+}
+
+// securityRuleMatches returns whether the security rule matches a flow between the interfaces. Criteria
+// referencing resources which aren't in the topology never match.
+func (t *NetworkTopology) securityRuleMatches(rule *SecurityRuleInfo, src, dst *TopologyInterface, protocol string, port int) bool {
+	if rule.SrcVnicSet != "" && !containsString(src.VnicSets, rule.SrcVnicSet) {
+		return false
+	}
+	if rule.DstVnicSet != "" && !containsString(dst.VnicSets, rule.DstVnicSet) {
+		return false
+	}
+	if len(rule.SrcIPAddressPrefixSets) > 0 && !t.prefixSetsContain(rule.SrcIPAddressPrefixSets, src.IPAddress) {
+		return false
+	}
+	if len(rule.DstIPAddressPrefixSets) > 0 && !t.prefixSetsContain(rule.DstIPAddressPrefixSets, dst.IPAddress) {
+		return false
+	}
+	if len(rule.SecProtocols) == 0 {
+		return true
+	}
+	for _, name := range rule.SecProtocols {
+		if p, ok := t.SecurityProtocols[name]; ok && securityProtocolMatches(p, protocol, port) {
+			return true
+		}
+	}
+	return false
+}
+
+// prefixSetsContain returns whether the IP address is in any of the prefixes of the IP address prefix sets
+func (t *NetworkTopology) prefixSetsContain(sets []string, ipAddress string) bool {
+	ip := net.ParseIP(ipAddress)
+	if ip == nil {
+		return false
+	}
+	for _, name := range sets {
+		set, ok := t.IPAddressPrefixSets[name]
+		if !ok {
+			continue
+		}
+		for _, prefix := range set.IPAddressPrefixes {
+			if _, network, err := net.ParseCIDR(prefix); err == nil && network.Contains(ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// securityProtocolMatches returns whether the security protocol matches the IP protocol and destination port.
+// A protocol without an IP protocol, or with `all`, matches every IP protocol, and one without destination
+// ports matches every port.
+func securityProtocolMatches(p *SecurityProtocolInfo, protocol string, port int) bool {
+	if p.IPProtocol != "" && p.IPProtocol != "all" && !strings.EqualFold(p.IPProtocol, protocol) {
+		return false
+	}
+	return len(p.DstPortSet) == 0 || portSetContains(p.DstPortSet, port)
+}
+
+// portSetContains returns whether the port is in the port set, a list of port numbers or
+// ranges such as 8000-8080. Invalid entries are ignored.
+func portSetContains(ports []string, port int) bool {
+	for _, entry := range ports {
+		if low, high, ok := parsePortRange(entry); ok && port >= low && port <= high {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys returns the keys of a map with string keys, in order
+func sortedKeys(m interface{}) []string {
+	keys := []string{}
+	for _, key := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
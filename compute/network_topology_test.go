@@ -0,0 +1,197 @@
+package compute
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNetworkTopology_Validate(t *testing.T) {
+	topology := NewNetworkTopology()
+	topology.IPNetworks = map[string]*IPNetworkInfo{
+		"web":      {Name: "web", IPAddressPrefix: "10.0.0.0/24", IPNetworkExchange: "exchange"},
+		"app":      {Name: "app", IPAddressPrefix: "10.0.0.128/25", IPNetworkExchange: "exchange"},
+		"db":       {Name: "db", IPAddressPrefix: "10.0.1.0/24", IPNetworkExchange: "exchange"},
+		"isolated": {Name: "isolated", IPAddressPrefix: "10.0.0.0/24"},
+		"broken":   {Name: "broken", IPAddressPrefix: "10.0.0.0/33"},
+	}
+	topology.VirtualNICSets = map[string]*VirtualNICSet{
+		"appliances": {Name: "appliances"},
+	}
+	topology.Routes = map[string]*RouteInfo{
+		"vpn-a":    {Name: "vpn-a", IPAddressPrefix: "192.168.0.0/16", NextHopVnicSet: "appliances"},
+		"vpn-b":    {Name: "vpn-b", IPAddressPrefix: "192.168.0.0/16", NextHopVnicSet: "appliances"},
+		"vpn-c":    {Name: "vpn-c", IPAddressPrefix: "192.168.0.0/16", NextHopVnicSet: "appliances", AdminDistance: 1},
+		"outbound": {Name: "outbound", IPAddressPrefix: "0.0.0.0/0", NextHopVnicSet: "gateways"},
+	}
+
+	problems := topology.Validate()
+	found := map[TopologyProblemType][]string{}
+	for _, problem := range problems {
+		found[problem.Type] = append(found[problem.Type], strings.Join(problem.Resources, ","))
+	}
+	expected := map[TopologyProblemType][]string{
+		TopologyInvalidIPAddressPrefix: {"broken"},
+		TopologyOverlappingIPNetworks:  {"app,web"},
+		TopologyMissingNextHopVnicSet:  {"outbound"},
+		TopologyDuplicateAdminDistance: {"vpn-a,vpn-b"},
+	}
+	if !reflect.DeepEqual(found, expected) {
+		t.Fatalf("Expected problems %v, got %v", expected, problems)
+	}
+}
+
+func TestNetworkTopology_CanReach(t *testing.T) {
+	topology := NewNetworkTopology()
+	topology.IPNetworks = map[string]*IPNetworkInfo{
+		"web":   {Name: "web", IPAddressPrefix: "10.0.0.0/24", IPNetworkExchange: "exchange"},
+		"db":    {Name: "db", IPAddressPrefix: "10.0.1.0/24", IPNetworkExchange: "exchange"},
+		"other": {Name: "other", IPAddressPrefix: "10.0.2.0/24"},
+	}
+	topology.Instances = map[string]*InstanceInfo{
+		"web1/1": {Name: "web1", ID: "1", Networking: map[string]NetworkingInfo{
+			"eth0": {IPNetwork: "web", IPAddress: "10.0.0.10", Vnic: "web1_eth0", VnicSets: []string{"web-servers"}},
+		}},
+		"db1/2": {Name: "db1", ID: "2", Networking: map[string]NetworkingInfo{
+			"eth0": {IPNetwork: "db", IPAddress: "10.0.1.10", Vnic: "db1_eth0"},
+		}},
+		"other1/3": {Name: "other1", ID: "3", Networking: map[string]NetworkingInfo{
+			"eth0": {IPNetwork: "other", IPAddress: "10.0.2.10", Vnic: "other1_eth0", VnicSets: []string{"web-servers"}},
+		}},
+	}
+	topology.VirtualNICSets = map[string]*VirtualNICSet{
+		"web-servers": {Name: "web-servers", AppliedACLs: []string{"web-acl"}},
+		// db1's vNIC is only in the database set through the vNIC set
+		"databases": {Name: "databases", AppliedACLs: []string{"db-acl", "disabled-acl"}, VirtualNICs: []string{"db1_eth0"}},
+	}
+	topology.ACLs = map[string]*ACLInfo{
+		"web-acl":      {Name: "web-acl", Enabled: true},
+		"db-acl":       {Name: "db-acl", Enabled: true},
+		"disabled-acl": {Name: "disabled-acl"},
+	}
+	topology.IPAddressPrefixSets = map[string]*IPAddressPrefixSetInfo{
+		"web-subnet": {Name: "web-subnet", IPAddressPrefixes: []string{"10.0.0.0/24"}},
+	}
+	topology.SecurityProtocols = map[string]*SecurityProtocolInfo{
+		"postgres": {Name: "postgres", IPProtocol: "tcp", DstPortSet: []string{"5432"}},
+		"ssh":      {Name: "ssh", IPProtocol: "tcp", DstPortSet: []string{"22"}},
+	}
+	topology.SecurityRules = map[string]*SecurityRuleInfo{
+		"web-egress": {Name: "web-egress", ACL: "web-acl", Enabled: true, FlowDirection: SecurityRuleEgress},
+		"db-postgres": {Name: "db-postgres", ACL: "db-acl", Enabled: true, FlowDirection: SecurityRuleIngress,
+			SecProtocols: []string{"postgres"}, SrcIPAddressPrefixSets: []string{"web-subnet"}},
+		"db-ssh-disabled": {Name: "db-ssh-disabled", ACL: "db-acl", FlowDirection: SecurityRuleIngress,
+			SecProtocols: []string{"ssh"}},
+		"db-ssh-disabled-acl": {Name: "db-ssh-disabled-acl", ACL: "disabled-acl", Enabled: true, FlowDirection: SecurityRuleIngress,
+			SecProtocols: []string{"ssh"}},
+	}
+
+	reachability, err := topology.CanReach(&ReachabilityInput{Source: "web1", Destination: "db1", Protocol: "tcp", Port: 5432})
+	if err != nil {
+		t.Fatalf("Error checking reachability: %s", err)
+	}
+	if !reachability.Reachable || reachability.EgressRule != "web-egress" || reachability.IngressRule != "db-postgres" {
+		t.Fatalf("Expected db1 to be reachable on 5432 through web-egress and db-postgres, got %+v", reachability)
+	}
+
+	for _, input := range []ReachabilityInput{
+		// The ssh rules are disabled, or in a disabled ACL
+		{Source: "web1/1", Destination: "db1", Protocol: "tcp", Port: 22},
+		// db1 has no egress rules
+		{Source: "db1", Destination: "web1", Protocol: "tcp", Port: 80},
+		// other1 isn't connected to the exchange
+		{Source: "other1", Destination: "db1", Protocol: "tcp", Port: 5432},
+	} {
+		reachability, err := topology.CanReach(&input)
+		if err != nil {
+			t.Fatalf("Error checking reachability: %s", err)
+		}
+		if reachability.Reachable || len(reachability.Reasons) != 1 {
+			t.Fatalf("Expected %s not to reach %s on port %d, got %+v", input.Source, input.Destination, input.Port, reachability)
+		}
+	}
+
+	if _, err := topology.CanReach(&ReachabilityInput{Source: "web2", Destination: "db1", Protocol: "tcp"}); err == nil {
+		t.Fatal("Expected an error checking reachability from an instance which doesn't exist")
+	}
+}
+
+func TestClient_LoadNetworkTopology(t *testing.T) {
+	client, _ := newFakeComputeClient(t)
+	if _, err := client.IPNetworkExchanges().CreateIPNetworkExchange(&CreateIPNetworkExchangeInput{Name: "exchange"}); err != nil {
+		t.Fatalf("Error creating IP network exchange: %s", err)
+	}
+	for name, prefix := range map[string]string{"web": "10.0.0.0/24", "db": "10.0.1.0/24"} {
+		_, err := client.IPNetworks().CreateIPNetwork(&CreateIPNetworkInput{Name: name, IPAddressPrefix: prefix, IPNetworkExchange: "exchange"})
+		if err != nil {
+			t.Fatalf("Error creating IP network: %s", err)
+		}
+	}
+	if _, err := client.ACLs().CreateACL(&CreateACLInput{Name: "acl", Enabled: true}); err != nil {
+		t.Fatalf("Error creating ACL: %s", err)
+	}
+	if _, err := client.VirtNICSets().CreateVirtualNICSet(&CreateVirtualNICSetInput{Name: "servers", AppliedACLs: []string{"acl"}}); err != nil {
+		t.Fatalf("Error creating vNIC set: %s", err)
+	}
+	_, err := client.SecurityProtocols().CreateSecurityProtocol(&CreateSecurityProtocolInput{Name: "https", IPProtocol: "tcp", DstPortSet: []string{"443"}})
+	if err != nil {
+		t.Fatalf("Error creating security protocol: %s", err)
+	}
+	for _, direction := range []string{SecurityRuleEgress, SecurityRuleIngress} {
+		_, err := client.SecurityRules().CreateSecurityRule(&CreateSecurityRuleInput{
+			Name:          "https-" + direction,
+			ACL:           "acl",
+			Enabled:       true,
+			FlowDirection: direction,
+			SecProtocols:  []string{"https"},
+		})
+		if err != nil {
+			t.Fatalf("Error creating security rule: %s", err)
+		}
+	}
+	for name, network := range map[string]string{"web1": "web", "db1": "db"} {
+		_, err := client.Instances().CreateInstance(&CreateInstanceInput{
+			Name:  name,
+			Label: name,
+			Shape: "oc3",
+			Networking: map[string]NetworkingInfo{
+				"eth0": {IPNetwork: network, Vnic: name + "_eth0", VnicSets: []string{"servers"}},
+			},
+			PollInterval: 10 * time.Millisecond,
+			Timeout:      5 * time.Second,
+		})
+		if err != nil {
+			t.Fatalf("Error creating instance: %s", err)
+		}
+	}
+
+	topology, err := client.LoadNetworkTopology(nil)
+	if err != nil {
+		t.Fatalf("Error loading network topology: %s", err)
+	}
+	if len(topology.IPNetworks) != 2 || len(topology.Instances) != 2 || len(topology.SecurityRules) != 2 {
+		t.Fatalf("Expected 2 IP networks, instances and security rules, got %+v", topology)
+	}
+	if problems := topology.Validate(); len(problems) != 0 {
+		t.Fatalf("Expected no problems with the topology, got %v", problems)
+	}
+
+	interfaces, err := topology.InstanceInterfaces("web1")
+	if err != nil {
+		t.Fatalf("Error getting instance interfaces: %s", err)
+	}
+	if len(interfaces) != 1 || interfaces[0].IPNetwork != "web" || !reflect.DeepEqual(interfaces[0].VnicSets, []string{"servers"}) {
+		t.Fatalf("Expected web1 to have an interface on the web IP network in the servers vNIC set, got %+v", interfaces)
+	}
+
+	for port, reachable := range map[int]bool{443: true, 80: false} {
+		reachability, err := topology.CanReach(&ReachabilityInput{Source: "web1", Destination: "db1", Protocol: "tcp", Port: port})
+		if err != nil {
+			t.Fatalf("Error checking reachability: %s", err)
+		}
+		if reachability.Reachable != reachable {
+			t.Fatalf("Expected reachability of db1 on port %d to be %t, got %+v", port, reachable, reachability)
+		}
+	}
+}
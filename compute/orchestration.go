@@ -1,6 +1,7 @@
 package compute
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -75,6 +76,42 @@ type OrchestrationType string
 const (
 	// OrchestrationTypeInstance - Instance
 	OrchestrationTypeInstance OrchestrationType = "Instance"
+	// OrchestrationTypeStorageVolume - StorageVolume
+	OrchestrationTypeStorageVolume OrchestrationType = "StorageVolume"
+	// OrchestrationTypeStorageAttachment - StorageAttachment
+	OrchestrationTypeStorageAttachment OrchestrationType = "StorageAttachment"
+	// OrchestrationTypeSSHKey - SSHKey
+	OrchestrationTypeSSHKey OrchestrationType = "SSHKey"
+	// OrchestrationTypeIPNetwork - IpNetwork
+	OrchestrationTypeIPNetwork OrchestrationType = "IpNetwork"
+	// OrchestrationTypeIPNetworkExchange - IpNetworkExchange
+	OrchestrationTypeIPNetworkExchange OrchestrationType = "IpNetworkExchange"
+	// OrchestrationTypeVirtualNICSet - VirtualNicSet
+	OrchestrationTypeVirtualNICSet OrchestrationType = "VirtualNicSet"
+	// OrchestrationTypeACL - Acl
+	OrchestrationTypeACL OrchestrationType = "Acl"
+	// OrchestrationTypeSecurityRule - SecurityRule
+	OrchestrationTypeSecurityRule OrchestrationType = "SecurityRule"
+	// OrchestrationTypeIPAddressPrefixSet - IpAddressPrefixSet
+	OrchestrationTypeIPAddressPrefixSet OrchestrationType = "IpAddressPrefixSet"
+	// OrchestrationTypeSecurityProtocol - SecurityProtocol
+	OrchestrationTypeSecurityProtocol OrchestrationType = "SecurityProtocol"
+	// OrchestrationTypeIPAddressReservation - IpAddressReservation
+	OrchestrationTypeIPAddressReservation OrchestrationType = "IpAddressReservation"
+	// OrchestrationTypeIPAddressAssociation - IpAddressAssociation
+	OrchestrationTypeIPAddressAssociation OrchestrationType = "IpAddressAssociation"
+	// OrchestrationTypeRoute - Route
+	OrchestrationTypeRoute OrchestrationType = "Route"
+	// OrchestrationTypeIPReservation - IpReservation
+	OrchestrationTypeIPReservation OrchestrationType = "IpReservation"
+	// OrchestrationTypeSecurityList - SecList
+	OrchestrationTypeSecurityList OrchestrationType = "SecList"
+	// OrchestrationTypeSecurityIPList - SecIpList
+	OrchestrationTypeSecurityIPList OrchestrationType = "SecIpList"
+	// OrchestrationTypeSecurityApplication - SecApplication
+	OrchestrationTypeSecurityApplication OrchestrationType = "SecApplication"
+	// OrchestrationTypeSecRule - SecRule
+	OrchestrationTypeSecRule OrchestrationType = "SecRule"
 )
 
 // OrchestrationRelationshipType defines the orchestration relationship type for an orchestration
@@ -201,10 +238,11 @@ type Object struct {
 	// For example, if you want to create a storage volume, the type would be StorageVolume, and the template would include
 	// size and bootable. If you want to create an instance, the type would be Instance, and the template would include
 	// instance-specific attributes, such as imagelist and shape.
+	// Use an ObjectTemplate, such as a *CreateInstanceInput or *CreateStorageVolumeInput, for the
+	// names in the template to be qualified when the orchestration is created.
 	// Required
 	Template interface{} `json:"template"`
-	// Specify one of the following object types that you want to create.
-	// The only allowed type is Instance
+	// Specify one of the OrchestrationType object types that you want to create, matching the template.
 	// Required
 	Type OrchestrationType `json:"type"`
 	// Version of this object, generated by the server
@@ -239,36 +277,26 @@ type Relationship struct {
 func (c *OrchestrationsClient) CreateOrchestration(input *CreateOrchestrationInput) (*Orchestration, error) {
 	var createdOrchestration Orchestration
 
+	if err := ValidateOrchestrationObjects(input.Objects); err != nil {
+		return nil, err
+	}
+
 	input.Name = c.getQualifiedName(input.Name)
-	for _, i := range input.Objects {
+	// Qualify copies of the objects, so the caller's templates can be used to update the orchestration
+	qualifiedInput := *input
+	qualifiedInput.Objects = make([]Object, len(input.Objects))
+	for n, i := range input.Objects {
 		i.Orchestration = c.getQualifiedName(i.Orchestration)
-		if i.Type == OrchestrationTypeInstance {
-			instanceClient := c.Client.Instances()
-			instanceInput := i.Template.(*CreateInstanceInput)
-			instanceInput.Name = c.getQualifiedName(instanceInput.Name)
-
-			qualifiedSSHKeys := []string{}
-			for _, key := range instanceInput.SSHKeys {
-				qualifiedSSHKeys = append(qualifiedSSHKeys, c.getQualifiedName(key))
+		if template, ok := i.Template.(ObjectTemplate); ok {
+			var err error
+			if i.Template, err = template.qualified(c.Client); err != nil {
+				return nil, err
 			}
-
-			instanceInput.SSHKeys = qualifiedSSHKeys
-
-			qualifiedStorageAttachments := []StorageAttachmentInput{}
-			for _, attachment := range instanceInput.Storage {
-				qualifiedStorageAttachments = append(qualifiedStorageAttachments, StorageAttachmentInput{
-					Index:  attachment.Index,
-					Volume: c.getQualifiedName(attachment.Volume),
-				})
-			}
-			instanceInput.Storage = qualifiedStorageAttachments
-
-			instanceInput.Networking = instanceClient.qualifyNetworking(instanceInput.Networking)
-
 		}
+		qualifiedInput.Objects[n] = i
 	}
 
-	if err := c.createResource(&input, &createdOrchestration); err != nil {
+	if err := c.createResource(&qualifiedInput, &createdOrchestration); err != nil {
 		return nil, err
 	}
 
@@ -295,7 +323,7 @@ func (c *OrchestrationsClient) CreateOrchestration(input *CreateOrchestrationInp
 		if err != nil {
 			return nil, fmt.Errorf("Error deleting orchestration %s: %s", getInput.Name, err)
 		}
-		return nil, fmt.Errorf("Error creating orchestration %s: %s", getInput.Name, orchestrationError)
+		return nil, fmt.Errorf("Error creating orchestration %s: %w", getInput.Name, orchestrationError)
 	}
 
 	return orchestrationInfo, nil
@@ -317,6 +345,30 @@ func (c *OrchestrationsClient) GetOrchestration(input *GetOrchestrationInput) (*
 	return c.success(&orchestrationInfo)
 }
 
+// ListOrchestrations retrieves the orchestrations in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *OrchestrationsClient) ListOrchestrations(input *ListInput) ([]Orchestration, error) {
+	var list struct {
+		Result []Orchestration `json:"result"`
+	}
+	if err := c.listResource(input, &list); err != nil {
+		return nil, err
+	}
+
+	orchestrations := []Orchestration{}
+	for i := range list.Result {
+		info, err := c.success(&list.Result[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, info.Tags) {
+			orchestrations = append(orchestrations, *info)
+		}
+	}
+
+	return orchestrations, nil
+}
+
 // UpdateOrchestrationInput defines an Orchestration to be updated
 type UpdateOrchestrationInput struct {
 	// The default Oracle Compute Cloud Service account, such as /Compute-acme/default.
@@ -353,16 +405,29 @@ type UpdateOrchestrationInput struct {
 // UpdateOrchestration updates the orchestration.
 func (c *OrchestrationsClient) UpdateOrchestration(input *UpdateOrchestrationInput) (*Orchestration, error) {
 	var updatedOrchestration Orchestration
+	if err := ValidateOrchestrationObjects(input.Objects); err != nil {
+		return nil, err
+	}
+
 	input.Name = c.getQualifiedName(input.Name)
-	for _, i := range input.Objects {
+	// Qualify copies of the objects, so the caller's templates can be used to update the orchestration again
+	qualifiedInput := *input
+	qualifiedInput.Objects = make([]Object, len(input.Objects))
+	for n, i := range input.Objects {
 		i.Orchestration = c.getQualifiedName(i.Orchestration)
-		if i.Type == OrchestrationTypeInstance {
+		if template, ok := i.Template.(ObjectTemplate); ok {
+			var err error
+			if i.Template, err = template.qualified(c.Client); err != nil {
+				return nil, err
+			}
+		} else if i.Type == OrchestrationTypeInstance {
 			instanceInput := i.Template.(map[string]interface{})
 			instanceInput["name"] = c.getQualifiedName(instanceInput["name"].(string))
 		}
+		qualifiedInput.Objects[n] = i
 	}
 
-	if err := c.updateResource(input.Name, input, &updatedOrchestration); err != nil {
+	if err := c.updateResource(input.Name, &qualifiedInput, &updatedOrchestration); err != nil {
 		return nil, err
 	}
 
@@ -432,7 +497,7 @@ func (c *OrchestrationsClient) success(info *Orchestration) (*Orchestration, err
 func (c *OrchestrationsClient) WaitForOrchestrationState(input *GetOrchestrationInput, pollInterval, timeout time.Duration) (*Orchestration, error) {
 	var info *Orchestration
 	var getErr error
-	err := c.client.WaitFor("orchestration to be ready", pollInterval, timeout, func() (bool, error) {
+	ready := func() (bool, error) {
 		info, getErr = c.GetOrchestration(input)
 		if getErr != nil {
 			return false, getErr
@@ -440,13 +505,9 @@ func (c *OrchestrationsClient) WaitForOrchestrationState(input *GetOrchestration
 		c.client.DebugLogString(fmt.Sprintf("Orchestration name is %v, Orchestration info is %+v", info.Name, info))
 		switch s := info.Status; s {
 		case OrchestrationStatusError:
-			// We need to check and see if an object the orchestration is trying to create is giving us an error instead of just the orchestration as a whole.
-			for _, object := range info.Objects {
-				if object.Health.Status == OrchestrationStatusError {
-					return false, fmt.Errorf("Error creating instance %s: %+v", object.Name, object.Health)
-				}
-			}
-			return false, fmt.Errorf("Error initializing orchestration: %+v", info)
+			// Report which of the objects the orchestration is trying to create failed, and why,
+			// rather than just the orchestration as a whole.
+			return false, c.diagnose(info)
 		case OrchestrationStatus(info.DesiredState):
 			c.client.DebugLogString(fmt.Sprintf("Orchestration %s", info.DesiredState))
 			return true, nil
@@ -471,7 +532,22 @@ func (c *OrchestrationsClient) WaitForOrchestrationState(input *GetOrchestration
 		default:
 			return false, fmt.Errorf("Unknown orchestration state: %s, erroring", s)
 		}
+	}
+
+	var stopped bool
+	err := c.client.WaitFor("orchestration to be ready", pollInterval, timeout, func() (bool, error) {
+		completed, err := ready()
+		stopped = err != nil
+		return completed, err
 	})
+
+	// An orchestration can stay activating while its objects fail, so the failed objects explain the timeout
+	if err != nil && !stopped && info != nil && c.client.Context().Err() == nil {
+		var orchestrationErr *OrchestrationError
+		if diagnosis := c.diagnose(info); errors.As(diagnosis, &orchestrationErr) && len(orchestrationErr.Failed) > 0 {
+			err = fmt.Errorf("%s: %w", err, diagnosis)
+		}
+	}
 	return info, err
 }
 
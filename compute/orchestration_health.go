@@ -0,0 +1,229 @@
+package compute
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ObjectHealth details the health of a single object in an orchestration, and of the resource it manages
+type ObjectHealth struct {
+	// The label of the object in the orchestration
+	Label string
+	// The type of the object
+	Type OrchestrationType
+	// The name of the resource managed by the object, from its template
+	ResourceName string
+	// The health of the object, as reported by the orchestration
+	Health Health
+	// Whether the resource managed by the object was resolved. Only Instance and
+	// StorageVolume resources are resolved.
+	ResourceResolved bool
+	// The state of the resource managed by the object, e.g. the state of an instance or the status of a storage volume
+	ResourceState string
+	// Why the resource is in its state, e.g. the error reason of an instance
+	ResourceDetail string
+}
+
+// Failed returns whether the object, or the resource it manages, has failed
+func (o *ObjectHealth) Failed() bool {
+	if o.Health.Status == OrchestrationStatusError || o.Health.Error != "" {
+		return true
+	}
+	switch o.Type {
+	case OrchestrationTypeInstance:
+		return o.ResourceState == string(InstanceError)
+	case OrchestrationTypeStorageVolume:
+		return strings.ToLower(o.ResourceState) == "error"
+	}
+	return false
+}
+
+func (o *ObjectHealth) String() string {
+	details := []string{}
+	for _, detail := range []string{o.Health.Error, o.Health.Cause, o.Health.Detail} {
+		if detail != "" {
+			details = append(details, detail)
+		}
+	}
+	if o.ResourceResolved && o.ResourceState != "" {
+		state := fmt.Sprintf("%s is %s", strings.ToLower(string(o.Type)), o.ResourceState)
+		if o.ResourceDetail != "" {
+			state = fmt.Sprintf("%s: %s", state, o.ResourceDetail)
+		}
+		details = append(details, state)
+	}
+	if len(details) == 0 {
+		details = append(details, "no details given")
+	}
+	return fmt.Sprintf("%s %q (%s) is %s: %s", o.Type, o.Label, o.ResourceName, o.Health.Status, strings.Join(details, "; "))
+}
+
+// OrchestrationHealthReport details the health of an orchestration and each of its objects
+type OrchestrationHealthReport struct {
+	// The three-part name of the Orchestration
+	Name string
+	// The desired state of the orchestration
+	DesiredState OrchestrationDesiredState
+	// The current status of the orchestration
+	Status OrchestrationStatus
+	// The health of each object in the orchestration, in the order of the orchestration
+	Objects []ObjectHealth
+}
+
+// ByStatus groups the objects in the orchestration by their health status
+func (r *OrchestrationHealthReport) ByStatus() map[OrchestrationStatus][]ObjectHealth {
+	grouped := map[OrchestrationStatus][]ObjectHealth{}
+	for _, object := range r.Objects {
+		grouped[object.Health.Status] = append(grouped[object.Health.Status], object)
+	}
+	return grouped
+}
+
+// Failed returns the objects in the orchestration which have failed
+func (r *OrchestrationHealthReport) Failed() []ObjectHealth {
+	failed := []ObjectHealth{}
+	for _, object := range r.Objects {
+		if object.Failed() {
+			failed = append(failed, object)
+		}
+	}
+	return failed
+}
+
+// Err returns an *OrchestrationError if the orchestration or any of its objects have failed, or nil
+func (r *OrchestrationHealthReport) Err() error {
+	failed := r.Failed()
+	if r.Status != OrchestrationStatusError && len(failed) == 0 {
+		return nil
+	}
+	return &OrchestrationError{
+		Name:   r.Name,
+		Status: r.Status,
+		Failed: failed,
+	}
+}
+
+// OrchestrationError describes an orchestration which has failed, and why each of its failed objects failed
+type OrchestrationError struct {
+	// The three-part name of the Orchestration
+	Name string
+	// The status of the orchestration
+	Status OrchestrationStatus
+	// The objects in the orchestration which have failed
+	Failed []ObjectHealth
+}
+
+func (e *OrchestrationError) Error() string {
+	if len(e.Failed) == 0 {
+		return fmt.Sprintf("Orchestration %s is %s, but none of its objects report an error", e.Name, e.Status)
+	}
+	failures := []string{}
+	for _, object := range e.Failed {
+		failures = append(failures, object.String())
+	}
+	return fmt.Sprintf("Orchestration %s is %s, %d object(s) failed: %s", e.Name, e.Status, len(e.Failed), strings.Join(failures, ", "))
+}
+
+// GetOrchestrationHealth retrieves the orchestration and reports the health of each of its objects,
+// resolving the instances and storage volumes they manage to include their state.
+func (c *OrchestrationsClient) GetOrchestrationHealth(input *GetOrchestrationInput) (*OrchestrationHealthReport, error) {
+	info, err := c.GetOrchestration(input)
+	if err != nil {
+		return nil, err
+	}
+	return c.healthReport(info)
+}
+
+func (c *OrchestrationsClient) healthReport(info *Orchestration) (*OrchestrationHealthReport, error) {
+	report := newHealthReport(info)
+	for i := range report.Objects {
+		if err := c.resolveObjectResource(&report.Objects[i]); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// newHealthReport returns the health of the orchestration's objects, without resolving their resources
+func newHealthReport(info *Orchestration) *OrchestrationHealthReport {
+	report := &OrchestrationHealthReport{
+		Name:         info.Name,
+		DesiredState: info.DesiredState,
+		Status:       info.Status,
+	}
+	for _, object := range info.Objects {
+		report.Objects = append(report.Objects, ObjectHealth{
+			Label:        object.Label,
+			Type:         object.Type,
+			ResourceName: templateResourceName(object.Template),
+			Health:       object.Health,
+		})
+	}
+	return report
+}
+
+// diagnose returns an *OrchestrationError describing why the orchestration failed. If the resources
+// managed by its objects can't be resolved, the error is based on the health of the objects alone.
+func (c *OrchestrationsClient) diagnose(info *Orchestration) error {
+	report, err := c.healthReport(info)
+	if err != nil {
+		c.client.DebugLogString(fmt.Sprintf("Unable to resolve the objects of orchestration %s: %s", info.Name, err))
+		report = newHealthReport(info)
+	}
+	if err := report.Err(); err != nil {
+		return err
+	}
+	return &OrchestrationError{Name: info.Name, Status: info.Status}
+}
+
+// resolveObjectResource looks up the resource managed by the object, recording its state.
+// Resources which don't exist yet, e.g. as the orchestration is still activating, are left unresolved.
+func (c *OrchestrationsClient) resolveObjectResource(health *ObjectHealth) error {
+	if health.ResourceName == "" {
+		return nil
+	}
+	switch health.Type {
+	case OrchestrationTypeInstance:
+		// The instance is named after the template, followed by its ID
+		name := c.getUnqualifiedName(c.getQualifiedName(health.ResourceName))
+		instances, err := c.Client.Instances().ListInstances(&ListInput{NamePrefix: name})
+		if err != nil {
+			return err
+		}
+		for _, instance := range instances {
+			if instance.Name == name {
+				health.ResourceResolved = true
+				health.ResourceState = string(instance.State)
+				health.ResourceDetail = instance.ErrorReason
+			}
+		}
+	case OrchestrationTypeStorageVolume:
+		volume, err := c.Client.StorageVolumes().GetStorageVolume(&GetStorageVolumeInput{Name: health.ResourceName})
+		if err != nil {
+			return err
+		}
+		if volume == nil {
+			return nil
+		}
+		health.ResourceResolved = true
+		health.ResourceState = volume.Status
+		health.ResourceDetail = volume.StatusDetail
+	}
+	return nil
+}
+
+// templateResourceName returns the name of the resource in an object's template, either a typed
+// ObjectTemplate or the template returned by the API
+func templateResourceName(template interface{}) string {
+	body, err := json.Marshal(template)
+	if err != nil {
+		return ""
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return ""
+	}
+	name, _ := fields["name"].(string)
+	return name
+}
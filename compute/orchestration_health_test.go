@@ -0,0 +1,118 @@
+package compute
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-oracle-terraform/compute/computetest"
+)
+
+const orchestrationHealthTestPath = "/platform/v1/orchestration/Compute-test-domain/test-user/orchestration"
+
+// createHealthTestOrchestration creates an active orchestration of a single instance, returning the
+// path of the instance it launched
+func createHealthTestOrchestration(t *testing.T) (*OrchestrationsClient, *computetest.Server, string) {
+	client, server := newFakeComputeClient(t)
+	orchestrations := client.Orchestrations()
+	_, err := orchestrations.CreateOrchestration(&CreateOrchestrationInput{
+		Name:         "orchestration",
+		DesiredState: OrchestrationDesiredStateActive,
+		Objects: []Object{
+			NewObject("orchestration", "instance1", &CreateInstanceInput{Name: "instance1", Label: "instance1", Shape: "oc3"}),
+		},
+		PollInterval: 10 * time.Millisecond,
+		Timeout:      5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("error creating orchestration: %s", err)
+	}
+
+	instance, err := client.Instances().GetInstanceFromName(&GetInstanceIDInput{Name: "instance1"})
+	if err != nil {
+		t.Fatalf("error getting orchestrated instance: %s", err)
+	}
+	return orchestrations, server, "/instance/Compute-test-domain/test-user/instance1/" + instance.ID
+}
+
+// failHealthTestOrchestration sets the status of the orchestration, with its object in a terminal error
+func failHealthTestOrchestration(t *testing.T, server *computetest.Server, status OrchestrationStatus) {
+	orchestration, _ := server.Resource(orchestrationHealthTestPath)
+	objects := orchestration["objects"].([]interface{})
+	objects[0].(map[string]interface{})["health"] = map[string]interface{}{
+		"status": "terminal_error",
+		"cause":  "Instance failed to start",
+	}
+	err := server.SetResource(orchestrationHealthTestPath, map[string]interface{}{
+		"status":  string(status),
+		"objects": objects,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOrchestrationsClient_GetOrchestrationHealth(t *testing.T) {
+	orchestrations, server, instancePath := createHealthTestOrchestration(t)
+
+	report, err := orchestrations.GetOrchestrationHealth(&GetOrchestrationInput{Name: "orchestration"})
+	if err != nil {
+		t.Fatalf("error getting orchestration health: %s", err)
+	}
+	if err := report.Err(); err != nil {
+		t.Fatalf("Expected a healthy orchestration, got %s", err)
+	}
+	if len(report.ByStatus()[OrchestrationStatusActive]) != 1 || report.Objects[0].ResourceState != string(InstanceRunning) {
+		t.Fatalf("Expected an active object with a running instance, got %+v", report.Objects)
+	}
+
+	failHealthTestOrchestration(t, server, OrchestrationStatusError)
+	if err := server.SetResource(instancePath, map[string]interface{}{"state": "error", "error_reason": "Out of capacity"}); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err = orchestrations.GetOrchestrationHealth(&GetOrchestrationInput{Name: "orchestration"})
+	if err != nil {
+		t.Fatalf("error getting orchestration health: %s", err)
+	}
+	if len(report.ByStatus()[OrchestrationStatusError]) != 1 || len(report.Failed()) != 1 {
+		t.Fatalf("Expected a failed object, got %+v", report.Objects)
+	}
+
+	var orchestrationErr *OrchestrationError
+	if !errors.As(report.Err(), &orchestrationErr) || orchestrationErr.Failed[0].Label != "instance1" {
+		t.Fatalf("Expected an orchestration error for instance1, got %v", report.Err())
+	}
+	for _, expected := range []string{"Instance failed to start", "instance is error: Out of capacity"} {
+		if !strings.Contains(orchestrationErr.Error(), expected) {
+			t.Fatalf("Expected the error to include %q, got %s", expected, orchestrationErr)
+		}
+	}
+}
+
+func TestOrchestrationsClient_WaitForOrchestrationStateObjectError(t *testing.T) {
+	orchestrations, server, _ := createHealthTestOrchestration(t)
+	getInput := &GetOrchestrationInput{Name: "orchestration"}
+
+	// The service may still recover an object while the orchestration is activating, so it's waited
+	// for, but the failed object is reported when the wait times out
+	failHealthTestOrchestration(t, server, OrchestrationStatusActivating)
+	_, err := orchestrations.WaitForOrchestrationState(getInput, 10*time.Millisecond, 100*time.Millisecond)
+	var orchestrationErr *OrchestrationError
+	if !errors.As(err, &orchestrationErr) || len(orchestrationErr.Failed) != 1 || orchestrationErr.Failed[0].Label != "instance1" {
+		t.Fatalf("Expected the timeout to report the failed object, got %v", err)
+	}
+	for _, expected := range []string{"Timeout after", "Instance failed to start"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Fatalf("Expected the error to include %q, got %s", expected, err)
+		}
+	}
+
+	// Once the orchestration is in error, the failed object is reported
+	failHealthTestOrchestration(t, server, OrchestrationStatusError)
+	_, err = orchestrations.WaitForOrchestrationState(getInput, 10*time.Millisecond, 5*time.Second)
+	if !errors.As(err, &orchestrationErr) || len(orchestrationErr.Failed) != 1 {
+		t.Fatalf("Expected an orchestration error, got %v", err)
+	}
+}
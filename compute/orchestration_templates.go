@@ -0,0 +1,462 @@
+package compute
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// maxOrchestrationObjects is the maximum number of objects in an orchestration
+const maxOrchestrationObjects = 100
+
+// maxOrchestrationLabelLength is the maximum length of the label of an orchestration object
+const maxOrchestrationLabelLength = 256
+
+// objectReference matches a reference to an attribute of another object in the same orchestration,
+// e.g. {{my-volume:name}}, capturing the label of the referenced object
+var objectReference = regexp.MustCompile(`\{\{([^:{}]+):[^{}]*\}\}`)
+
+// ObjectTemplate is the template of an orchestration object of a specific type. The Create*Input of
+// each object type which can be created by an orchestration implements ObjectTemplate, e.g.
+// *CreateInstanceInput for Instance objects and *CreateStorageVolumeInput for StorageVolume objects.
+type ObjectTemplate interface {
+	// OrchestrationType returns the type of the orchestration object the template defines
+	OrchestrationType() OrchestrationType
+	// qualified returns a copy of the template with the names of the object, and the objects it
+	// references, qualified. The template itself is left unchanged, so it can be used again.
+	qualified(c *Client) (ObjectTemplate, error)
+}
+
+// NewObject returns an orchestration object of the template's type, which depends on the objects with the
+// labels in dependsOn being created first
+func NewObject(orchestration, label string, template ObjectTemplate, dependsOn ...string) Object {
+	object := Object{
+		Label:         label,
+		Orchestration: orchestration,
+		Template:      template,
+		Type:          template.OrchestrationType(),
+	}
+	if len(dependsOn) > 0 {
+		object.Relationships = []Relationship{
+			{
+				Type:    OrchestrationRelationshipTypeDepends,
+				Targets: dependsOn,
+			},
+		}
+	}
+	return object
+}
+
+// ValidateOrchestrationObjects checks the objects of an orchestration before it's created or updated,
+// returning an error describing every problem found. The objects must have unique labels without spaces,
+// their templates must match their types, their relationships must target other objects in the
+// orchestration and there must be no more than 100 objects. The dependencies between the objects,
+// from their relationships and the {{label:attribute}} references in their templates, must not form a cycle.
+func ValidateOrchestrationObjects(objects []Object) error {
+	problems := []string{}
+	if len(objects) == 0 {
+		problems = append(problems, "an orchestration must contain at least one object")
+	}
+	if len(objects) > maxOrchestrationObjects {
+		problems = append(problems, fmt.Sprintf("an orchestration can contain up to %d objects, got %d", maxOrchestrationObjects, len(objects)))
+	}
+
+	labels := map[string]bool{}
+	for _, object := range objects {
+		switch {
+		case object.Label == "":
+			problems = append(problems, "every object requires a label")
+		case strings.ContainsAny(object.Label, " \t\n"):
+			problems = append(problems, fmt.Sprintf("label %q can't include spaces", object.Label))
+		case len(object.Label) > maxOrchestrationLabelLength:
+			problems = append(problems, fmt.Sprintf("label %q is longer than %d characters", object.Label, maxOrchestrationLabelLength))
+		case labels[object.Label]:
+			problems = append(problems, fmt.Sprintf("label %q is used by more than one object", object.Label))
+		}
+		labels[object.Label] = true
+	}
+
+	dependencies := map[string][]string{}
+	for _, object := range objects {
+		if object.Type == "" {
+			problems = append(problems, fmt.Sprintf("object %q requires a type", object.Label))
+		}
+		if object.Template == nil {
+			problems = append(problems, fmt.Sprintf("object %q requires a template", object.Label))
+		}
+		if template, ok := object.Template.(ObjectTemplate); ok && template.OrchestrationType() != object.Type {
+			problems = append(problems, fmt.Sprintf("object %q is of type %s, but has a %s template", object.Label, object.Type, template.OrchestrationType()))
+		}
+
+		targets := []string{}
+		for _, relationship := range object.Relationships {
+			if relationship.Type != OrchestrationRelationshipTypeDepends {
+				problems = append(problems, fmt.Sprintf("object %q has an unsupported relationship type %q", object.Label, relationship.Type))
+			}
+			if len(relationship.Targets) == 0 {
+				problems = append(problems, fmt.Sprintf("object %q has a relationship without any targets", object.Label))
+			}
+			targets = append(targets, relationship.Targets...)
+		}
+		references, err := templateReferences(object.Template)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("object %q has an invalid template: %s", object.Label, err))
+		}
+		targets = append(targets, references...)
+
+		for _, target := range targets {
+			switch {
+			case target == object.Label:
+				problems = append(problems, fmt.Sprintf("object %q depends on itself", object.Label))
+			case !labels[target]:
+				problems = append(problems, fmt.Sprintf("object %q depends on %q, which isn't in the orchestration", object.Label, target))
+			default:
+				dependencies[object.Label] = append(dependencies[object.Label], target)
+			}
+		}
+	}
+
+	if cycle := findDependencyCycle(dependencies); cycle != nil {
+		problems = append(problems, fmt.Sprintf("the objects have a circular dependency: %s", strings.Join(cycle, " -> ")))
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("Invalid orchestration: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// templateReferences returns the labels of the objects referenced in the template
+func templateReferences(template interface{}) ([]string, error) {
+	if template == nil {
+		return nil, nil
+	}
+	body, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+	references := []string{}
+	for _, match := range objectReference.FindAllStringSubmatch(string(body), -1) {
+		references = append(references, match[1])
+	}
+	return references, nil
+}
+
+// findDependencyCycle returns the labels of a cycle in the dependencies between objects, starting and ending
+// with the same label, or nil if there isn't one
+func findDependencyCycle(dependencies map[string][]string) []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := map[string]int{}
+	path := []string{}
+
+	var visit func(label string) []string
+	visit = func(label string) []string {
+		state[label] = visiting
+		path = append(path, label)
+		for _, target := range dependencies[label] {
+			switch state[target] {
+			case visiting:
+				for i := range path {
+					if path[i] == target {
+						return append(append([]string{}, path[i:]...), target)
+					}
+				}
+			case unvisited:
+				if cycle := visit(target); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[label] = visited
+		return nil
+	}
+
+	// Visit the objects in a stable order, so the same cycle is always reported
+	labels := []string{}
+	for label := range dependencies {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	for _, label := range labels {
+		if state[label] == unvisited {
+			if cycle := visit(label); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// qualifyTemplateName qualifies a name in a template, leaving references to other objects in the
+// orchestration as they are
+func qualifyTemplateName(c *Client, name string) string {
+	if objectReference.MatchString(name) {
+		return name
+	}
+	return c.getQualifiedName(name)
+}
+
+func qualifyTemplateList(c *Client, names []string) []string {
+	if len(names) == 0 {
+		return names
+	}
+	qualified := []string{}
+	for _, name := range names {
+		qualified = append(qualified, qualifyTemplateName(c, name))
+	}
+	return qualified
+}
+
+// OrchestrationType returns OrchestrationTypeInstance
+func (input *CreateInstanceInput) OrchestrationType() OrchestrationType {
+	return OrchestrationTypeInstance
+}
+
+func (input *CreateInstanceInput) qualified(c *Client) (ObjectTemplate, error) {
+	qualified := *input
+	qualified.Name = qualifyTemplateName(c, input.Name)
+	qualified.SSHKeys = qualifyTemplateList(c, input.SSHKeys)
+	if input.Storage != nil {
+		qualified.Storage = make([]StorageAttachmentInput, len(input.Storage))
+		for i, attachment := range input.Storage {
+			attachment.Volume = qualifyTemplateName(c, attachment.Volume)
+			qualified.Storage[i] = attachment
+		}
+	}
+	qualified.Networking = c.Instances().qualifyNetworking(input.Networking)
+	return &qualified, nil
+}
+
+// OrchestrationType returns OrchestrationTypeStorageVolume
+func (input *CreateStorageVolumeInput) OrchestrationType() OrchestrationType {
+	return OrchestrationTypeStorageVolume
+}
+
+func (input *CreateStorageVolumeInput) qualified(c *Client) (ObjectTemplate, error) {
+	qualified := *input
+	qualified.Name = qualifyTemplateName(c, input.Name)
+	qualified.ImageList = qualifyTemplateName(c, input.ImageList)
+	size, err := sizeInBytes(input.Size)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid size %q of storage volume %s: %s", input.Size, input.Name, err)
+	}
+	qualified.Size = size
+	return &qualified, nil
+}
+
+// OrchestrationType returns OrchestrationTypeStorageAttachment
+func (input *CreateStorageAttachmentInput) OrchestrationType() OrchestrationType {
+	return OrchestrationTypeStorageAttachment
+}
+
+func (input *CreateStorageAttachmentInput) qualified(c *Client) (ObjectTemplate, error) {
+	qualified := *input
+	qualified.InstanceName = qualifyTemplateName(c, input.InstanceName)
+	qualified.StorageVolumeName = qualifyTemplateName(c, input.StorageVolumeName)
+	return &qualified, nil
+}
+
+// OrchestrationType returns OrchestrationTypeSSHKey
+func (input *CreateSSHKeyInput) OrchestrationType() OrchestrationType {
+	return OrchestrationTypeSSHKey
+}
+
+func (input *CreateSSHKeyInput) qualified(c *Client) (ObjectTemplate, error) {
+	qualified := *input
+	qualified.Name = qualifyTemplateName(c, input.Name)
+	return &qualified, nil
+}
+
+// OrchestrationType returns OrchestrationTypeIPNetwork
+func (input *CreateIPNetworkInput) OrchestrationType() OrchestrationType {
+	return OrchestrationTypeIPNetwork
+}
+
+func (input *CreateIPNetworkInput) qualified(c *Client) (ObjectTemplate, error) {
+	qualified := *input
+	qualified.Name = qualifyTemplateName(c, input.Name)
+	qualified.IPNetworkExchange = qualifyTemplateName(c, input.IPNetworkExchange)
+	return &qualified, nil
+}
+
+// OrchestrationType returns OrchestrationTypeIPNetworkExchange
+func (input *CreateIPNetworkExchangeInput) OrchestrationType() OrchestrationType {
+	return OrchestrationTypeIPNetworkExchange
+}
+
+func (input *CreateIPNetworkExchangeInput) qualified(c *Client) (ObjectTemplate, error) {
+	qualified := *input
+	qualified.Name = qualifyTemplateName(c, input.Name)
+	return &qualified, nil
+}
+
+// OrchestrationType returns OrchestrationTypeVirtualNICSet
+func (input *CreateVirtualNICSetInput) OrchestrationType() OrchestrationType {
+	return OrchestrationTypeVirtualNICSet
+}
+
+func (input *CreateVirtualNICSetInput) qualified(c *Client) (ObjectTemplate, error) {
+	qualified := *input
+	qualified.Name = qualifyTemplateName(c, input.Name)
+	qualified.AppliedACLs = qualifyTemplateList(c, input.AppliedACLs)
+	qualified.VirtualNICs = qualifyTemplateList(c, input.VirtualNICs)
+	return &qualified, nil
+}
+
+// OrchestrationType returns OrchestrationTypeACL
+func (input *CreateACLInput) OrchestrationType() OrchestrationType {
+	return OrchestrationTypeACL
+}
+
+func (input *CreateACLInput) qualified(c *Client) (ObjectTemplate, error) {
+	qualified := *input
+	qualified.Name = qualifyTemplateName(c, input.Name)
+	return &qualified, nil
+}
+
+// OrchestrationType returns OrchestrationTypeSecurityRule
+func (input *CreateSecurityRuleInput) OrchestrationType() OrchestrationType {
+	return OrchestrationTypeSecurityRule
+}
+
+func (input *CreateSecurityRuleInput) qualified(c *Client) (ObjectTemplate, error) {
+	qualified := *input
+	qualified.Name = qualifyTemplateName(c, input.Name)
+	qualified.ACL = qualifyTemplateName(c, input.ACL)
+	qualified.SrcVnicSet = qualifyTemplateName(c, input.SrcVnicSet)
+	qualified.DstVnicSet = qualifyTemplateName(c, input.DstVnicSet)
+	qualified.SrcIPAddressPrefixSets = qualifyTemplateList(c, input.SrcIPAddressPrefixSets)
+	qualified.DstIPAddressPrefixSets = qualifyTemplateList(c, input.DstIPAddressPrefixSets)
+	qualified.SecProtocols = qualifyTemplateList(c, input.SecProtocols)
+	return &qualified, nil
+}
+
+// OrchestrationType returns OrchestrationTypeIPAddressPrefixSet
+func (input *CreateIPAddressPrefixSetInput) OrchestrationType() OrchestrationType {
+	return OrchestrationTypeIPAddressPrefixSet
+}
+
+func (input *CreateIPAddressPrefixSetInput) qualified(c *Client) (ObjectTemplate, error) {
+	qualified := *input
+	qualified.Name = qualifyTemplateName(c, input.Name)
+	return &qualified, nil
+}
+
+// OrchestrationType returns OrchestrationTypeSecurityProtocol
+func (input *CreateSecurityProtocolInput) OrchestrationType() OrchestrationType {
+	return OrchestrationTypeSecurityProtocol
+}
+
+func (input *CreateSecurityProtocolInput) qualified(c *Client) (ObjectTemplate, error) {
+	qualified := *input
+	qualified.Name = qualifyTemplateName(c, input.Name)
+	return &qualified, nil
+}
+
+// OrchestrationType returns OrchestrationTypeIPAddressReservation
+func (input *CreateIPAddressReservationInput) OrchestrationType() OrchestrationType {
+	return OrchestrationTypeIPAddressReservation
+}
+
+func (input *CreateIPAddressReservationInput) qualified(c *Client) (ObjectTemplate, error) {
+	qualified := *input
+	qualified.Name = qualifyTemplateName(c, input.Name)
+	if input.IPAddressPool != "" {
+		qualified.IPAddressPool = c.IPAddressReservations().qualifyIPAddressPool(input.IPAddressPool)
+	}
+	return &qualified, nil
+}
+
+// OrchestrationType returns OrchestrationTypeIPAddressAssociation
+func (input *CreateIPAddressAssociationInput) OrchestrationType() OrchestrationType {
+	return OrchestrationTypeIPAddressAssociation
+}
+
+func (input *CreateIPAddressAssociationInput) qualified(c *Client) (ObjectTemplate, error) {
+	qualified := *input
+	qualified.Name = qualifyTemplateName(c, input.Name)
+	qualified.IPAddressReservation = qualifyTemplateName(c, input.IPAddressReservation)
+	qualified.Vnic = qualifyTemplateName(c, input.Vnic)
+	return &qualified, nil
+}
+
+// OrchestrationType returns OrchestrationTypeRoute
+func (input *CreateRouteInput) OrchestrationType() OrchestrationType {
+	return OrchestrationTypeRoute
+}
+
+func (input *CreateRouteInput) qualified(c *Client) (ObjectTemplate, error) {
+	qualified := *input
+	qualified.Name = qualifyTemplateName(c, input.Name)
+	qualified.NextHopVnicSet = qualifyTemplateName(c, input.NextHopVnicSet)
+	return &qualified, nil
+}
+
+// OrchestrationType returns OrchestrationTypeIPReservation
+func (input *CreateIPReservationInput) OrchestrationType() OrchestrationType {
+	return OrchestrationTypeIPReservation
+}
+
+func (input *CreateIPReservationInput) qualified(c *Client) (ObjectTemplate, error) {
+	qualified := *input
+	qualified.Name = qualifyTemplateName(c, input.Name)
+	return &qualified, nil
+}
+
+// OrchestrationType returns OrchestrationTypeSecurityList
+func (input *CreateSecurityListInput) OrchestrationType() OrchestrationType {
+	return OrchestrationTypeSecurityList
+}
+
+func (input *CreateSecurityListInput) qualified(c *Client) (ObjectTemplate, error) {
+	qualified := *input
+	qualified.Name = qualifyTemplateName(c, input.Name)
+	return &qualified, nil
+}
+
+// OrchestrationType returns OrchestrationTypeSecurityIPList
+func (input *CreateSecurityIPListInput) OrchestrationType() OrchestrationType {
+	return OrchestrationTypeSecurityIPList
+}
+
+func (input *CreateSecurityIPListInput) qualified(c *Client) (ObjectTemplate, error) {
+	qualified := *input
+	qualified.Name = qualifyTemplateName(c, input.Name)
+	return &qualified, nil
+}
+
+// OrchestrationType returns OrchestrationTypeSecurityApplication
+func (input *CreateSecurityApplicationInput) OrchestrationType() OrchestrationType {
+	return OrchestrationTypeSecurityApplication
+}
+
+func (input *CreateSecurityApplicationInput) qualified(c *Client) (ObjectTemplate, error) {
+	qualified := *input
+	qualified.Name = qualifyTemplateName(c, input.Name)
+	return &qualified, nil
+}
+
+// OrchestrationType returns OrchestrationTypeSecRule
+func (input *CreateSecRuleInput) OrchestrationType() OrchestrationType {
+	return OrchestrationTypeSecRule
+}
+
+func (input *CreateSecRuleInput) qualified(c *Client) (ObjectTemplate, error) {
+	qualified := *input
+	qualified.Name = qualifyTemplateName(c, input.Name)
+	if !objectReference.MatchString(input.SourceList) {
+		qualified.SourceList = c.getQualifiedListName(input.SourceList)
+	}
+	if !objectReference.MatchString(input.DestinationList) {
+		qualified.DestinationList = c.getQualifiedListName(input.DestinationList)
+	}
+	qualified.Application = qualifyTemplateName(c, input.Application)
+	return &qualified, nil
+}
@@ -0,0 +1,174 @@
+package compute
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateOrchestrationObjects(t *testing.T) {
+	volume := NewObject("orchestration", "volume", &CreateStorageVolumeInput{Name: "volume", Size: "10"})
+	instance := NewObject("orchestration", "instance", &CreateInstanceInput{
+		Name:    "instance",
+		Shape:   "oc3",
+		Storage: []StorageAttachmentInput{{Index: 1, Volume: "{{volume:name}}"}},
+	}, "volume")
+
+	if err := ValidateOrchestrationObjects([]Object{volume, instance}); err != nil {
+		t.Fatalf("Expected the objects to be valid, got %s", err)
+	}
+
+	tooMany := []Object{}
+	for i := 0; i <= maxOrchestrationObjects; i++ {
+		tooMany = append(tooMany, NewObject("orchestration", fmt.Sprintf("key%d", i), &CreateSSHKeyInput{Name: fmt.Sprintf("key%d", i)}))
+	}
+
+	cases := []struct {
+		name     string
+		objects  []Object
+		expected string
+	}{
+		{
+			name:     "no objects",
+			expected: "at least one object",
+		},
+		{
+			name:     "too many objects",
+			objects:  tooMany,
+			expected: "up to 100 objects, got 101",
+		},
+		{
+			name: "label with spaces",
+			objects: []Object{
+				NewObject("orchestration", "my volume", &CreateStorageVolumeInput{Name: "volume", Size: "10"}),
+			},
+			expected: `label "my volume" can't include spaces`,
+		},
+		{
+			name:     "duplicate labels",
+			objects:  []Object{volume, volume},
+			expected: `label "volume" is used by more than one object`,
+		},
+		{
+			name: "mismatched template",
+			objects: []Object{
+				{Label: "volume", Type: OrchestrationTypeInstance, Template: &CreateStorageVolumeInput{Name: "volume"}},
+			},
+			expected: `object "volume" is of type Instance, but has a StorageVolume template`,
+		},
+		{
+			name:     "missing relationship target",
+			objects:  []Object{instance},
+			expected: `object "instance" depends on "volume", which isn't in the orchestration`,
+		},
+		{
+			name: "missing reference",
+			objects: []Object{
+				NewObject("orchestration", "attachment", &CreateStorageAttachmentInput{
+					InstanceName:      "{{instance:name}}",
+					StorageVolumeName: "volume",
+				}),
+			},
+			expected: `object "attachment" depends on "instance", which isn't in the orchestration`,
+		},
+		{
+			name: "cycle",
+			objects: []Object{
+				NewObject("orchestration", "a", &CreateACLInput{Name: "a"}, "c"),
+				NewObject("orchestration", "b", &CreateACLInput{Name: "b"}, "a"),
+				NewObject("orchestration", "c", &CreateVirtualNICSetInput{Name: "c", AppliedACLs: []string{"{{b:name}}"}}),
+			},
+			expected: "circular dependency: a -> c -> b -> a",
+		},
+	}
+
+	for _, tc := range cases {
+		err := ValidateOrchestrationObjects(tc.objects)
+		if err == nil || !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("%s: expected an error containing %q, got %v", tc.name, tc.expected, err)
+		}
+	}
+}
+
+func TestOrchestrationsClient_CreateOrchestrationWithTemplates(t *testing.T) {
+	client, server := newFakeComputeClient(t)
+	orchestrations := client.Orchestrations()
+
+	volume := &CreateStorageVolumeInput{Name: "volume", Size: "10"}
+	instance := &CreateInstanceInput{
+		Name:    "instance",
+		Label:   "instance",
+		Shape:   "oc3",
+		SSHKeys: []string{"key"},
+		Storage: []StorageAttachmentInput{{Index: 1, Volume: "{{volume:name}}"}},
+	}
+	input := &CreateOrchestrationInput{
+		Name:         "orchestration",
+		DesiredState: OrchestrationDesiredStateInactive,
+		Objects: []Object{
+			NewObject("orchestration", "volume", volume),
+			NewObject("orchestration", "instance", instance, "volume"),
+		},
+		PollInterval: 10 * time.Millisecond,
+		Timeout:      5 * time.Second,
+	}
+	if _, err := orchestrations.CreateOrchestration(input); err != nil {
+		t.Fatalf("error creating orchestration: %s", err)
+	}
+
+	// The templates are qualified in the request, leaving the caller's templates unchanged
+	storedTemplates := func() map[string]map[string]interface{} {
+		stored, ok := server.Resource("/platform/v1/orchestration/Compute-test-domain/test-user/orchestration")
+		if !ok {
+			t.Fatal("Expected the orchestration to be created")
+		}
+		templates := map[string]map[string]interface{}{}
+		for _, object := range stored["objects"].([]interface{}) {
+			object := object.(map[string]interface{})
+			templates[object["label"].(string)] = object["template"].(map[string]interface{})
+		}
+		return templates
+	}
+	templates := storedTemplates()
+	if templates["volume"]["name"] != "/Compute-test-domain/test-user/volume" || templates["volume"]["size"] != "10737418240" {
+		t.Fatalf("Expected the storage volume template to be qualified, got %+v", templates["volume"])
+	}
+	if templates["instance"]["name"] != "/Compute-test-domain/test-user/instance" {
+		t.Fatalf("Expected the instance template to be qualified, got %+v", templates["instance"])
+	}
+	if volume.Name != "volume" || volume.Size != "10" || instance.Name != "instance" || instance.SSHKeys[0] != "key" {
+		t.Fatalf("Expected the caller's templates to be left unchanged, got %+v and %+v", volume, instance)
+	}
+	if instance.Storage[0].Volume != "{{volume:name}}" {
+		t.Fatalf("Expected the reference to the storage volume to be left as is, got %q", instance.Storage[0].Volume)
+	}
+
+	// The same templates can be used to update the orchestration
+	_, err := orchestrations.UpdateOrchestration(&UpdateOrchestrationInput{
+		Name:         "orchestration",
+		DesiredState: OrchestrationDesiredStateInactive,
+		Objects:      input.Objects,
+		PollInterval: 10 * time.Millisecond,
+		Timeout:      5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("error updating orchestration: %s", err)
+	}
+	if size := storedTemplates()["volume"]["size"]; size != "10737418240" {
+		t.Fatalf("Expected the storage volume size to be converted once, got %v", size)
+	}
+
+	// An invalid orchestration isn't submitted
+	input.Name = "invalid"
+	input.Objects = append(input.Objects, NewObject("orchestration", "attachment", &CreateStorageAttachmentInput{
+		InstanceName:      "{{missing:name}}",
+		StorageVolumeName: "{{volume:name}}",
+	}))
+	if _, err := orchestrations.CreateOrchestration(input); err == nil {
+		t.Fatal("Expected an error creating an invalid orchestration")
+	}
+	if _, ok := server.Resource("/platform/v1/orchestration/Compute-test-domain/test-user/invalid"); ok {
+		t.Fatal("Expected the invalid orchestration not to be created")
+	}
+}
@@ -104,6 +104,30 @@ func (c *RoutesClient) GetRoute(input *GetRouteInput) (*RouteInfo, error) {
 	return c.success(&routeInfo)
 }
 
+// ListRoutes retrieves the routes in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *RoutesClient) ListRoutes(input *ListInput) ([]RouteInfo, error) {
+	var list struct {
+		Result []RouteInfo `json:"result"`
+	}
+	if err := c.listResource(input, &list); err != nil {
+		return nil, err
+	}
+
+	routes := []RouteInfo{}
+	for i := range list.Result {
+		info, err := c.success(&list.Result[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, info.Tags) {
+			routes = append(routes, *info)
+		}
+	}
+
+	return routes, nil
+}
+
 // UpdateRouteInput details the attributes needed to update a route
 type UpdateRouteInput struct {
 	// Specify 0,1, or 2 as the route's administrative distance.
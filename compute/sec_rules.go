@@ -115,6 +115,30 @@ func (c *SecRulesClient) GetSecRule(getInput *GetSecRuleInput) (*SecRuleInfo, er
 	return c.success(&ruleInfo)
 }
 
+// ListSecRules retrieves the security rules in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *SecRulesClient) ListSecRules(input *ListInput) ([]SecRuleInfo, error) {
+	var list struct {
+		Result []SecRuleInfo `json:"result"`
+	}
+	if err := c.listResource(input, &list); err != nil {
+		return nil, err
+	}
+
+	rules := []SecRuleInfo{}
+	for i := range list.Result {
+		info, err := c.success(&list.Result[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, nil) {
+			rules = append(rules, *info)
+		}
+	}
+
+	return rules, nil
+}
+
 // UpdateSecRuleInput describes a secruity rule to update
 type UpdateSecRuleInput struct {
 	// Set this parameter to PERMIT.
@@ -168,6 +168,30 @@ func (c *SecurityApplicationsClient) GetSecurityApplication(input *GetSecurityAp
 	return c.success(&appInfo)
 }
 
+// ListSecurityApplications retrieves the security applications in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *SecurityApplicationsClient) ListSecurityApplications(input *ListInput) ([]SecurityApplicationInfo, error) {
+	var list struct {
+		Result []SecurityApplicationInfo `json:"result"`
+	}
+	if err := c.listResource(input, &list); err != nil {
+		return nil, err
+	}
+
+	applications := []SecurityApplicationInfo{}
+	for i := range list.Result {
+		info, err := c.success(&list.Result[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, nil) {
+			applications = append(applications, *info)
+		}
+	}
+
+	return applications, nil
+}
+
 // DeleteSecurityApplicationInput  describes the Security Application to delete
 type DeleteSecurityApplicationInput struct {
 	// The three-part name of the Security Application (/Compute-identity_domain/user/object).
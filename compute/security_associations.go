@@ -79,6 +79,30 @@ func (c *SecurityAssociationsClient) GetSecurityAssociation(getInput *GetSecurit
 	return c.success(&assocInfo)
 }
 
+// ListSecurityAssociations retrieves the security associations in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *SecurityAssociationsClient) ListSecurityAssociations(input *ListInput) ([]SecurityAssociationInfo, error) {
+	var list struct {
+		Result []SecurityAssociationInfo `json:"result"`
+	}
+	if err := c.listResource(input, &list); err != nil {
+		return nil, err
+	}
+
+	associations := []SecurityAssociationInfo{}
+	for i := range list.Result {
+		info, err := c.success(&list.Result[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, nil) {
+			associations = append(associations, *info)
+		}
+	}
+
+	return associations, nil
+}
+
 // DeleteSecurityAssociationInput describes the security association to delete
 type DeleteSecurityAssociationInput struct {
 	// The three-part name of the Security Association (/Compute-identity_domain/user/object).
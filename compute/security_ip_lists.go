@@ -73,6 +73,30 @@ func (c *SecurityIPListsClient) GetSecurityIPList(getInput *GetSecurityIPListInp
 	return c.success(&listInfo)
 }
 
+// ListSecurityIPLists retrieves the security IP lists in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *SecurityIPListsClient) ListSecurityIPLists(input *ListInput) ([]SecurityIPListInfo, error) {
+	var list struct {
+		Result []SecurityIPListInfo `json:"result"`
+	}
+	if err := c.listResource(input, &list); err != nil {
+		return nil, err
+	}
+
+	ipLists := []SecurityIPListInfo{}
+	for i := range list.Result {
+		info, err := c.success(&list.Result[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, nil) {
+			ipLists = append(ipLists, *info)
+		}
+	}
+
+	return ipLists, nil
+}
+
 // UpdateSecurityIPListInput describes the security ip list to update
 type UpdateSecurityIPListInput struct {
 	// A description of the security IP list.
@@ -92,6 +92,30 @@ func (c *SecurityListsClient) GetSecurityList(getInput *GetSecurityListInput) (*
 	return c.success(&listInfo)
 }
 
+// ListSecurityLists retrieves the security lists in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *SecurityListsClient) ListSecurityLists(input *ListInput) ([]SecurityListInfo, error) {
+	var list struct {
+		Result []SecurityListInfo `json:"result"`
+	}
+	if err := c.listResource(input, &list); err != nil {
+		return nil, err
+	}
+
+	lists := []SecurityListInfo{}
+	for i := range list.Result {
+		info, err := c.success(&list.Result[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, nil) {
+			lists = append(lists, *info)
+		}
+	}
+
+	return lists, nil
+}
+
 // UpdateSecurityListInput defines what to update in a security list
 type UpdateSecurityListInput struct {
 	// A description of the security list.
@@ -0,0 +1,520 @@
+package compute
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultSensitivePorts are the ports which shouldn't be open to any address, used when an
+// EffectivePolicyInput doesn't specify its own: SSH and RDP.
+var DefaultSensitivePorts = []int{22, 3389}
+
+// anyPolicyValue is used for the protocol and ports of a policy entry which matches any
+const anyPolicyValue = "all"
+
+// EffectivePolicyInput specifies the vNIC or IP address to evaluate the security rules of
+type EffectivePolicyInput struct {
+	// The name of a vNIC. The policy is made up of the rules in the ACLs applied to the vNIC's vNIC sets
+	// which match the vNIC.
+	// Optional - One of Vnic and IPAddress is required
+	Vnic string
+	// An IP address. If it's the address of an instance's interface, the policy of the interface's vNIC is
+	// evaluated. Otherwise the policy is made up of the rules permitting traffic between the address and vNICs.
+	// Optional - Defaults to the IP address of the vNIC's interface, if a vNIC is given
+	IPAddress string
+	// Ports which are flagged if ingress rules permit them from any address
+	// Optional - Defaults to DefaultSensitivePorts
+	SensitivePorts []int
+}
+
+// PolicyEntry is a single row of an effective policy, permitting a protocol and range of ports between the
+// vNIC sets a security rule applies to and a peer. A rule with several protocols, port ranges or peer prefixes
+// has an entry for each combination of them.
+type PolicyEntry struct {
+	// The ACL containing the security rule
+	ACL string
+	// The name of the security rule
+	Rule string
+	// The direction of the flow, relative to the vNIC sets in AppliedTo, either ingress or egress
+	FlowDirection string
+	// The vNIC sets the rule applies to, i.e. the rule's own vNIC set if it specifies one, otherwise the
+	// vNIC sets the ACL is applied to
+	AppliedTo []string
+	// The vNIC set the peer must be in, or "" if the peer can be any vNIC or address
+	PeerVnicSet string
+	// The IP address prefix, in CIDR format, the peer's address must be in, or "" for any address
+	PeerPrefix string
+	// The IP protocol of the flow, or `all` for any protocol
+	Protocol string
+	// The range of destination ports of the flow, e.g. 22 or 8000-8080, or `all` for any port
+	Ports string
+}
+
+// PolicyFindingType specifies the kinds of finding reported by an effective policy
+type PolicyFindingType string
+
+const (
+	// PolicyShadowedRule - everything permitted by the rule is already permitted by another rule
+	PolicyShadowedRule PolicyFindingType = "shadowed_rule"
+	// PolicyOverlyPermissiveRule - the rule permits ingress from any address to a sensitive port, or of all traffic
+	PolicyOverlyPermissiveRule PolicyFindingType = "overly_permissive_rule"
+	// PolicyUnresolvedReference - the rule references a security protocol, IP address prefix set or
+	// vNIC set which doesn't exist
+	PolicyUnresolvedReference PolicyFindingType = "unresolved_reference"
+)
+
+// PolicyFinding describes a problem with a security rule in an effective policy
+type PolicyFinding struct {
+	// The kind of finding
+	Type PolicyFindingType
+	// The name of the security rule
+	Rule string
+	// Describes the finding
+	Message string
+}
+
+func (f PolicyFinding) String() string {
+	return fmt.Sprintf("%s: %s", f.Type, f.Message)
+}
+
+// EffectivePolicy details the traffic permitted to and from a vNIC or IP address by security rules
+type EffectivePolicy struct {
+	// The vNIC the policy was evaluated for, if any
+	Vnic string
+	// The IP address the policy was evaluated for, if known
+	IPAddress string
+	// The vNIC sets containing the vNIC
+	VnicSets []string
+	// The permitted traffic, ordered by security rule
+	Entries []PolicyEntry
+	// Problems with the security rules in the policy, ordered by security rule
+	Findings []PolicyFinding
+}
+
+// policyCSVHeader are the columns written by EffectivePolicy.WriteCSV
+var policyCSVHeader = []string{"acl", "rule", "flow_direction", "applied_to", "peer_vnic_set", "peer_prefix", "protocol", "ports"}
+
+// WriteCSV writes the entries of the policy as CSV, with a header row. Lists of vNIC sets are
+// separated by spaces.
+func (p *EffectivePolicy) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(policyCSVHeader); err != nil {
+		return err
+	}
+	for _, entry := range p.Entries {
+		record := []string{
+			entry.ACL,
+			entry.Rule,
+			entry.FlowDirection,
+			strings.Join(entry.AppliedTo, " "),
+			entry.PeerVnicSet,
+			entry.PeerPrefix,
+			entry.Protocol,
+			entry.Ports,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// EffectivePolicy resolves the references of the enabled security rules in enabled ACLs, and returns the traffic they
+// permit to and from the vNIC or IP address of the input, as a flat list of entries. Disabled rules and rules in disabled
+// ACLs never permit traffic, so they aren't included, and neither are references to resources which don't exist. The
+// rules of the policy are checked for rules shadowed by other rules, ingress rules permitting sensitive ports or all
+// traffic from any address, and references to resources which don't exist.
+func (t *NetworkTopology) EffectivePolicy(input *EffectivePolicyInput) (*EffectivePolicy, error) {
+	if input.Vnic == "" && input.IPAddress == "" {
+		return nil, errors.New("Either a vNIC or an IP address must be specified")
+	}
+	if input.IPAddress != "" && net.ParseIP(input.IPAddress) == nil {
+		return nil, fmt.Errorf("Invalid IP address %q", input.IPAddress)
+	}
+
+	policy := &EffectivePolicy{
+		Vnic:      input.Vnic,
+		IPAddress: input.IPAddress,
+	}
+	if iface := t.findInterface(func(iface *TopologyInterface) bool {
+		if input.Vnic != "" {
+			return iface.Vnic == input.Vnic
+		}
+		return iface.IPAddress == input.IPAddress
+	}); iface != nil {
+		policy.Vnic = iface.Vnic
+		policy.VnicSets = iface.VnicSets
+		if policy.IPAddress == "" {
+			policy.IPAddress = iface.IPAddress
+		}
+	} else if policy.Vnic != "" {
+		policy.VnicSets = t.vnicSets(policy.Vnic, nil)
+	}
+
+	findings := []PolicyFinding{}
+	rules := []string{}
+	for _, name := range sortedKeys(t.SecurityRules) {
+		rule := t.SecurityRules[name]
+		acl, ok := t.ACLs[rule.ACL]
+		if !rule.Enabled || !ok || !acl.Enabled {
+			continue
+		}
+		entries := t.policyEntries(rule)
+		if policy.Vnic != "" {
+			if !t.ruleAppliesToVnic(rule, policy) {
+				continue
+			}
+		} else {
+			entries = t.entriesWithPeer(entries, policy.IPAddress)
+			if len(entries) == 0 {
+				continue
+			}
+		}
+		findings = append(findings, t.unresolvedReferences(rule)...)
+		policy.Entries = append(policy.Entries, entries...)
+		if len(entries) > 0 {
+			rules = append(rules, name)
+		}
+	}
+
+	sensitivePorts := input.SensitivePorts
+	if len(sensitivePorts) == 0 {
+		sensitivePorts = DefaultSensitivePorts
+	}
+	byRule := map[string][]PolicyEntry{}
+	for _, entry := range policy.Entries {
+		byRule[entry.Rule] = append(byRule[entry.Rule], entry)
+	}
+	for _, name := range rules {
+		if finding := overlyPermissiveFinding(name, byRule[name], sensitivePorts); finding != nil {
+			findings = append(findings, *finding)
+		}
+		if finding := shadowedFinding(name, rules, byRule); finding != nil {
+			findings = append(findings, *finding)
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool { return findings[i].Rule < findings[j].Rule })
+	policy.Findings = findings
+
+	return policy, nil
+}
+
+// findInterface returns the first IP network interface of the instances in the topology which matches, or nil
+func (t *NetworkTopology) findInterface(matches func(iface *TopologyInterface) bool) *TopologyInterface {
+	for _, key := range sortedKeys(t.Instances) {
+		interfaces, err := t.InstanceInterfaces(key)
+		if err != nil {
+			continue
+		}
+		for i := range interfaces {
+			if matches(&interfaces[i]) {
+				return &interfaces[i]
+			}
+		}
+	}
+	return nil
+}
+
+// ruleAppliesToVnic returns whether the rule is in an ACL applied to one of the policy's vNIC sets, and its own
+// criteria, i.e. its destination for an ingress rule or its source for an egress rule, match the vNIC
+func (t *NetworkTopology) ruleAppliesToVnic(rule *SecurityRuleInfo, policy *EffectivePolicy) bool {
+	applied := false
+	for _, setName := range policy.VnicSets {
+		if set, ok := t.VirtualNICSets[setName]; ok && containsString(set.AppliedACLs, rule.ACL) {
+			applied = true
+		}
+	}
+	if !applied {
+		return false
+	}
+
+	ownVnicSet, ownPrefixSets := rule.SrcVnicSet, rule.SrcIPAddressPrefixSets
+	if strings.EqualFold(rule.FlowDirection, SecurityRuleIngress) {
+		ownVnicSet, ownPrefixSets = rule.DstVnicSet, rule.DstIPAddressPrefixSets
+	}
+	if ownVnicSet != "" && !containsString(policy.VnicSets, ownVnicSet) {
+		return false
+	}
+	return len(ownPrefixSets) == 0 || t.prefixSetsContain(ownPrefixSets, policy.IPAddress)
+}
+
+// entriesWithPeer returns the entries whose peer matches the IP address
+func (t *NetworkTopology) entriesWithPeer(entries []PolicyEntry, ipAddress string) []PolicyEntry {
+	ip := net.ParseIP(ipAddress)
+	var peerVnicSets []string
+	if iface := t.findInterface(func(iface *TopologyInterface) bool { return iface.IPAddress == ipAddress }); iface != nil {
+		peerVnicSets = iface.VnicSets
+	}
+
+	matching := []PolicyEntry{}
+	for _, entry := range entries {
+		if entry.PeerVnicSet != "" && !containsString(peerVnicSets, entry.PeerVnicSet) {
+			continue
+		}
+		if entry.PeerPrefix != "" {
+			_, prefix, err := net.ParseCIDR(entry.PeerPrefix)
+			if err != nil || !prefix.Contains(ip) {
+				continue
+			}
+		}
+		matching = append(matching, entry)
+	}
+	return matching
+}
+
+// policyEntries expands a security rule into an entry for each of its protocols, port ranges and peer prefixes.
+// A rule referencing only resources which don't exist for its protocols or peer prefixes has no entries.
+func (t *NetworkTopology) policyEntries(rule *SecurityRuleInfo) []PolicyEntry {
+	peerVnicSet, peerPrefixSets := rule.DstVnicSet, rule.DstIPAddressPrefixSets
+	ownVnicSet := rule.SrcVnicSet
+	if strings.EqualFold(rule.FlowDirection, SecurityRuleIngress) {
+		peerVnicSet, peerPrefixSets = rule.SrcVnicSet, rule.SrcIPAddressPrefixSets
+		ownVnicSet = rule.DstVnicSet
+	}
+
+	appliedTo := []string{}
+	if ownVnicSet != "" {
+		appliedTo = append(appliedTo, ownVnicSet)
+	} else {
+		for _, name := range sortedKeys(t.VirtualNICSets) {
+			if containsString(t.VirtualNICSets[name].AppliedACLs, rule.ACL) {
+				appliedTo = append(appliedTo, name)
+			}
+		}
+	}
+
+	peerPrefixes := []string{""}
+	if len(peerPrefixSets) > 0 {
+		peerPrefixes = []string{}
+		for _, name := range peerPrefixSets {
+			if set, ok := t.IPAddressPrefixSets[name]; ok {
+				peerPrefixes = append(peerPrefixes, set.IPAddressPrefixes...)
+			}
+		}
+	}
+
+	type protocolPorts struct{ protocol, ports string }
+	protocols := []protocolPorts{{anyPolicyValue, anyPolicyValue}}
+	if len(rule.SecProtocols) > 0 {
+		protocols = []protocolPorts{}
+		for _, name := range rule.SecProtocols {
+			p, ok := t.SecurityProtocols[name]
+			if !ok {
+				continue
+			}
+			protocol := p.IPProtocol
+			if protocol == "" {
+				protocol = anyPolicyValue
+			}
+			if len(p.DstPortSet) == 0 {
+				protocols = append(protocols, protocolPorts{protocol, anyPolicyValue})
+			}
+			for _, ports := range p.DstPortSet {
+				protocols = append(protocols, protocolPorts{protocol, ports})
+			}
+		}
+	}
+
+	entries := []PolicyEntry{}
+	for _, prefix := range peerPrefixes {
+		for _, p := range protocols {
+			entries = append(entries, PolicyEntry{
+				ACL:           rule.ACL,
+				Rule:          rule.Name,
+				FlowDirection: strings.ToLower(rule.FlowDirection),
+				AppliedTo:     appliedTo,
+				PeerVnicSet:   peerVnicSet,
+				PeerPrefix:    prefix,
+				Protocol:      p.protocol,
+				Ports:         p.ports,
+			})
+		}
+	}
+	return entries
+}
+
+// unresolvedReferences returns a finding for each security protocol, IP address prefix set and
+// vNIC set referenced by the rule which doesn't exist
+func (t *NetworkTopology) unresolvedReferences(rule *SecurityRuleInfo) []PolicyFinding {
+	missing := []string{}
+	for _, name := range rule.SecProtocols {
+		if _, ok := t.SecurityProtocols[name]; !ok {
+			missing = append(missing, "security protocol "+name)
+		}
+	}
+	for _, name := range append(append([]string{}, rule.SrcIPAddressPrefixSets...), rule.DstIPAddressPrefixSets...) {
+		if _, ok := t.IPAddressPrefixSets[name]; !ok {
+			missing = append(missing, "IP address prefix set "+name)
+		}
+	}
+	for _, name := range []string{rule.SrcVnicSet, rule.DstVnicSet} {
+		if _, ok := t.VirtualNICSets[name]; name != "" && !ok {
+			missing = append(missing, "vNIC set "+name)
+		}
+	}
+
+	findings := []PolicyFinding{}
+	for _, reference := range missing {
+		findings = append(findings, PolicyFinding{
+			Type:    PolicyUnresolvedReference,
+			Rule:    rule.Name,
+			Message: fmt.Sprintf("Security rule %s references %s, which doesn't exist", rule.Name, reference),
+		})
+	}
+	return findings
+}
+
+// overlyPermissiveFinding returns a finding if any of the rule's entries permit ingress from any address to
+// one of the sensitive ports, or of every protocol and port
+func overlyPermissiveFinding(rule string, entries []PolicyEntry, sensitivePorts []int) *PolicyFinding {
+	for _, entry := range entries {
+		if entry.FlowDirection != SecurityRuleIngress || entry.PeerVnicSet != "" || !isAnyPrefix(entry.PeerPrefix) {
+			continue
+		}
+		if entry.Protocol == anyPolicyValue && entry.Ports == anyPolicyValue {
+			return &PolicyFinding{
+				Type:    PolicyOverlyPermissiveRule,
+				Rule:    rule,
+				Message: fmt.Sprintf("Security rule %s permits all traffic from any address", rule),
+			}
+		}
+		// Only TCP and UDP have ports, other protocols are given the ports "all"
+		if entry.Protocol != string(TCP) && entry.Protocol != string(UDP) && entry.Protocol != anyPolicyValue {
+			continue
+		}
+		for _, port := range sensitivePorts {
+			if portRangeContains(entry.Ports, port) {
+				return &PolicyFinding{
+					Type:    PolicyOverlyPermissiveRule,
+					Rule:    rule,
+					Message: fmt.Sprintf("Security rule %s permits %s port %d from any address", rule, entry.Protocol, port),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// shadowedFinding returns a finding if every entry of the rule is covered by the entries of another rule.
+// Of two rules which cover each other, only the rule which sorts last is reported.
+func shadowedFinding(rule string, rules []string, byRule map[string][]PolicyEntry) *PolicyFinding {
+	for _, other := range rules {
+		if other == rule || !entriesCovered(byRule[rule], byRule[other]) {
+			continue
+		}
+		if other > rule && entriesCovered(byRule[other], byRule[rule]) {
+			continue
+		}
+		return &PolicyFinding{
+			Type:    PolicyShadowedRule,
+			Rule:    rule,
+			Message: fmt.Sprintf("Security rule %s is shadowed by %s, which permits all the same traffic", rule, other),
+		}
+	}
+	return nil
+}
+
+// entriesCovered returns whether every entry is covered by one of the covering entries
+func entriesCovered(entries, covering []PolicyEntry) bool {
+	for _, entry := range entries {
+		covered := false
+		for _, c := range covering {
+			if entryCovers(&c, &entry) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+// entryCovers returns whether everything permitted by entry b is permitted by entry a
+func entryCovers(a, b *PolicyEntry) bool {
+	if a.FlowDirection != b.FlowDirection {
+		return false
+	}
+	for _, set := range b.AppliedTo {
+		if !containsString(a.AppliedTo, set) {
+			return false
+		}
+	}
+	if a.PeerVnicSet != "" && a.PeerVnicSet != b.PeerVnicSet {
+		return false
+	}
+	if !prefixCovers(a.PeerPrefix, b.PeerPrefix) {
+		return false
+	}
+	if a.Protocol != anyPolicyValue && a.Protocol != b.Protocol {
+		return false
+	}
+	aLow, aHigh, aOK := parsePortRange(a.Ports)
+	bLow, bHigh, bOK := parsePortRange(b.Ports)
+	return aOK && bOK && aLow <= bLow && aHigh >= bHigh
+}
+
+// prefixCovers returns whether every address in prefix b is in prefix a, where "" is any address
+func prefixCovers(a, b string) bool {
+	if isAnyPrefix(a) {
+		return true
+	}
+	if isAnyPrefix(b) {
+		return false
+	}
+	_, networkA, errA := net.ParseCIDR(a)
+	_, networkB, errB := net.ParseCIDR(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	sizeA, _ := networkA.Mask.Size()
+	sizeB, _ := networkB.Mask.Size()
+	return sizeA <= sizeB && networkA.Contains(networkB.IP)
+}
+
+// isAnyPrefix returns whether the prefix matches any address, i.e. it's unset or 0.0.0.0/0
+func isAnyPrefix(prefix string) bool {
+	if prefix == "" {
+		return true
+	}
+	_, network, err := net.ParseCIDR(prefix)
+	if err != nil {
+		return false
+	}
+	size, _ := network.Mask.Size()
+	return size == 0
+}
+
+// portRangeContains returns whether the port range, as used by policy entries, contains the port
+func portRangeContains(ports string, port int) bool {
+	low, high, ok := parsePortRange(ports)
+	return ok && port >= low && port <= high
+}
+
+// parsePortRange parses a port number, a range such as 8000-8080, or `all`
+func parsePortRange(ports string) (int, int, bool) {
+	if ports == anyPolicyValue {
+		return 0, 65535, true
+	}
+	low, high := ports, ports
+	if i := strings.Index(ports, "-"); i >= 0 {
+		low, high = ports[:i], ports[i+1:]
+	}
+	from, err := strconv.Atoi(strings.TrimSpace(low))
+	if err != nil {
+		return 0, 0, false
+	}
+	to, err := strconv.Atoi(strings.TrimSpace(high))
+	if err != nil {
+		return 0, 0, false
+	}
+	return from, to, true
+}
@@ -0,0 +1,147 @@
+package compute
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func getSecurityPolicyTestTopology() *NetworkTopology {
+	topology := NewNetworkTopology()
+	topology.IPNetworks = map[string]*IPNetworkInfo{
+		"web": {Name: "web", IPAddressPrefix: "10.0.0.0/24"},
+	}
+	topology.Instances = map[string]*InstanceInfo{
+		"web1/1": {Name: "web1", ID: "1", Networking: map[string]NetworkingInfo{
+			"eth0": {IPNetwork: "web", IPAddress: "10.0.0.10", Vnic: "web1_eth0", VnicSets: []string{"web-servers"}},
+		}},
+	}
+	topology.VirtualNICSets = map[string]*VirtualNICSet{
+		"web-servers": {Name: "web-servers", AppliedACLs: []string{"web-acl", "old-acl"}},
+		"bastions":    {Name: "bastions"},
+	}
+	topology.ACLs = map[string]*ACLInfo{
+		"web-acl": {Name: "web-acl", Enabled: true},
+		"old-acl": {Name: "old-acl"},
+	}
+	topology.IPAddressPrefixSets = map[string]*IPAddressPrefixSetInfo{
+		"internet": {Name: "internet", IPAddressPrefixes: []string{"0.0.0.0/0"}},
+		"office":   {Name: "office", IPAddressPrefixes: []string{"192.0.2.0/24"}},
+	}
+	topology.SecurityProtocols = map[string]*SecurityProtocolInfo{
+		"web":   {Name: "web", IPProtocol: "tcp", DstPortSet: []string{"80", "443"}},
+		"https": {Name: "https", IPProtocol: "tcp", DstPortSet: []string{"443"}},
+		"ssh":   {Name: "ssh", IPProtocol: "tcp", DstPortSet: []string{"22"}},
+	}
+	topology.SecurityRules = map[string]*SecurityRuleInfo{
+		"web-from-internet": {Name: "web-from-internet", ACL: "web-acl", Enabled: true, FlowDirection: SecurityRuleIngress,
+			SecProtocols: []string{"web"}, SrcIPAddressPrefixSets: []string{"internet"}},
+		"https-from-office": {Name: "https-from-office", ACL: "web-acl", Enabled: true, FlowDirection: SecurityRuleIngress,
+			SecProtocols: []string{"https"}, SrcIPAddressPrefixSets: []string{"office"}},
+		"ssh-from-internet": {Name: "ssh-from-internet", ACL: "web-acl", Enabled: true, FlowDirection: SecurityRuleIngress,
+			SecProtocols: []string{"ssh"}},
+		"ssh-from-bastions": {Name: "ssh-from-bastions", ACL: "web-acl", Enabled: true, FlowDirection: SecurityRuleIngress,
+			SecProtocols: []string{"ssh", "mosh"}, SrcVnicSet: "bastions"},
+		"disabled": {Name: "disabled", ACL: "web-acl", FlowDirection: SecurityRuleIngress},
+		"old":      {Name: "old", ACL: "old-acl", Enabled: true, FlowDirection: SecurityRuleIngress},
+	}
+	return topology
+}
+
+func TestNetworkTopology_EffectivePolicy(t *testing.T) {
+	topology := getSecurityPolicyTestTopology()
+
+	policy, err := topology.EffectivePolicy(&EffectivePolicyInput{Vnic: "web1_eth0"})
+	if err != nil {
+		t.Fatalf("Error evaluating policy: %s", err)
+	}
+	if policy.IPAddress != "10.0.0.10" || !reflect.DeepEqual(policy.VnicSets, []string{"web-servers"}) {
+		t.Fatalf("Expected the policy of web1's interface, got %s in %v", policy.IPAddress, policy.VnicSets)
+	}
+
+	var table bytes.Buffer
+	if err := policy.WriteCSV(&table); err != nil {
+		t.Fatalf("Error writing policy: %s", err)
+	}
+	expected := `acl,rule,flow_direction,applied_to,peer_vnic_set,peer_prefix,protocol,ports
+web-acl,https-from-office,ingress,web-servers,,192.0.2.0/24,tcp,443
+web-acl,ssh-from-bastions,ingress,web-servers,bastions,,tcp,22
+web-acl,ssh-from-internet,ingress,web-servers,,,tcp,22
+web-acl,web-from-internet,ingress,web-servers,,0.0.0.0/0,tcp,80
+web-acl,web-from-internet,ingress,web-servers,,0.0.0.0/0,tcp,443
+`
+	if table.String() != expected {
+		t.Fatalf("Expected policy:\n%s\ngot:\n%s", expected, table.String())
+	}
+
+	findings := []string{}
+	for _, finding := range policy.Findings {
+		findings = append(findings, string(finding.Type)+" "+finding.Rule)
+	}
+	expectedFindings := []string{
+		"shadowed_rule https-from-office",
+		"unresolved_reference ssh-from-bastions",
+		"shadowed_rule ssh-from-bastions",
+		"overly_permissive_rule ssh-from-internet",
+	}
+	if !reflect.DeepEqual(findings, expectedFindings) {
+		t.Fatalf("Expected findings %v, got %v", expectedFindings, policy.Findings)
+	}
+}
+
+func TestNetworkTopology_EffectivePolicyICMP(t *testing.T) {
+	topology := getSecurityPolicyTestTopology()
+	topology.SecurityProtocols["icmp"] = &SecurityProtocolInfo{Name: "icmp", IPProtocol: "icmp"}
+	topology.SecurityProtocols["dns"] = &SecurityProtocolInfo{Name: "dns", IPProtocol: "udp"}
+	topology.SecurityRules["ping-from-internet"] = &SecurityRuleInfo{Name: "ping-from-internet", ACL: "web-acl", Enabled: true,
+		FlowDirection: SecurityRuleIngress, SecProtocols: []string{"icmp"}}
+	topology.SecurityRules["dns-from-internet"] = &SecurityRuleInfo{Name: "dns-from-internet", ACL: "web-acl", Enabled: true,
+		FlowDirection: SecurityRuleIngress, SecProtocols: []string{"dns"}}
+
+	policy, err := topology.EffectivePolicy(&EffectivePolicyInput{Vnic: "web1_eth0"})
+	if err != nil {
+		t.Fatalf("Error evaluating policy: %s", err)
+	}
+
+	// ICMP doesn't have ports, so doesn't open SSH, unlike a UDP protocol without any ports set
+	permissive := []string{}
+	for _, finding := range policy.Findings {
+		if finding.Type == PolicyOverlyPermissiveRule {
+			permissive = append(permissive, finding.Rule)
+		}
+	}
+	if !reflect.DeepEqual(permissive, []string{"dns-from-internet", "ssh-from-internet"}) {
+		t.Fatalf("Expected dns-from-internet and ssh-from-internet to be overly permissive, got %v", policy.Findings)
+	}
+}
+
+func TestNetworkTopology_EffectivePolicyForAddress(t *testing.T) {
+	topology := getSecurityPolicyTestTopology()
+
+	// The address of an instance's interface has the policy of its vNIC
+	policy, err := topology.EffectivePolicy(&EffectivePolicyInput{IPAddress: "10.0.0.10"})
+	if err != nil {
+		t.Fatalf("Error evaluating policy: %s", err)
+	}
+	if policy.Vnic != "web1_eth0" || len(policy.Entries) != 5 {
+		t.Fatalf("Expected the policy of web1_eth0, got %+v", policy)
+	}
+
+	// Other addresses have the rules permitting traffic with them
+	policy, err = topology.EffectivePolicy(&EffectivePolicyInput{IPAddress: "192.0.2.1"})
+	if err != nil {
+		t.Fatalf("Error evaluating policy: %s", err)
+	}
+	rules := []string{}
+	for _, entry := range policy.Entries {
+		rules = append(rules, entry.Rule+":"+entry.Ports)
+	}
+	if strings.Join(rules, " ") != "https-from-office:443 ssh-from-internet:22 web-from-internet:80 web-from-internet:443" {
+		t.Fatalf("Expected the rules permitting traffic from the office, got %v", rules)
+	}
+
+	if _, err := topology.EffectivePolicy(&EffectivePolicyInput{IPAddress: "not-an-address"}); err == nil {
+		t.Fatal("Expected an error evaluating the policy of an invalid address")
+	}
+}
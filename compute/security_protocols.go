@@ -122,6 +122,30 @@ func (c *SecurityProtocolsClient) GetSecurityProtocol(input *GetSecurityProtocol
 	return c.success(&ipInfo)
 }
 
+// ListSecurityProtocols retrieves the security protocols in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *SecurityProtocolsClient) ListSecurityProtocols(input *ListInput) ([]SecurityProtocolInfo, error) {
+	var list struct {
+		Result []SecurityProtocolInfo `json:"result"`
+	}
+	if err := c.listResource(input, &list); err != nil {
+		return nil, err
+	}
+
+	protocols := []SecurityProtocolInfo{}
+	for i := range list.Result {
+		info, err := c.success(&list.Result[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, info.Tags) {
+			protocols = append(protocols, *info)
+		}
+	}
+
+	return protocols, nil
+}
+
 // UpdateSecurityProtocolInput defines what to update in a security protocol
 type UpdateSecurityProtocolInput struct {
 	// The name of the Security Protocol to create. Object names can only contain alphanumeric,
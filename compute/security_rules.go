@@ -163,6 +163,30 @@ func (c *SecurityRuleClient) GetSecurityRule(input *GetSecurityRuleInput) (*Secu
 	return c.success(&securityRuleInfo)
 }
 
+// ListSecurityRules retrieves the security rules in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *SecurityRuleClient) ListSecurityRules(input *ListInput) ([]SecurityRuleInfo, error) {
+	var list struct {
+		Result []SecurityRuleInfo `json:"result"`
+	}
+	if err := c.listResource(input, &list); err != nil {
+		return nil, err
+	}
+
+	rules := []SecurityRuleInfo{}
+	for i := range list.Result {
+		info, err := c.success(&list.Result[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, info.Tags) {
+			rules = append(rules, *info)
+		}
+	}
+
+	return rules, nil
+}
+
 // UpdateSecurityRuleInput describes a secruity rule to update
 type UpdateSecurityRuleInput struct {
 	//Select the name of the access control list (ACL) that you want to add this
@@ -0,0 +1,375 @@
+package compute
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// oraclePublicContainer holds the security applications and security IP lists predefined by Oracle,
+// such as /oracle/public/ssh and /oracle/public/public-internet
+const oraclePublicContainer = "/oracle/public"
+
+// Prefixes of the lists referenced by sec rules
+const (
+	secRuleSecurityListPrefix   = "seclist:"
+	secRuleSecurityIPListPrefix = "seciplist:"
+)
+
+// SharedNetworkModel is an in-memory model of the security objects of the shared network: security lists,
+// sec rules, security applications, security IP lists and security associations, along with the instances
+// attached to the shared network. Each resource is keyed by its name, as returned by its client, except
+// instances, which are keyed by their name and ID, i.e. name/id.
+type SharedNetworkModel struct {
+	SecurityLists        map[string]*SecurityListInfo
+	SecRules             map[string]*SecRuleInfo
+	SecurityApplications map[string]*SecurityApplicationInfo
+	SecurityIPLists      map[string]*SecurityIPListInfo
+	SecurityAssociations map[string]*SecurityAssociationInfo
+	Instances            map[string]*InstanceInfo
+}
+
+// NewSharedNetworkModel returns an empty model, for resources to be added to
+func NewSharedNetworkModel() *SharedNetworkModel {
+	return &SharedNetworkModel{
+		SecurityLists:        map[string]*SecurityListInfo{},
+		SecRules:             map[string]*SecRuleInfo{},
+		SecurityApplications: map[string]*SecurityApplicationInfo{},
+		SecurityIPLists:      map[string]*SecurityIPListInfo{},
+		SecurityAssociations: map[string]*SecurityAssociationInfo{},
+		Instances:            map[string]*InstanceInfo{},
+	}
+}
+
+// LoadSharedNetworkModelInput specifies where to load a shared network model from
+type LoadSharedNetworkModelInput struct {
+	// The container to load resources from, see ListInput.
+	// Optional - Defaults to the authenticated user's container
+	Container string
+}
+
+// LoadSharedNetworkModel lists every shared network security object, and every instance, in the container into a
+// SharedNetworkModel. The security applications and security IP lists predefined in /oracle/public are also loaded,
+// as sec rules commonly reference them.
+func (c *Client) LoadSharedNetworkModel(input *LoadSharedNetworkModelInput) (*SharedNetworkModel, error) {
+	listInput := &ListInput{}
+	if input != nil {
+		listInput.Container = input.Container
+	}
+	publicInput := &ListInput{Container: oraclePublicContainer}
+	m := NewSharedNetworkModel()
+
+	securityLists, err := c.SecurityLists().ListSecurityLists(listInput)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing security lists: %w", err)
+	}
+	for i := range securityLists {
+		m.SecurityLists[securityLists[i].Name] = &securityLists[i]
+	}
+
+	secRules, err := c.SecRules().ListSecRules(listInput)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing sec rules: %w", err)
+	}
+	for i := range secRules {
+		m.SecRules[secRules[i].Name] = &secRules[i]
+	}
+
+	for _, containerInput := range []*ListInput{listInput, publicInput} {
+		applications, err := c.SecurityApplications().ListSecurityApplications(containerInput)
+		if err != nil {
+			return nil, fmt.Errorf("Error listing security applications: %w", err)
+		}
+		for i := range applications {
+			m.SecurityApplications[applications[i].Name] = &applications[i]
+		}
+
+		ipLists, err := c.SecurityIPLists().ListSecurityIPLists(containerInput)
+		if err != nil {
+			return nil, fmt.Errorf("Error listing security IP lists: %w", err)
+		}
+		for i := range ipLists {
+			m.SecurityIPLists[ipLists[i].Name] = &ipLists[i]
+		}
+	}
+
+	associations, err := c.SecurityAssociations().ListSecurityAssociations(listInput)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing security associations: %w", err)
+	}
+	for i := range associations {
+		m.SecurityAssociations[associations[i].Name] = &associations[i]
+	}
+
+	instances, err := c.Instances().ListInstances(listInput)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing instances: %w", err)
+	}
+	for i := range instances {
+		m.Instances[instances[i].Name+"/"+instances[i].ID] = &instances[i]
+	}
+
+	return m, nil
+}
+
+// SharedNetworkAccess describes traffic permitted to an instance on the shared network
+type SharedNetworkAccess struct {
+	// The name and ID of the instance, i.e. name/id
+	Instance string
+	// The instance's security list the traffic is permitted to
+	SecurityList string
+	// The list the traffic is permitted from, as referenced by the sec rule, i.e. seciplist:name or seclist:name.
+	// Empty if the security list's inbound policy permits traffic from anywhere.
+	Source string
+	// The security application of the permitted traffic, empty if the security list's inbound policy permits it
+	Application string
+	// The protocol of the security application, `all` if the security list's inbound policy permits the traffic
+	Protocol SecurityApplicationProtocol
+	// The destination ports of the security application, if any
+	Ports string
+	// The sec rule permitting the traffic, empty if the security list's inbound policy permits it
+	Rule string
+}
+
+// SharedNetworkFindingType specifies the kinds of finding reported by a shared network audit
+type SharedNetworkFindingType string
+
+const (
+	// SharedNetworkUnusedSecurityList - no instances are in the security list
+	SharedNetworkUnusedSecurityList SharedNetworkFindingType = "unused_security_list"
+	// SharedNetworkUnusedSecurityIPList - no sec rules reference the security IP list
+	SharedNetworkUnusedSecurityIPList SharedNetworkFindingType = "unused_security_ip_list"
+	// SharedNetworkUnusedSecRule - the sec rule doesn't permit traffic to or from any instance
+	SharedNetworkUnusedSecRule SharedNetworkFindingType = "unused_sec_rule"
+	// SharedNetworkMissingApplication - the sec rule references a security application which doesn't exist
+	SharedNetworkMissingApplication SharedNetworkFindingType = "missing_application"
+	// SharedNetworkMissingList - the sec rule references a security list or security IP list which doesn't exist
+	SharedNetworkMissingList SharedNetworkFindingType = "missing_list"
+)
+
+// SharedNetworkFinding describes a problem found auditing the shared network
+type SharedNetworkFinding struct {
+	// The kind of finding
+	Type SharedNetworkFindingType
+	// The name of the resource with the problem
+	Resource string
+	// Describes the finding
+	Message string
+}
+
+func (f SharedNetworkFinding) String() string {
+	return fmt.Sprintf("%s: %s", f.Type, f.Message)
+}
+
+// SharedNetworkAudit details the traffic permitted to instances on the shared network, and problems with its
+// security objects
+type SharedNetworkAudit struct {
+	// The security lists each instance is in, keyed by instance name and ID
+	InstanceSecurityLists map[string][]string
+	// The traffic permitted to each instance, ordered by instance, security list, source and application
+	Access []SharedNetworkAccess
+	// Problems with the security objects, ordered by type and resource name
+	Findings []SharedNetworkFinding
+}
+
+// AccessFrom returns the traffic permitted from the security IP list
+func (a *SharedNetworkAudit) AccessFrom(ipList string) []SharedNetworkAccess {
+	access := []SharedNetworkAccess{}
+	for _, entry := range a.Access {
+		if entry.Source == secRuleSecurityIPListPrefix+ipList {
+			access = append(access, entry)
+		}
+	}
+	return access
+}
+
+// Audit joins the sec rules of the shared network to the instances in their security lists, through the security
+// lists of the instances' shared network interfaces and security associations. It reports the traffic permitted to
+// each instance by enabled sec rules, or by the inbound policy of its security lists. Security lists without instances,
+// security IP lists without sec rules and sec rules which don't permit traffic to or from any instance are flagged,
+// along with sec rules referencing security applications or lists which don't exist. Sec rules to security IP lists
+// permit outbound traffic, so they're only flagged if their source has no instances.
+func (m *SharedNetworkModel) Audit() *SharedNetworkAudit {
+	audit := &SharedNetworkAudit{
+		InstanceSecurityLists: map[string][]string{},
+		Access:                []SharedNetworkAccess{},
+		Findings:              []SharedNetworkFinding{},
+	}
+
+	members := map[string][]string{}
+	for _, key := range sortedKeys(m.Instances) {
+		lists := m.instanceSecurityLists(m.Instances[key])
+		audit.InstanceSecurityLists[key] = lists
+		for _, list := range lists {
+			members[list] = append(members[list], key)
+		}
+	}
+
+	for _, name := range sortedKeys(m.SecurityLists) {
+		if len(members[name]) == 0 {
+			audit.Findings = append(audit.Findings, SharedNetworkFinding{
+				Type:     SharedNetworkUnusedSecurityList,
+				Resource: name,
+				Message:  fmt.Sprintf("Security list %s has no instances", name),
+			})
+			continue
+		}
+		if m.SecurityLists[name].Policy == SecurityListPolicyPermit {
+			for _, instance := range members[name] {
+				audit.Access = append(audit.Access, SharedNetworkAccess{
+					Instance:     instance,
+					SecurityList: name,
+					Protocol:     All,
+				})
+			}
+		}
+	}
+
+	referencedIPLists := map[string]bool{}
+	for _, name := range sortedKeys(m.SecRules) {
+		rule := m.SecRules[name]
+		for _, list := range []string{rule.SourceList, rule.DestinationList} {
+			if ipList := strings.TrimPrefix(list, secRuleSecurityIPListPrefix); ipList != list {
+				referencedIPLists[ipList] = true
+			}
+		}
+		// A rule referencing something which doesn't exist can't permit any traffic, so it's only flagged once
+		if findings := m.secRuleReferenceFindings(rule); len(findings) > 0 {
+			audit.Findings = append(audit.Findings, findings...)
+			continue
+		}
+		if reason := m.unusedSecRuleReason(rule, members); reason != "" {
+			audit.Findings = append(audit.Findings, SharedNetworkFinding{
+				Type:     SharedNetworkUnusedSecRule,
+				Resource: name,
+				Message:  fmt.Sprintf("Sec rule %s %s", name, reason),
+			})
+			continue
+		}
+		// Rules to security IP lists permit outbound traffic, rather than traffic to instances
+		destination := strings.TrimPrefix(rule.DestinationList, secRuleSecurityListPrefix)
+		if destination == rule.DestinationList {
+			continue
+		}
+		application := m.SecurityApplications[rule.Application]
+		for _, instance := range members[destination] {
+			audit.Access = append(audit.Access, SharedNetworkAccess{
+				Instance:     instance,
+				SecurityList: destination,
+				Source:       rule.SourceList,
+				Application:  rule.Application,
+				Protocol:     application.Protocol,
+				Ports:        application.DPort,
+				Rule:         name,
+			})
+		}
+	}
+
+	for _, name := range sortedKeys(m.SecurityIPLists) {
+		// The predefined lists are available to every user, so they're never unused
+		if !referencedIPLists[name] && !strings.HasPrefix(name, oraclePublicContainer+"/") {
+			audit.Findings = append(audit.Findings, SharedNetworkFinding{
+				Type:     SharedNetworkUnusedSecurityIPList,
+				Resource: name,
+				Message:  fmt.Sprintf("Security IP list %s isn't used by any sec rule", name),
+			})
+		}
+	}
+
+	sort.SliceStable(audit.Access, func(i, j int) bool {
+		a, b := audit.Access[i], audit.Access[j]
+		if a.Instance != b.Instance {
+			return a.Instance < b.Instance
+		}
+		if a.SecurityList != b.SecurityList {
+			return a.SecurityList < b.SecurityList
+		}
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		return a.Application < b.Application
+	})
+	sort.SliceStable(audit.Findings, func(i, j int) bool {
+		a, b := audit.Findings[i], audit.Findings[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Resource < b.Resource
+	})
+	return audit
+}
+
+// instanceSecurityLists returns the security lists of the instance's shared network interfaces, and those
+// associated with its vCable
+func (m *SharedNetworkModel) instanceSecurityLists(instance *InstanceInfo) []string {
+	lists := map[string]bool{}
+	for _, networking := range instance.Networking {
+		if networking.IPNetwork != "" {
+			continue
+		}
+		for _, list := range networking.SecLists {
+			lists[list] = true
+		}
+	}
+	for _, association := range m.SecurityAssociations {
+		if instance.VCableID != "" && association.VCable == instance.VCableID {
+			lists[association.SecList] = true
+		}
+	}
+	return sortedKeys(lists)
+}
+
+// secRuleReferenceFindings returns a finding for the security application and each list referenced by
+// the sec rule which doesn't exist
+func (m *SharedNetworkModel) secRuleReferenceFindings(rule *SecRuleInfo) []SharedNetworkFinding {
+	findings := []SharedNetworkFinding{}
+	if _, ok := m.SecurityApplications[rule.Application]; !ok {
+		findings = append(findings, SharedNetworkFinding{
+			Type:     SharedNetworkMissingApplication,
+			Resource: rule.Name,
+			Message:  fmt.Sprintf("Sec rule %s references security application %q, which doesn't exist", rule.Name, rule.Application),
+		})
+	}
+	for _, list := range []string{rule.SourceList, rule.DestinationList} {
+		if !m.listExists(list) {
+			findings = append(findings, SharedNetworkFinding{
+				Type:     SharedNetworkMissingList,
+				Resource: rule.Name,
+				Message:  fmt.Sprintf("Sec rule %s references %q, which doesn't exist", rule.Name, list),
+			})
+		}
+	}
+	return findings
+}
+
+// listExists returns whether the list referenced by a sec rule, i.e. seclist:name or seciplist:name, exists
+func (m *SharedNetworkModel) listExists(list string) bool {
+	if name := strings.TrimPrefix(list, secRuleSecurityListPrefix); name != list {
+		_, ok := m.SecurityLists[name]
+		return ok
+	}
+	if name := strings.TrimPrefix(list, secRuleSecurityIPListPrefix); name != list {
+		_, ok := m.SecurityIPLists[name]
+		return ok
+	}
+	return false
+}
+
+// unusedSecRuleReason returns why the sec rule doesn't permit traffic to or from any instance, or "" if it does.
+// The references of the rule must exist.
+func (m *SharedNetworkModel) unusedSecRuleReason(rule *SecRuleInfo, members map[string][]string) string {
+	if rule.Disabled {
+		return "is disabled"
+	}
+	if rule.Action != "" && !strings.EqualFold(rule.Action, "permit") {
+		return fmt.Sprintf("has action %s, rather than PERMIT", rule.Action)
+	}
+	destination := strings.TrimPrefix(rule.DestinationList, secRuleSecurityListPrefix)
+	if destination != rule.DestinationList && len(members[destination]) == 0 {
+		return fmt.Sprintf("has destination security list %s, which has no instances", destination)
+	}
+	if source := strings.TrimPrefix(rule.SourceList, secRuleSecurityListPrefix); source != rule.SourceList && len(members[source]) == 0 {
+		return fmt.Sprintf("has source security list %s, which has no instances", source)
+	}
+	return ""
+}
@@ -0,0 +1,146 @@
+package compute
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSharedNetworkModel_Audit(t *testing.T) {
+	model := NewSharedNetworkModel()
+	model.Instances = map[string]*InstanceInfo{
+		"web1/1": {Name: "web1", ID: "1", Networking: map[string]NetworkingInfo{
+			"eth0": {SecLists: []string{"web"}},
+		}},
+		"db1/2": {Name: "db1", ID: "2", VCableID: "db1-vcable", Networking: map[string]NetworkingInfo{
+			"eth0": {SecLists: []string{"default"}},
+			"eth1": {IPNetwork: "private", VnicSets: []string{"databases"}},
+		}},
+	}
+	model.SecurityAssociations = map[string]*SecurityAssociationInfo{
+		"db1-databases": {Name: "db1-databases", SecList: "databases", VCable: "db1-vcable"},
+	}
+	model.SecurityLists = map[string]*SecurityListInfo{
+		"web":       {Name: "web", Policy: SecurityListPolicyDeny},
+		"databases": {Name: "databases", Policy: SecurityListPolicyDeny},
+		"default":   {Name: "default", Policy: SecurityListPolicyPermit},
+		"retired":   {Name: "retired", Policy: SecurityListPolicyDeny},
+	}
+	model.SecurityIPLists = map[string]*SecurityIPListInfo{
+		"/oracle/public/public-internet": {Name: "/oracle/public/public-internet", SecIPEntries: []string{"0.0.0.0/0"}},
+		"/oracle/public/paas-infra":      {Name: "/oracle/public/paas-infra"},
+		"office":                         {Name: "office", SecIPEntries: []string{"192.0.2.0/24"}},
+		"old-office":                     {Name: "old-office", SecIPEntries: []string{"198.51.100.0/24"}},
+	}
+	model.SecurityApplications = map[string]*SecurityApplicationInfo{
+		"/oracle/public/https": {Name: "/oracle/public/https", Protocol: TCP, DPort: "443"},
+		"/oracle/public/ssh":   {Name: "/oracle/public/ssh", Protocol: TCP, DPort: "22"},
+		"postgres":             {Name: "postgres", Protocol: TCP, DPort: "5432"},
+	}
+	model.SecRules = map[string]*SecRuleInfo{
+		"https-from-internet": {Name: "https-from-internet", Action: "PERMIT", Application: "/oracle/public/https",
+			SourceList: "seciplist:/oracle/public/public-internet", DestinationList: "seclist:web"},
+		"ssh-from-office": {Name: "ssh-from-office", Action: "PERMIT", Application: "/oracle/public/ssh",
+			SourceList: "seciplist:office", DestinationList: "seclist:web"},
+		"web-to-db": {Name: "web-to-db", Action: "PERMIT", Application: "postgres",
+			SourceList: "seclist:web", DestinationList: "seclist:databases"},
+		"ssh-to-retired": {Name: "ssh-to-retired", Action: "PERMIT", Application: "/oracle/public/ssh",
+			SourceList: "seciplist:office", DestinationList: "seclist:retired"},
+		"disabled": {Name: "disabled", Action: "PERMIT", Application: "/oracle/public/ssh", Disabled: true,
+			SourceList: "seciplist:office", DestinationList: "seclist:web"},
+		"mysql-from-office": {Name: "mysql-from-office", Action: "PERMIT", Application: "mysql",
+			SourceList: "seciplist:office", DestinationList: "seclist:databases"},
+	}
+
+	audit := model.Audit()
+
+	expectedLists := map[string][]string{
+		"web1/1": {"web"},
+		"db1/2":  {"databases", "default"},
+	}
+	if !reflect.DeepEqual(audit.InstanceSecurityLists, expectedLists) {
+		t.Fatalf("Expected instance security lists %v, got %v", expectedLists, audit.InstanceSecurityLists)
+	}
+
+	access := []string{}
+	for _, entry := range audit.Access {
+		access = append(access, entry.Instance+" "+entry.Source+" "+entry.Application+" "+entry.Ports)
+	}
+	expectedAccess := []string{
+		"db1/2 seclist:web postgres 5432",
+		"db1/2   ",
+		"web1/1 seciplist:/oracle/public/public-internet /oracle/public/https 443",
+		"web1/1 seciplist:office /oracle/public/ssh 22",
+	}
+	if !reflect.DeepEqual(access, expectedAccess) {
+		t.Fatalf("Expected access %q, got %q", expectedAccess, access)
+	}
+	if office := audit.AccessFrom("office"); len(office) != 1 || office[0].Rule != "ssh-from-office" {
+		t.Fatalf("Expected the office to have SSH access to web1, got %+v", office)
+	}
+
+	findings := []string{}
+	for _, finding := range audit.Findings {
+		findings = append(findings, string(finding.Type)+" "+finding.Resource)
+	}
+	expectedFindings := []string{
+		"missing_application mysql-from-office",
+		"unused_sec_rule disabled",
+		"unused_sec_rule ssh-to-retired",
+		"unused_security_ip_list old-office",
+		"unused_security_list retired",
+	}
+	if !reflect.DeepEqual(findings, expectedFindings) {
+		t.Fatalf("Expected findings %v, got %v", expectedFindings, audit.Findings)
+	}
+}
+
+func TestClient_LoadSharedNetworkModel(t *testing.T) {
+	client, _ := newFakeComputeClient(t)
+	if _, err := client.SecurityLists().CreateSecurityList(&CreateSecurityListInput{Name: "web", Policy: SecurityListPolicyDeny}); err != nil {
+		t.Fatalf("Error creating security list: %s", err)
+	}
+	if _, err := client.SecurityIPLists().CreateSecurityIPList(&CreateSecurityIPListInput{Name: "office", SecIPEntries: []string{"192.0.2.0/24"}}); err != nil {
+		t.Fatalf("Error creating security IP list: %s", err)
+	}
+	_, err := client.SecurityApplications().CreateSecurityApplication(&CreateSecurityApplicationInput{Name: "ssh", Protocol: TCP, DPort: "22"})
+	if err != nil {
+		t.Fatalf("Error creating security application: %s", err)
+	}
+	for name, application := range map[string]string{"ssh-from-office": "ssh", "rdp-from-office": "rdp"} {
+		_, err := client.SecRules().CreateSecRule(&CreateSecRuleInput{
+			Name:            name,
+			Action:          "PERMIT",
+			Application:     application,
+			SourceList:      "seciplist:office",
+			DestinationList: "seclist:web",
+		})
+		if err != nil {
+			t.Fatalf("Error creating sec rule: %s", err)
+		}
+	}
+	_, err = client.Instances().CreateInstance(&CreateInstanceInput{
+		Name:         "web1",
+		Label:        "web1",
+		Shape:        "oc3",
+		Networking:   map[string]NetworkingInfo{"eth0": {Model: NICDefaultModel, SecLists: []string{"web"}}},
+		PollInterval: 10 * time.Millisecond,
+		Timeout:      5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("Error creating instance: %s", err)
+	}
+
+	model, err := client.LoadSharedNetworkModel(nil)
+	if err != nil {
+		t.Fatalf("Error loading shared network model: %s", err)
+	}
+	audit := model.Audit()
+	office := audit.AccessFrom("office")
+	if len(office) != 1 || office[0].Application != "ssh" || office[0].SecurityList != "web" || office[0].Protocol != TCP {
+		t.Fatalf("Expected the office to have SSH access to web1, got %+v", audit.Access)
+	}
+	if len(audit.Findings) != 1 || audit.Findings[0].Type != SharedNetworkMissingApplication || audit.Findings[0].Resource != "rdp-from-office" {
+		t.Fatalf("Expected rdp-from-office to reference a missing application, got %v", audit.Findings)
+	}
+}
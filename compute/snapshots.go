@@ -149,6 +149,30 @@ func (c *SnapshotsClient) GetSnapshot(getInput *GetSnapshotInput) (*Snapshot, er
 	return c.success(&snapshotInfo)
 }
 
+// ListSnapshots retrieves the snapshots in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *SnapshotsClient) ListSnapshots(input *ListInput) ([]Snapshot, error) {
+	var list struct {
+		Result []Snapshot `json:"result"`
+	}
+	if err := c.listResource(input, &list); err != nil {
+		return nil, err
+	}
+
+	snapshots := []Snapshot{}
+	for i := range list.Result {
+		info, err := c.success(&list.Result[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, nil) {
+			snapshots = append(snapshots, *info)
+		}
+	}
+
+	return snapshots, nil
+}
+
 // DeleteSnapshotInput describes the snapshot to delete
 type DeleteSnapshotInput struct {
 	// The name of the Snapshot
@@ -85,6 +85,30 @@ func (c *SSHKeysClient) GetSSHKey(getInput *GetSSHKeyInput) (*SSHKey, error) {
 	return c.success(&keyInfo)
 }
 
+// ListSSHKeys retrieves the SSH keys in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *SSHKeysClient) ListSSHKeys(input *ListInput) ([]SSHKey, error) {
+	var list struct {
+		Result []SSHKey `json:"result"`
+	}
+	if err := c.listResource(input, &list); err != nil {
+		return nil, err
+	}
+
+	keys := []SSHKey{}
+	for i := range list.Result {
+		info, err := c.success(&list.Result[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, nil) {
+			keys = append(keys, *info)
+		}
+	}
+
+	return keys, nil
+}
+
 // UpdateSSHKeyInput defines an SSH key to be updated
 type UpdateSSHKeyInput struct {
 	// The three-part name of the object (/Compute-identity_domain/user/object).
@@ -160,6 +160,30 @@ func (c *StorageAttachmentsClient) GetStorageAttachment(input *GetStorageAttachm
 	return c.success(attachmentInfo)
 }
 
+// ListStorageAttachments retrieves the storage attachments in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *StorageAttachmentsClient) ListStorageAttachments(input *ListInput) ([]StorageAttachmentInfo, error) {
+	var list struct {
+		Result []StorageAttachmentInfo `json:"result"`
+	}
+	if err := c.listResource(input, &list); err != nil {
+		return nil, err
+	}
+
+	attachments := []StorageAttachmentInfo{}
+	for i := range list.Result {
+		info, err := c.success(&list.Result[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, nil) {
+			attachments = append(attachments, *info)
+		}
+	}
+
+	return attachments, nil
+}
+
 // waitForStorageAttachmentToFullyAttach waits for the storage attachment with the given name to be fully attached, or times out.
 func (c *StorageAttachmentsClient) waitForStorageAttachmentToFullyAttach(name string, pollInterval, timeout time.Duration) (*StorageAttachmentInfo, error) {
 	var waitResult *StorageAttachmentInfo
@@ -172,6 +172,30 @@ func (c *StorageVolumeSnapshotClient) GetStorageVolumeSnapshot(input *GetStorage
 	return c.success(&storageSnapshot)
 }
 
+// ListStorageVolumeSnapshots retrieves the storage volume snapshots in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *StorageVolumeSnapshotClient) ListStorageVolumeSnapshots(input *ListInput) ([]StorageVolumeSnapshotInfo, error) {
+	var list struct {
+		Result []StorageVolumeSnapshotInfo `json:"result"`
+	}
+	if err := c.listResource(input, &list); err != nil {
+		return nil, err
+	}
+
+	snapshots := []StorageVolumeSnapshotInfo{}
+	for i := range list.Result {
+		info, err := c.success(&list.Result[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, info.Tags) {
+			snapshots = append(snapshots, *info)
+		}
+	}
+
+	return snapshots, nil
+}
+
 // DeleteStorageVolumeSnapshotInput represents the body of an API request to delete a storage volume snapshot
 type DeleteStorageVolumeSnapshotInput struct {
 	// Name of the snapshot to delete
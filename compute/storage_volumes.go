@@ -267,6 +267,30 @@ func (c *StorageVolumeClient) GetStorageVolume(input *GetStorageVolumeInput) (*S
 	return c.success(&storageVolume)
 }
 
+// ListStorageVolumes retrieves the storage volumes in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *StorageVolumeClient) ListStorageVolumes(input *ListInput) ([]StorageVolumeInfo, error) {
+	var list struct {
+		Result []StorageVolumeInfo `json:"result"`
+	}
+	if err := c.listResource(input, &list); err != nil {
+		return nil, err
+	}
+
+	volumes := []StorageVolumeInfo{}
+	for i := range list.Result {
+		info, err := c.success(&list.Result[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, info.Tags) {
+			volumes = append(volumes, *info)
+		}
+	}
+
+	return volumes, nil
+}
+
 // UpdateStorageVolumeInput represents the body of an API request to update a Storage Volume.
 type UpdateStorageVolumeInput struct {
 	// The description of the storage volume.
@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hashicorp/go-oracle-terraform/compute/computetest"
 	"github.com/hashicorp/go-oracle-terraform/opc"
 )
 
@@ -96,6 +97,18 @@ func getBlankTestClient() (*Client, *httptest.Server, error) {
 	return client, server, nil
 }
 
+// Returns a client for an in-memory fake of the Compute API, which is closed when the test completes
+// nolint: deadcode
+func newFakeComputeClient(t *testing.T) (*Client, *computetest.Server) {
+	server := computetest.NewServer()
+	t.Cleanup(server.Close)
+	client, err := NewComputeClient(server.Config())
+	if err != nil {
+		t.Fatalf("error creating client: %s", err)
+	}
+	return client, server
+}
+
 // Returns a stub client with default values, and a custom API Endpoint
 // nolint: deadcode
 func getStubClient(endpoint *url.URL) (*Client, error) {
@@ -52,6 +52,30 @@ func (c *VirtNICsClient) GetVirtualNIC(input *GetVirtualNICInput) (*VirtualNIC,
 	return c.success(&virtNIC)
 }
 
+// ListVirtualNICs retrieves the virtual NICs in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *VirtNICsClient) ListVirtualNICs(input *ListInput) ([]VirtualNIC, error) {
+	var list struct {
+		Result []VirtualNIC `json:"result"`
+	}
+	if err := c.listResource(input, &list); err != nil {
+		return nil, err
+	}
+
+	nics := []VirtualNIC{}
+	for i := range list.Result {
+		info, err := c.success(&list.Result[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, info.Tags) {
+			nics = append(nics, *info)
+		}
+	}
+
+	return nics, nil
+}
+
 func (c *VirtNICsClient) success(info *VirtualNIC) (*VirtualNIC, error) {
 	info.Name = c.getUnqualifiedName(info.FQDN)
 	return info, nil
@@ -91,6 +91,30 @@ func (c *VirtNICSetsClient) GetVirtualNICSet(input *GetVirtualNICSetInput) (*Vir
 	return c.success(&virtNicSet)
 }
 
+// ListVirtualNICSets retrieves the virtual NIC sets in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *VirtNICSetsClient) ListVirtualNICSets(input *ListInput) ([]VirtualNICSet, error) {
+	var list struct {
+		Result []VirtualNICSet `json:"result"`
+	}
+	if err := c.listResource(input, &list); err != nil {
+		return nil, err
+	}
+
+	sets := []VirtualNICSet{}
+	for i := range list.Result {
+		info, err := c.success(&list.Result[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, info.Tags) {
+			sets = append(sets, *info)
+		}
+	}
+
+	return sets, nil
+}
+
 // UpdateVirtualNICSetInput specifies the information that will be updated in the virtual nic set
 type UpdateVirtualNICSetInput struct {
 	// List of ACLs applied to the VNICs in the set.
@@ -256,6 +256,30 @@ func (c *VPNEndpointV2sClient) GetVPNEndpointV2(input *GetVPNEndpointV2Input) (*
 	return c.success(&ipInfo)
 }
 
+// ListVPNEndpointV2s retrieves the VPN endpoints in the container specified by the input,
+// optionally filtered by name prefix and tags.
+func (c *VPNEndpointV2sClient) ListVPNEndpointV2s(input *ListInput) ([]VPNEndpointV2Info, error) {
+	var list struct {
+		Result []VPNEndpointV2Info `json:"result"`
+	}
+	if err := c.listResource(input, &list); err != nil {
+		return nil, err
+	}
+
+	endpoints := []VPNEndpointV2Info{}
+	for i := range list.Result {
+		info, err := c.success(&list.Result[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.listMatches(input, info.Name, nil) {
+			endpoints = append(endpoints, *info)
+		}
+	}
+
+	return endpoints, nil
+}
+
 // UpdateVPNEndpointV2Input defines what to update in a VPN Endpoint V2
 // Only PSK and ReachableRoutes are updatable
 type UpdateVPNEndpointV2Input struct {
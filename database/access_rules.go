@@ -142,9 +142,7 @@ type CreateAccessRuleInput struct {
 // Thus, the Create method will return the resulting object from an internal GET call
 // during the WaitForReady timeout.
 func (c *UtilityClient) CreateAccessRule(input *CreateAccessRuleInput) (*AccessRuleInfo, error) {
-	if input.ServiceInstanceID != "" {
-		c.ServiceInstanceID = input.ServiceInstanceID
-	}
+	c = c.forServiceInstance(input.ServiceInstanceID)
 
 	var accessRule AccessRuleInfo
 	if err := c.createResource(input, &accessRule); err != nil {
@@ -192,9 +190,7 @@ type GetAccessRuleInput struct {
 // on how many access rules the customer has. However, since there's no direct GET API endpoint
 // for a single Access Rule, it's not able to be optimized yet.
 func (c *UtilityClient) GetAccessRule(input *GetAccessRuleInput) (*AccessRuleInfo, error) {
-	if input.ServiceInstanceID != "" {
-		c.ServiceInstanceID = input.ServiceInstanceID
-	}
+	c = c.forServiceInstance(input.ServiceInstanceID)
 
 	var accessRules AccessRules
 	if err := c.getResource("", &accessRules); err != nil {
@@ -239,9 +235,7 @@ type UpdateAccessRuleInput struct {
 // and any errors encountered
 func (c *UtilityClient) UpdateAccessRule(input *UpdateAccessRuleInput,
 ) (*AccessRuleInfo, error) {
-	if input.ServiceInstanceID != "" {
-		c.ServiceInstanceID = input.ServiceInstanceID
-	}
+	c = c.forServiceInstance(input.ServiceInstanceID)
 
 	// Since this is strictly an Update call, set the Operation constant
 	input.Operation = AccessRuleUpdate
@@ -281,9 +275,7 @@ type DeleteAccessRuleInput struct {
 
 // DeleteAccessRule - Deletes an AccessRule with the provided input struct. Returns any errors that occurred.
 func (c *UtilityClient) DeleteAccessRule(input *DeleteAccessRuleInput) error {
-	if input.ServiceInstanceID != "" {
-		c.ServiceInstanceID = input.ServiceInstanceID
-	}
+	c = c.forServiceInstance(input.ServiceInstanceID)
 
 	// Since this is strictly an Update call, set the Operation constant
 	input.Operation = AccessRuleDelete
@@ -394,9 +386,7 @@ type GetDefaultAccessRuleInput struct {
 
 // GetDefaultAccessRules retrieves all the default access rules pertaining to Database Service Instance
 func (c *UtilityClient) GetDefaultAccessRules(input *GetDefaultAccessRuleInput) (*DefaultAccessRuleInfo, error) {
-	if input.ServiceInstanceID != "" {
-		c.ServiceInstanceID = input.ServiceInstanceID
-	}
+	c = c.forServiceInstance(input.ServiceInstanceID)
 	defaultAccessRules := &DefaultAccessRuleInfo{}
 	// Obtain all the access rules since it isn't possible to get a specific one from the api
 	var accessRules AccessRules
@@ -449,9 +439,7 @@ func setRuleBools(key string, rule *AccessRuleInfo, defaultAccessRules *DefaultA
 
 // UpdateDefaultAccessRules Updates all the specified/relevant default access rules for a database service instance
 func (c *UtilityClient) UpdateDefaultAccessRules(input *DefaultAccessRuleInfo) (*DefaultAccessRuleInfo, error) {
-	if input.ServiceInstanceID != "" {
-		c.ServiceInstanceID = input.ServiceInstanceID
-	}
+	c = c.forServiceInstance(input.ServiceInstanceID)
 	var accessRules AccessRules
 	if err := c.getResource("", &accessRules); err != nil {
 		return nil, err
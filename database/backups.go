@@ -0,0 +1,202 @@
+// Manages the Backups of a DBaaS Service Instance, and the Recovery of its
+// database from them. Starting a backup and recovering the database are both
+// asynchronous, so return the details of the job which can be tracked through
+// the JobClient.
+
+package database
+
+import (
+	"fmt"
+	"time"
+)
+
+// API URI Paths for Container and Root objects
+const (
+	DBBackupContainerPath          = "/paas/api/v1.1/instancemgmt/%s/services/dbaas/instances/%s/backups"
+	DBRecoveryContainerPath        = "/paas/api/v1.1/instancemgmt/%s/services/dbaas/instances/%s/backups/recovery"
+	DBRecoveryHistoryContainerPath = "/paas/api/v1.1/instancemgmt/%s/services/dbaas/instances/%s/backups/recovery/history"
+)
+
+// recoveryTimestampFormat is the format of the point in time to recover a database to
+const recoveryTimestampFormat = "02-Jan-2006 15:04:05"
+
+// Backups returns a UtilityClient for managing Backups and Recovery for a DBaaS Service Instance
+func (c *Client) Backups() *UtilityClient {
+	return &UtilityClient{
+		UtilityResourceClient: UtilityResourceClient{
+			Client:        c,
+			ContainerPath: DBBackupContainerPath,
+		},
+	}
+}
+
+// BackupInfo holds the details of a single backup of the database
+type BackupInfo struct {
+	// The date and time the backup completed.
+	BackupCompleteDate string `json:"backupCompleteDate"`
+	// The date and time the backup started.
+	BackupStartDate string `json:"backupStartDate"`
+	// The ID of the database that was backed up.
+	DatabaseID string `json:"database_id"`
+	// The tag of the backup, used to recover the database to it.
+	DBTag string `json:"dbTag"`
+	// The name of the backup displayed in the console.
+	DisplayName string `json:"displayName"`
+	// Whether the backup is a long term backup, which is kept until deleted.
+	KeepForever bool `json:"keepForever"`
+	// The status of the backup, e.g. COMPLETED, IN_PROGRESS or FAILED.
+	Status string `json:"status"`
+	// The type of the backup, e.g. FULL or INCREMENTAL.
+	Type string `json:"type"`
+}
+
+// BackupsInfo holds the backups of a service instance
+type BackupsInfo struct {
+	Backups []BackupInfo `json:"backupList"`
+}
+
+// ListBackupsInput defines the service instance to list the backups of
+type ListBackupsInput struct {
+	// Name of the DBaaS service instance.
+	// Required
+	ServiceInstanceID string `json:"-"`
+}
+
+// ListBackups lists the backups of a service instance
+func (c *UtilityClient) ListBackups(input *ListBackupsInput) (*BackupsInfo, error) {
+	c = c.forServiceInstance(input.ServiceInstanceID)
+
+	var backups BackupsInfo
+	if err := c.getResource("", &backups); err != nil {
+		return nil, err
+	}
+	return &backups, nil
+}
+
+// CreateBackupInput defines the on-demand backup to start for a service instance
+type CreateBackupInput struct {
+	// Name of the DBaaS service instance.
+	// Required
+	ServiceInstanceID string `json:"-"`
+	// Keep the backup until it's deleted, rather than for the retention period of the service instance.
+	// Optional
+	KeepForever bool `json:"keepForever,omitempty"`
+}
+
+// CreateBackup starts an on-demand backup of the service instance, returning the backup job. The
+// service instance must have been created with a backup destination other than NONE.
+func (c *UtilityClient) CreateBackup(input *CreateBackupInput) (*JobResponse, error) {
+	c = c.forServiceInstance(input.ServiceInstanceID)
+
+	serviceInstance, err := c.Client.ServiceInstanceClient().GetServiceInstance(&GetServiceInstanceInput{
+		Name: c.ServiceInstanceID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error getting Service Instance %q: %+v", c.ServiceInstanceID, err)
+	}
+	if ServiceInstanceBackupDestination(serviceInstance.BackupDestination) == ServiceInstanceBackupDestinationNone {
+		return nil, fmt.Errorf("Service Instance %q has no backup destination configured", c.ServiceInstanceID)
+	}
+
+	return c.submitJob(c.getContainerPath(c.ContainerPath), input)
+}
+
+// RecoverDatabaseInput defines the backup or point in time to recover the database of a service
+// instance to. Exactly one of Latest, Tag, SCN and Timestamp must be set.
+type RecoverDatabaseInput struct {
+	// Name of the DBaaS service instance.
+	// Required
+	ServiceInstanceID string
+	// Recover the database to the most recent point in time possible.
+	// Optional
+	Latest bool
+	// Recover the database to the backup with the tag, see BackupInfo.DBTag.
+	// Optional
+	Tag string
+	// Recover the database to the system change number (SCN).
+	// Optional
+	SCN string
+	// Recover the database to the point in time, in the time zone of the service instance.
+	// Optional
+	Timestamp time.Time
+}
+
+// recoverDatabaseRequest is the request body to recover the database of a service instance
+type recoverDatabaseRequest struct {
+	Latest    bool   `json:"latest,omitempty"`
+	Tag       string `json:"tag,omitempty"`
+	SCN       string `json:"scn,omitempty"`
+	Timestamp string `json:"timestamp,omitempty"`
+}
+
+// RecoverDatabase restores and recovers the database of the service instance to a backup or
+// point in time, returning the recovery job
+func (c *UtilityClient) RecoverDatabase(input *RecoverDatabaseInput) (*JobResponse, error) {
+	c = c.forServiceInstance(input.ServiceInstanceID)
+
+	request := recoverDatabaseRequest{
+		Latest: input.Latest,
+		Tag:    input.Tag,
+		SCN:    input.SCN,
+	}
+	if !input.Timestamp.IsZero() {
+		request.Timestamp = input.Timestamp.Format(recoveryTimestampFormat)
+	}
+
+	targets := 0
+	for _, set := range []bool{request.Latest, request.Tag != "", request.SCN != "", request.Timestamp != ""} {
+		if set {
+			targets++
+		}
+	}
+	if targets != 1 {
+		return nil, fmt.Errorf("Exactly one of Latest, Tag, SCN and Timestamp must be set to recover Service Instance %q", c.ServiceInstanceID)
+	}
+
+	return c.submitJob(c.getContainerPath(DBRecoveryContainerPath), request)
+}
+
+// RecoveryInfo holds the details of a single recovery of the database
+type RecoveryInfo struct {
+	// Whether the database was recovered to the most recent point in time possible.
+	Latest bool `json:"latest"`
+	// The date and time the recovery completed.
+	RecoveryCompleteDate string `json:"recoveryCompleteDate"`
+	// The date and time the recovery started.
+	RecoveryStartDate string `json:"recoveryStartDate"`
+	// The system change number the database was recovered to.
+	SCN string `json:"scn"`
+	// The status of the recovery, e.g. COMPLETED, IN_PROGRESS or FAILED.
+	Status string `json:"status"`
+	// The tag of the backup the database was recovered to.
+	Tag string `json:"tag"`
+	// The point in time the database was recovered to.
+	Timestamp string `json:"timestamp"`
+}
+
+// RecoveriesInfo holds the recovery history of a service instance
+type RecoveriesInfo struct {
+	Recoveries []RecoveryInfo `json:"recoveryList"`
+}
+
+// ListRecoveriesInput defines the service instance to list the recovery history of
+type ListRecoveriesInput struct {
+	// Name of the DBaaS service instance.
+	// Required
+	ServiceInstanceID string `json:"-"`
+}
+
+// ListRecoveries lists the recovery history of a service instance
+func (c *UtilityClient) ListRecoveries(input *ListRecoveriesInput) (*RecoveriesInfo, error) {
+	c = c.forServiceInstance(input.ServiceInstanceID)
+
+	var recoveries RecoveriesInfo
+	resp, err := c.executeRequest("GET", c.getContainerPath(DBRecoveryHistoryContainerPath), nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.unmarshalResponseBody(resp, &recoveries); err != nil {
+		return nil, err
+	}
+	return &recoveries, nil
+}
@@ -0,0 +1,97 @@
+package database
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newBackupTestClient returns a client for a server with a single service instance using the backup
+// destination, recording the body of each POST request by path
+func newBackupTestClient(t *testing.T, backupDestination ServiceInstanceBackupDestination) (*Client, map[string]string) {
+	const instancePath = "/paas/api/v1.1/instancemgmt/test-domain/services/dbaas/instances/test-db"
+	posted := map[string]string{}
+	client := getStubTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/paas/service/dbcs/api/v1.1/instances/test-domain/test-db":
+			json.NewEncoder(w).Encode(map[string]string{
+				"service_name":       "test-db",
+				"backup_destination": string(backupDestination),
+			})
+		case r.Method == "GET" && r.URL.Path == instancePath+"/backups":
+			w.Write([]byte(`{"backupList": [{"dbTag": "TAG20180101T000000", "status": "COMPLETED", "keepForever": false}]}`))
+		case r.Method == "POST" && strings.HasPrefix(r.URL.Path, instancePath+"/backups"):
+			body, _ := ioutil.ReadAll(r.Body)
+			posted[strings.TrimPrefix(r.URL.Path, instancePath)] = string(body)
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"details": {"message": "Submitted job", "jobId": "1234"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	return client, posted
+}
+
+func TestBackups_CreateAndList(t *testing.T) {
+	client, posted := newBackupTestClient(t, ServiceInstanceBackupDestinationBoth)
+
+	job, err := client.Backups().CreateBackup(&CreateBackupInput{ServiceInstanceID: "test-db", KeepForever: true})
+	if err != nil {
+		t.Fatalf("Error creating backup: %s", err)
+	}
+	if job.Details.JobID != "1234" {
+		t.Fatalf("Expected job 1234, got %q", job.Details.JobID)
+	}
+	if posted["/backups"] != `{"keepForever":true}` {
+		t.Fatalf("Unexpected backup request: %s", posted["/backups"])
+	}
+
+	backups, err := client.Backups().ListBackups(&ListBackupsInput{ServiceInstanceID: "test-db"})
+	if err != nil {
+		t.Fatalf("Error listing backups: %s", err)
+	}
+	if len(backups.Backups) != 1 || backups.Backups[0].DBTag != "TAG20180101T000000" {
+		t.Fatalf("Unexpected backups: %+v", backups)
+	}
+}
+
+func TestBackups_CreateWithoutBackupDestination(t *testing.T) {
+	client, posted := newBackupTestClient(t, ServiceInstanceBackupDestinationNone)
+
+	if _, err := client.Backups().CreateBackup(&CreateBackupInput{ServiceInstanceID: "test-db"}); err == nil {
+		t.Fatalf("Expected an error backing up a service instance without a backup destination")
+	}
+	if len(posted) != 0 {
+		t.Fatalf("Expected no backup to be started, got %v", posted)
+	}
+}
+
+func TestBackups_RecoverDatabase(t *testing.T) {
+	client, posted := newBackupTestClient(t, ServiceInstanceBackupDestinationBoth)
+
+	job, err := client.Backups().RecoverDatabase(&RecoverDatabaseInput{
+		ServiceInstanceID: "test-db",
+		Timestamp:         time.Date(2018, 3, 4, 13, 30, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Error recovering database: %s", err)
+	}
+	if job.Details.JobID != "1234" {
+		t.Fatalf("Expected job 1234, got %q", job.Details.JobID)
+	}
+	if posted["/backups/recovery"] != `{"timestamp":"04-Mar-2018 13:30:00"}` {
+		t.Fatalf("Unexpected recovery request: %s", posted["/backups/recovery"])
+	}
+
+	for _, input := range []*RecoverDatabaseInput{
+		{ServiceInstanceID: "test-db"},
+		{ServiceInstanceID: "test-db", Latest: true, Tag: "TAG20180101T000000"},
+	} {
+		if _, err := client.Backups().RecoverDatabase(input); err == nil {
+			t.Fatalf("Expected an error recovering to %+v", input)
+		}
+	}
+}
@@ -80,9 +80,7 @@ type GetComputeNodesInput struct {
 
 // GetComputeNodes gets details of all Compute Nodes for a Service Instance
 func (c *UtilityClient) GetComputeNodes(input *GetComputeNodesInput) (*ComputeNodesInfo, error) {
-	if input.ServiceInstanceID != "" {
-		c.ServiceInstanceID = input.ServiceInstanceID
-	}
+	c = c.forServiceInstance(input.ServiceInstanceID)
 	var computeNodes []ComputeNodeInfo
 	if err := c.getResource("", &computeNodes); err != nil {
 		return nil, err
@@ -1,6 +1,10 @@
 package database
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 
 	"os"
@@ -59,3 +63,33 @@ func getComputeNodesTestClients() (*ServiceInstanceClient, *UtilityClient, error
 	}
 	return client.ServiceInstanceClient(), client.ComputeNodes(), nil
 }
+
+// TestComputeNodes_ConcurrentServiceInstances gets the compute nodes of several service instances in
+// parallel through a single client, checking each request is sent to the path of its own service instance.
+// Run with -race to check the client isn't modified by its operations.
+func TestComputeNodes_ConcurrentServiceInstances(t *testing.T) {
+	const instancesPath = "/paas/service/dbcs/api/v1.1/instances/test-domain/"
+	client := getStubTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		instance := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, instancesPath), "/servers")
+		if r.Method != "GET" || instance == r.URL.Path || strings.Contains(instance, "/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode([]map[string]string{{"hostname": instance + "-host"}})
+	})
+
+	computeNodes := client.ComputeNodes()
+	helper.RunConcurrently(t, 20, func(i int) error {
+		instance := fmt.Sprintf("test-db-%d", i)
+		info, err := computeNodes.GetComputeNodes(&GetComputeNodesInput{ServiceInstanceID: instance})
+		if err != nil {
+			return err
+		}
+		if len(info.Nodes) != 1 || info.Nodes[0].Hostname != instance+"-host" {
+			return fmt.Errorf("Expected the compute node of %s, got %+v", instance, info.Nodes)
+		}
+		return nil
+	})
+
+	assert.Empty(t, computeNodes.ServiceInstanceID, "Expected the shared client's service instance to be left unset")
+}
@@ -1,36 +1,49 @@
 package database
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
 	"github.com/hashicorp/go-oracle-terraform/client"
+	"github.com/hashicorp/go-oracle-terraform/jobs"
 	"github.com/hashicorp/go-oracle-terraform/opc"
 )
 
-const authHeader = "Authorization"
 const tenantHeader = "X-ID-TENANT-NAME"
 
 // Client - Client represents an authenticated database client, with compute credentials and an api client.
+// The client and the resource clients obtained from it can be shared between goroutines, as operations
+// apply their wait defaults and service instance to a copy of the resource client rather than changing it.
 type Client struct {
-	client     *client.Client
-	authHeader *string
+	client *client.Client
+	// Called with the new activity log messages of the jobs waited on by the client
+	// Optional
+	JobProgress jobs.ProgressFunc
 }
 
 // NewDatabaseClient returns a database client
 func NewDatabaseClient(c *opc.Config) (*Client, error) {
 	databaseClient := &Client{}
-	client, err := client.NewClient(c)
+	opcClient, err := client.NewClient(c)
 	if err != nil {
 		return nil, err
 	}
-	databaseClient.client = client
-
-	databaseClient.authHeader = databaseClient.getAuthenticationHeader()
+	databaseClient.client = opcClient.WithAuthenticator(&client.BasicAuthenticator{Credentials: opcClient.Credentials()})
 
 	return databaseClient, nil
 }
 
+// WithContext returns a copy of the database client bound to the given context.
+// Resource clients obtained from the returned client will abort their requests,
+// job polling and WaitFor loops once the context is done.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	c2 := new(Client)
+	*c2 = *c
+	c2.client = c.client.WithContext(ctx)
+	return c2
+}
+
 func (c *Client) executeRequest(method, path string, body interface{}) (*http.Response, error) {
 	reqBody, err := c.client.MarshallRequestBody(body)
 	if err != nil {
@@ -48,11 +61,10 @@ func (c *Client) executeRequest(method, path string, body interface{}) (*http.Re
 		// Debug the body for database services
 		debugReqString = fmt.Sprintf("%s:\nBody: %+v", debugReqString, string(reqBody))
 	}
-	// Log the request before the authentication header, so as not to leak credentials
+	// The authentication header is added by the client, so isn't leaked in the log
 	c.client.DebugLogString(debugReqString)
 
-	// Set the authentication headers
-	req.Header.Add(authHeader, *c.authHeader)
+	// Set the tenant header
 	req.Header.Add(tenantHeader, *c.client.IdentityDomain)
 	resp, err := c.client.ExecuteRequest(req)
 
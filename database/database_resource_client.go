@@ -3,6 +3,7 @@ package database
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -60,11 +61,12 @@ func (c *ResourceClient) deleteResource(name string, backups bool) error {
 
 	_, err := c.executeRequest("DELETE", objectPath, nil)
 	if err != nil {
-		if v, ok := err.(*opc.OracleError); ok {
-			if v.StatusCode == 404 {
-				// Object can't be found, doesn't exist, no error
-				return nil
-			}
+		if opc.IsNotFound(err) {
+			// Object can't be found, doesn't exist, no error
+			return nil
+		}
+		var v *opc.OracleError
+		if errors.As(err, &v) {
 			return fmt.Errorf("Error on delete (%d): %s", v.StatusCode, v.Message)
 		}
 
@@ -1,109 +1,58 @@
 package database
 
 import (
-	"fmt"
-	"time"
+	"github.com/hashicorp/go-oracle-terraform/jobs"
 )
 
-// API URI Paths for the Root Job path
-const (
-	JobRootPath = "/paas/api/v1.1/activitylog/%s/job/%s"
-)
-
-// Default Poll Interval value
-const waitForJobPollInterval = 1 * time.Second
+// JobRootPath is the API URI Path for the Root Job path
+const JobRootPath = jobs.RootPath
 
 // JobClient is a client for the Service functions of the Job API.
 type JobClient struct {
 	ResourceClient
-	PollInterval time.Duration
-	Timeout      time.Duration
+	*jobsClient
 }
 
-// Jobs returns a JobClient for checking job status
+// jobsClient is embedded in JobClient under an unexported name, so that
+// JobClient.Client is still the *Client of its ResourceClient
+type jobsClient = jobs.Client
+
+// Jobs returns a JobClient for checking job status, reporting the progress of jobs
+// being waited on to the JobProgress of the client
 func (c *Client) Jobs() *JobClient {
+	jobClient := jobs.NewClient(c.client)
+	jobClient.Progress = c.JobProgress
 	return &JobClient{
 		ResourceClient: ResourceClient{
 			Client:           c,
 			ResourceRootPath: JobRootPath,
 		},
+		jobsClient: jobClient,
 	}
 }
 
 // JobStatus defines the constants for the status of a job
-type JobStatus string
+type JobStatus = jobs.Status
 
 const (
 	// JobStatusNew - the job is new.
-	JobStatusNew JobStatus = "NEW"
+	JobStatusNew = jobs.StatusNew
 	// JobStatusRunning - the job is still running.
-	JobStatusRunning JobStatus = "RUNNING"
+	JobStatusRunning = jobs.StatusRunning
 	// JobStatusFailed - the job has failed.
-	JobStatusFailed JobStatus = "FAILED"
+	JobStatusFailed = jobs.StatusFailed
 	// JobStatusSucceed - the job has succeeded.
-	JobStatusSucceed JobStatus = "SUCCEED"
+	JobStatusSucceed = jobs.StatusSucceed
 )
 
 // JobResponse details the job information received after submitting a request
-type JobResponse struct {
-	Details Details `json:"details"`
-}
+type JobResponse = jobs.Response
 
 // Details details the attributes of the specific job that is running on the service instance
-type Details struct {
-	JobID   string `json:"jobId"`
-	Message string `json:"message"`
-}
+type Details = jobs.Details
 
 // Job details the attributes related to a job
-type Job struct {
-	// Job ID
-	ID int `json:"jobId"`
-	// Status of the job
-	Status JobStatus `json:"status"`
-}
+type Job = jobs.Job
 
 // GetJobInput specifies which job to retrieve
-type GetJobInput struct {
-	// ID of the job.
-	// Required.
-	ID string
-}
-
-// GetJob retrieves the job with the given id
-func (c *JobClient) GetJob(getInput *GetJobInput) (*Job, error) {
-	var job Job
-	if err := c.getResource(getInput.ID, &job); err != nil {
-		return nil, err
-	}
-
-	return &job, nil
-}
-
-// WaitForJobCompletion waits for a service instance to be in the desired state
-func (c *JobClient) WaitForJobCompletion(input *GetJobInput, pollInterval, timeoutSeconds time.Duration) error {
-	return c.client.WaitFor("job to complete", pollInterval, timeoutSeconds, func() (bool, error) {
-		info, getErr := c.GetJob(input)
-		if getErr != nil {
-			return false, getErr
-		}
-		c.client.DebugLogString(fmt.Sprintf("job ID is %v", info.ID))
-		switch s := info.Status; s {
-		case JobStatusSucceed: // Target State
-			c.client.DebugLogString("Job Succeeded")
-			return true, nil
-		case JobStatusFailed:
-			c.client.DebugLogString("Job Failed")
-			return false, fmt.Errorf("Job %q failed", input.ID)
-		case JobStatusNew:
-			c.client.DebugLogString("Job New")
-			return false, nil
-		case JobStatusRunning:
-			c.client.DebugLogString("Job Running")
-			return false, nil
-		default:
-			c.client.DebugLogString(fmt.Sprintf("Unknown job state: %s, waiting", s))
-			return false, nil
-		}
-	})
-}
+type GetJobInput = jobs.GetJobInput
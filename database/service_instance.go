@@ -38,6 +38,13 @@ func (c *Client) ServiceInstanceClient() *ServiceInstanceClient {
 		}}
 }
 
+// withWaitDefaults returns a copy of the client with the given poll interval and timeout, unless it sets its own
+func (c *ServiceInstanceClient) withWaitDefaults(pollInterval, timeout time.Duration) *ServiceInstanceClient {
+	c2 := *c
+	client.SetWaitDefaults(&c2.PollInterval, &c2.Timeout, pollInterval, timeout)
+	return &c2
+}
+
 // ServiceInstanceEdition is the allowable edition a service instance can be
 type ServiceInstanceEdition string
 
@@ -692,14 +699,11 @@ type ComputesInfo struct {
 
 // CreateServiceInstance creates a new ServiceInstace.
 func (c *ServiceInstanceClient) CreateServiceInstance(input *CreateServiceInstanceInput) (*ServiceInstance, error) {
-	if c.PollInterval == 0 {
-		c.PollInterval = waitForServiceInstanceReadyPollInterval
-	}
-	if c.Timeout == 0 {
-		c.Timeout = waitForServiceInstanceReadyTimeout
-	}
+	c = c.withWaitDefaults(waitForServiceInstanceReadyPollInterval, waitForServiceInstanceReadyTimeout)
 
-	c.checkAndSetCredentials(input)
+	if err := c.checkAndSetCredentials(input); err != nil {
+		return nil, err
+	}
 
 	// Create request where bools(true/false) are switched to strings(yes/no).
 	request := createRequest(input)
@@ -731,31 +735,41 @@ func createRequest(input *CreateServiceInstanceInput) *CreateServiceInstanceRequ
 
 // Since these CloudStorageUsername and CloudStoragePassword are sensitive we'll read them
 // from the client if they haven't specified in the config.
-func (c *ServiceInstanceClient) checkAndSetCredentials(input *CreateServiceInstanceInput) {
+func (c *ServiceInstanceClient) checkAndSetCredentials(input *CreateServiceInstanceInput) error {
+	if input.Parameter.CloudStorageContainer == "" && input.Parameter.IBKUPCloudStorageContainer == "" &&
+		input.Parameter.HDGCloudStorageContainer == "" {
+		return nil
+	}
+	creds, err := c.ResourceClient.Client.client.Credentials().Retrieve(c.ResourceClient.Client.client.Context())
+	if err != nil {
+		return err
+	}
+
 	if input.Parameter.CloudStorageContainer != "" {
 		if input.Parameter.CloudStorageUsername == "" {
-			input.Parameter.CloudStorageUsername = *c.ResourceClient.Client.client.UserName
+			input.Parameter.CloudStorageUsername = creds.Username
 		}
 		if input.Parameter.CloudStoragePassword == "" {
-			input.Parameter.CloudStoragePassword = *c.ResourceClient.Client.client.Password
+			input.Parameter.CloudStoragePassword = creds.Password
 		}
 	}
 	if input.Parameter.IBKUPCloudStorageContainer != "" {
 		if input.Parameter.IBKUPCloudStorageUser == "" {
-			input.Parameter.IBKUPCloudStorageUser = *c.ResourceClient.Client.client.UserName
+			input.Parameter.IBKUPCloudStorageUser = creds.Username
 		}
 		if input.Parameter.IBKUPCloudStoragePassword == "" {
-			input.Parameter.IBKUPCloudStoragePassword = *c.ResourceClient.Client.client.Password
+			input.Parameter.IBKUPCloudStoragePassword = creds.Password
 		}
 	}
 	if input.Parameter.HDGCloudStorageContainer != "" {
 		if input.Parameter.HDGCloudStorageUser == "" {
-			input.Parameter.HDGCloudStorageUser = *c.ResourceClient.Client.client.UserName
+			input.Parameter.HDGCloudStorageUser = creds.Username
 		}
 		if input.Parameter.HDGCloudStoragePassword == "" {
-			input.Parameter.HDGCloudStoragePassword = *c.ResourceClient.Client.client.Password
+			input.Parameter.HDGCloudStoragePassword = creds.Password
 		}
 	}
+	return nil
 }
 
 func (c *ServiceInstanceClient) startServiceInstance(name string, input *CreateServiceInstanceRequest) (*ServiceInstance, error) {
@@ -857,12 +871,7 @@ type DeleteServiceInstanceInput struct {
 
 // DeleteServiceInstance deletes the service instance with the specified input
 func (c *ServiceInstanceClient) DeleteServiceInstance(input *DeleteServiceInstanceInput) error {
-	if c.PollInterval == 0 {
-		c.PollInterval = waitForServiceInstanceDeletePollInterval
-	}
-	if c.Timeout == 0 {
-		c.Timeout = waitForServiceInstanceDeleteTimeout
-	}
+	c = c.withWaitDefaults(waitForServiceInstanceDeletePollInterval, waitForServiceInstanceDeleteTimeout)
 
 	// We can't delete backups if the instance is stopped so we'll start the instance if that is the case.
 	if input.DeleteBackup {
@@ -898,7 +907,9 @@ func (c *ServiceInstanceClient) DeleteServiceInstance(input *DeleteServiceInstan
 	for i := 0; i < serviceInstanceDeleteRetry; i++ {
 		if deleteErr = c.deleteResource(input.Name, input.DeleteBackup); deleteErr != nil {
 			log.Printf("Error during delete, waiting 30s: %+v", deleteErr)
-			time.Sleep(30 * time.Second)
+			if err := c.client.Sleep(30 * time.Second); err != nil {
+				return err
+			}
 			continue
 		}
 		break
@@ -968,12 +979,7 @@ type UpdateServiceInstanceInput struct {
 
 // UpdateServiceInstance updates the specified service instance
 func (c *ServiceInstanceClient) UpdateServiceInstance(input *UpdateServiceInstanceInput) (*ServiceInstance, error) {
-	if c.PollInterval == 0 {
-		c.PollInterval = waitForServiceInstanceReadyPollInterval
-	}
-	if c.Timeout == 0 {
-		c.Timeout = waitForServiceInstanceReadyTimeout
-	}
+	c = c.withWaitDefaults(waitForServiceInstanceReadyPollInterval, waitForServiceInstanceReadyTimeout)
 
 	if err := c.updateResource(input.Name, *input, nil, "PUT"); err != nil {
 		return nil, err
@@ -1012,12 +1018,7 @@ type DesiredStateInput struct {
 
 // UpdateDesiredState updates the specified desired state of a service instance
 func (c *ServiceInstanceClient) UpdateDesiredState(input *DesiredStateInput) (*ServiceInstance, error) {
-	if c.PollInterval == 0 {
-		c.PollInterval = waitForServiceInstanceReadyPollInterval
-	}
-	if c.Timeout == 0 {
-		c.Timeout = waitForServiceInstanceReadyTimeout
-	}
+	c = c.withWaitDefaults(waitForServiceInstanceReadyPollInterval, waitForServiceInstanceReadyTimeout)
 
 	if err := c.updateResource(input.Name, *input, nil, "POST"); err != nil {
 		return nil, err
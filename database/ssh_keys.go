@@ -102,9 +102,7 @@ type CreateSSHKeyInput struct {
 
 // CreateSSHKey creates an SSH Key with the supplied input struct.
 func (c *UtilityClient) CreateSSHKey(input *CreateSSHKeyInput) (*SSHKeyInfo, error) {
-	if input.ServiceInstanceID != "" {
-		c.ServiceInstanceID = input.ServiceInstanceID
-	}
+	c = c.forServiceInstance(input.ServiceInstanceID)
 
 	var sshKey SSHKeyInfo
 	if err := c.createResource(input, &sshKey); err != nil {
@@ -140,9 +138,7 @@ type GetSSHKeyInput struct {
 
 // GetSSHKey gets information on a single SSH Key
 func (c *UtilityClient) GetSSHKey(input *GetSSHKeyInput) (*SSHKeyInfo, error) {
-	if input.ServiceInstanceID != "" {
-		c.ServiceInstanceID = input.ServiceInstanceID
-	}
+	c = c.forServiceInstance(input.ServiceInstanceID)
 
 	// Name has to be populated in this case as the Container path and the Root path are completely
 	// separate paths. Otherwise, with a nil name, the Container path would have been used, which
@@ -26,6 +26,15 @@ type UtilityResourceClient struct {
 	ServiceInstanceID string
 }
 
+// forServiceInstance returns a copy of the client addressing the given service instance, or its own if the ID is empty
+func (c *UtilityClient) forServiceInstance(id string) *UtilityClient {
+	c2 := *c
+	if id != "" {
+		c2.ServiceInstanceID = id
+	}
+	return &c2
+}
+
 func (c *UtilityResourceClient) createResource(requestBody interface{}, responseBody interface{}) error {
 	_, err := c.executeRequest("POST", c.getContainerPath(c.ContainerPath), requestBody)
 
@@ -57,6 +66,21 @@ func (c *UtilityResourceClient) getResource(name string, responseBody interface{
 	return c.unmarshalResponseBody(resp, responseBody)
 }
 
+// submitJob POSTs the request body to start an asynchronous operation, returning the
+// details of the job performing it
+func (c *UtilityResourceClient) submitJob(path string, requestBody interface{}) (*JobResponse, error) {
+	resp, err := c.executeRequest("POST", path, requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobResponse JobResponse
+	if err := c.unmarshalResponseBody(resp, &jobResponse); err != nil {
+		return nil, err
+	}
+	return &jobResponse, nil
+}
+
 func (c *UtilityResourceClient) unmarshalResponseBody(resp *http.Response, iface interface{}) error {
 	buf := new(bytes.Buffer)
 	_, err := buf.ReadFrom(resp.Body)
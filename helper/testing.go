@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"sync"
 
 	"github.com/hashicorp/go-oracle-terraform/opc"
 )
@@ -37,3 +38,23 @@ type TestT interface {
 	Fatal(args ...interface{})
 	Skip(args ...interface{})
 }
+
+// RunConcurrently calls f from n goroutines, with the index of each call, and reports the errors
+// they return once every call has completed. Run tests with -race to check calls don't share state.
+func RunConcurrently(t TestT, n int, f func(i int) error) {
+	var wg sync.WaitGroup
+	errs := make([]error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = f(i)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -164,9 +164,7 @@ type CreateAccessRuleInput struct {
 // Thus, the Create method will return the resulting object from an internal GET call
 // during the WaitForReady timeout.
 func (c *UtilityClient) CreateAccessRule(input *CreateAccessRuleInput) (*AccessRuleInfo, error) {
-	if input.ServiceInstanceID != "" {
-		c.ServiceInstanceID = input.ServiceInstanceID
-	}
+	c = c.forServiceInstance(input.ServiceInstanceID)
 
 	var accessRule AccessRuleInfo
 	if err := c.createResource(input, &accessRule); err != nil {
@@ -223,9 +221,7 @@ type GetAccessRuleInput struct {
 // on how many access rules the customer has. However, since there's no direct GET API endpoint
 // for a single Access Rule, it's not able to be optimized yet.
 func (c *UtilityClient) GetAccessRule(input *GetAccessRuleInput) (*AccessRuleInfo, error) {
-	if input.ServiceInstanceID != "" {
-		c.ServiceInstanceID = input.ServiceInstanceID
-	}
+	c = c.forServiceInstance(input.ServiceInstanceID)
 
 	var accessRules AccessRules
 	if err := c.getResource("", &accessRules); err != nil {
@@ -270,9 +266,7 @@ type UpdateAccessRuleInput struct {
 // and any errors encountered
 func (c *UtilityClient) UpdateAccessRule(input *UpdateAccessRuleInput,
 ) (*AccessRuleInfo, error) {
-	if input.ServiceInstanceID != "" {
-		c.ServiceInstanceID = input.ServiceInstanceID
-	}
+	c = c.forServiceInstance(input.ServiceInstanceID)
 
 	// Since this is strictly an Update call, set the Operation constant
 	input.Operation = AccessRuleUpdate
@@ -320,9 +314,7 @@ type DeleteAccessRuleInput struct {
 
 // DeleteAccessRule Deletes an AccessRule with the provided input struct. Returns any errors that occurred.
 func (c *UtilityClient) DeleteAccessRule(input *DeleteAccessRuleInput) error {
-	if input.ServiceInstanceID != "" {
-		c.ServiceInstanceID = input.ServiceInstanceID
-	}
+	c = c.forServiceInstance(input.ServiceInstanceID)
 
 	// Since this is strictly an Update call, set the Operation constant
 	input.Operation = AccessRuleDelete
@@ -353,9 +345,7 @@ func (c *UtilityClient) DeleteAccessRule(input *DeleteAccessRuleInput) error {
 	if err != nil || serviceInstance == nil {
 		return fmt.Errorf("error waiting for service instance to be ready %q: %+v", input.ServiceInstanceID, err)
 	}
-	time.Sleep(2 * time.Minute)
-
-	return err
+	return c.client.Sleep(2 * time.Minute)
 }
 
 func (c *UtilityClient) waitForAccessRuleReady(input *GetAccessRuleInput, pollInterval, timeout time.Duration) (*AccessRuleInfo, error) {
@@ -3,6 +3,8 @@ package java
 import (
 	"fmt"
 	"time"
+
+	"github.com/hashicorp/go-oracle-terraform/client"
 )
 
 // API URI Paths for Container and Root objects
@@ -45,6 +47,13 @@ func (c *Client) IPReservationClient() *IPReservationClient {
 	}
 }
 
+// withWaitDefaults returns a copy of the client with the given poll interval and timeout, unless it sets its own
+func (c *IPReservationClient) withWaitDefaults(pollInterval, timeout time.Duration) *IPReservationClient {
+	c2 := *c
+	client.SetWaitDefaults(&c2.PollInterval, &c2.Timeout, pollInterval, timeout)
+	return &c2
+}
+
 // CreateIPReservationInput represents the Create IP Reservation API Request body
 type CreateIPReservationInput struct {
 	// Identity domain ID for the Database Cloud Service account
@@ -119,12 +128,7 @@ type IPReservations struct {
 // CreateIPReservation creates a new IP Reservation.
 func (c *IPReservationClient) CreateIPReservation(input *CreateIPReservationInput) (*IPReservationInfo, error) {
 
-	if c.PollInterval == 0 {
-		c.PollInterval = waitForIPReservationReadyPollInterval
-	}
-	if c.Timeout == 0 {
-		c.Timeout = waitForIPReservationReadyTimeout
-	}
+	c = c.withWaitDefaults(waitForIPReservationReadyPollInterval, waitForIPReservationReadyTimeout)
 
 	info, err := c.createResource(input)
 	if err != nil {
@@ -0,0 +1,52 @@
+package java
+
+import (
+	"github.com/hashicorp/go-oracle-terraform/patching"
+)
+
+// JavaPatchServiceInstancePath is the API URI Path of a service instance, which its patches are relative to
+const JavaPatchServiceInstancePath = "/paas/api/v1.1/instancemgmt/%s/services/jaas/instances/%s"
+
+// PatchClient is a client for listing, prechecking, applying and rolling back the patches of
+// Java service instances
+type PatchClient = patching.Client
+
+// Patches returns a PatchClient, which waits for the patching jobs with the JobClient of the client
+func (c *Client) Patches() *PatchClient {
+	return patching.NewClient(c.client, c.Jobs().jobsClient, JavaPatchServiceInstancePath)
+}
+
+// Patch details a patch available for a service instance
+type Patch = patching.Patch
+
+// AvailablePatches holds the patches available for a service instance
+type AvailablePatches = patching.AvailablePatches
+
+// AppliedPatch details a patch which has been applied to, or rolled back from, a service instance
+type AppliedPatch = patching.AppliedPatch
+
+// AppliedPatches holds the patching history of a service instance
+type AppliedPatches = patching.AppliedPatches
+
+// PatchStatus defines the constants for the status of a patch applied to a service instance
+type PatchStatus = patching.Status
+
+const (
+	// PatchStatusInProgress - the patch is being applied or rolled back.
+	PatchStatusInProgress = patching.StatusInProgress
+	// PatchStatusCompleted - the patch was applied.
+	PatchStatusCompleted = patching.StatusCompleted
+	// PatchStatusFailed - the patch failed to apply.
+	PatchStatusFailed = patching.StatusFailed
+	// PatchStatusRolledBack - the patch was rolled back.
+	PatchStatusRolledBack = patching.StatusRolledBack
+)
+
+// ListPatchesInput specifies the service instance to list the patches of
+type ListPatchesInput = patching.ListPatchesInput
+
+// GetPatchStatusInput specifies the patch to get the status of
+type GetPatchStatusInput = patching.GetPatchStatusInput
+
+// PatchInput specifies the patch to precheck, apply or roll back
+type PatchInput = patching.PatchInput
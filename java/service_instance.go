@@ -43,6 +43,13 @@ func (c *Client) ServiceInstanceClient() *ServiceInstanceClient {
 		}}
 }
 
+// withWaitDefaults returns a copy of the client with the given poll interval and timeout, unless it sets its own
+func (c *ServiceInstanceClient) withWaitDefaults(pollInterval, timeout time.Duration) *ServiceInstanceClient {
+	c2 := *c
+	client.SetWaitDefaults(&c2.PollInterval, &c2.Timeout, pollInterval, timeout)
+	return &c2
+}
+
 // ServiceInstanceLevel specifies the level type for the service instance
 type ServiceInstanceLevel string
 
@@ -1512,18 +1519,17 @@ type AppDB struct {
 
 // CreateServiceInstance creates a new ServiceInstace.
 func (c *ServiceInstanceClient) CreateServiceInstance(input *CreateServiceInstanceInput) (*ServiceInstance, error) {
-	if c.PollInterval == 0 {
-		c.PollInterval = waitForServiceInstanceReadyPollInterval
-	}
-	if c.Timeout == 0 {
-		c.Timeout = waitForServiceInstanceReadyTimeout
-	}
+	c = c.withWaitDefaults(waitForServiceInstanceReadyPollInterval, waitForServiceInstanceReadyTimeout)
 
 	// Since these CloudStorageUsername and CloudStoragePassword are sensitive we'll read them
 	// from the environment if they aren't passed in.
 	if input.CloudStorageContainer != "" && input.CloudStorageUsername == "" && input.CloudStoragePassword == "" {
-		input.CloudStorageUsername = *c.ResourceClient.Client.client.UserName
-		input.CloudStoragePassword = *c.ResourceClient.Client.client.Password
+		creds, err := c.ResourceClient.Client.client.Credentials().Retrieve(c.ResourceClient.Client.client.Context())
+		if err != nil {
+			return nil, err
+		}
+		input.CloudStorageUsername = creds.Username
+		input.CloudStoragePassword = creds.Password
 	}
 
 	// The JCS API errors if an ssh key has trailing content; we'll trim that here.
@@ -1683,12 +1689,7 @@ type DeleteServiceInstanceInput struct {
 
 // DeleteServiceInstance deletes the specified service instance
 func (c *ServiceInstanceClient) DeleteServiceInstance(deleteInput *DeleteServiceInstanceInput) error {
-	if c.PollInterval == 0 {
-		c.PollInterval = waitForServiceInstanceDeletePollInterval
-	}
-	if c.Timeout == 0 {
-		c.Timeout = waitForServiceInstanceDeleteTimeout
-	}
+	c = c.withWaitDefaults(waitForServiceInstanceDeletePollInterval, waitForServiceInstanceDeleteTimeout)
 
 	// There are times when the service instance isn't in a state to be deleted even though the api returns a ready
 	// instance. We'll wait a set amount of time for it to be ready to delete before erroring out.
@@ -1701,7 +1702,9 @@ func (c *ServiceInstanceClient) DeleteServiceInstance(deleteInput *DeleteService
 			c.client.DebugLogString(fmt.Sprintf("(Iteration: %d of %d) Finished deleting instance with name %s", i, *c.Client.client.MaxRetries, deleteInput.Name))
 			break
 		}
-		time.Sleep(1 * time.Minute)
+		if err := c.client.Sleep(1 * time.Minute); err != nil {
+			return err
+		}
 	}
 	if deleteErr != nil {
 		return fmt.Errorf("error submitting delete request for java service instance %q", deleteInput.Name)
@@ -1782,12 +1785,7 @@ type ScaleUpDownWLS struct {
 // ScaleUpDownServiceInstance scales the service instance up or down depending on the shape passed in.
 func (c *ServiceInstanceClient) ScaleUpDownServiceInstance(input *ScaleUpDownServiceInstanceInput) error {
 	var jobResponse JobResponse
-	if c.PollInterval == 0 {
-		c.PollInterval = waitForServiceInstanceReadyPollInterval
-	}
-	if c.Timeout == 0 {
-		c.Timeout = waitForServiceInstanceReadyTimeout
-	}
+	c = c.withWaitDefaults(waitForServiceInstanceReadyPollInterval, waitForServiceInstanceReadyTimeout)
 
 	if err := c.updateResource(input.Name, serviceInstanceScaleUpDownPath, "POST", input, &jobResponse); err != nil {
 		return fmt.Errorf("unable to update Java Service Instance %q: %+v", input.Name, err)
@@ -1843,12 +1841,7 @@ type DesiredStateHost struct {
 // UpdateDesiredState updates the specified desired state of a service instance
 func (c *ServiceInstanceClient) UpdateDesiredState(input *DesiredStateInput) error {
 	var jobResponse JobResponse
-	if c.PollInterval == 0 {
-		c.PollInterval = waitForServiceInstanceReadyPollInterval
-	}
-	if c.Timeout == 0 {
-		c.Timeout = waitForServiceInstanceReadyTimeout
-	}
+	c = c.withWaitDefaults(waitForServiceInstanceReadyPollInterval, waitForServiceInstanceReadyTimeout)
 
 	if err := c.updateResource(input.Name, fmt.Sprintf(serviceInstanceDesiredStatePath, input.LifecycleState), "POST", input, &jobResponse); err != nil {
 		return err
@@ -1931,12 +1924,7 @@ type ScaleOutWLS struct {
 // ScaleOutServiceInstance scales out a Java Service Instance
 func (c *ServiceInstanceClient) ScaleOutServiceInstance(input *ScaleOutInput) error {
 	var jobResponse JobResponse
-	if c.PollInterval == 0 {
-		c.PollInterval = waitForServiceInstanceReadyPollInterval
-	}
-	if c.Timeout == 0 {
-		c.Timeout = waitForServiceInstanceReadyTimeout
-	}
+	c = c.withWaitDefaults(waitForServiceInstanceReadyPollInterval, waitForServiceInstanceReadyTimeout)
 
 	if err := c.updateResource(input.Name, serviceInstanceScaleInOutPath, "POST", input, &jobResponse); err != nil {
 		return err
@@ -1982,12 +1970,7 @@ type ScaleInHostName struct {
 // ScaleInServiceInstance scales in a Java Service Instance
 func (c *ServiceInstanceClient) ScaleInServiceInstance(input *ScaleInInput) error {
 	var jobResponse JobResponse
-	if c.PollInterval == 0 {
-		c.PollInterval = waitForServiceInstanceReadyPollInterval
-	}
-	if c.Timeout == 0 {
-		c.Timeout = waitForServiceInstanceReadyTimeout
-	}
+	c = c.withWaitDefaults(waitForServiceInstanceReadyPollInterval, waitForServiceInstanceReadyTimeout)
 
 	if err := c.updateResource(input.Name, serviceInstanceScaleInOutPath, "PUT", input, &jobResponse); err != nil {
 		return err
@@ -26,6 +26,15 @@ type UtilityResourceClient struct {
 	ServiceInstanceID string
 }
 
+// forServiceInstance returns a copy of the client addressing the given service instance, or its own if the ID is empty
+func (c *UtilityClient) forServiceInstance(id string) *UtilityClient {
+	c2 := *c
+	if id != "" {
+		c2.ServiceInstanceID = id
+	}
+	return &c2
+}
+
 func (c *UtilityResourceClient) createResource(requestBody interface{}, responseBody interface{}) error {
 	_, err := c.executeRequest("POST", c.getContainerPath(c.ContainerPath), requestBody)
 	return err
@@ -0,0 +1,271 @@
+// Package jobs tracks the asynchronous jobs submitted to the PaaS services (Database,
+// Java and MySQL Cloud Service) through the shared activity log API.
+package jobs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-oracle-terraform/client"
+	"github.com/mitchellh/mapstructure"
+)
+
+// RootPath is the API URI Path of a Job, formatted with the identity domain and job id
+const RootPath = "/paas/api/v1.1/activitylog/%s/job/%s"
+
+// Default Poll Interval and Timeout values
+const waitForJobPollInterval = 1 * time.Second
+const waitForJobTimeout = 60 * time.Minute
+
+const tenantHeader = "X-ID-TENANT-NAME"
+
+// Status defines the constants for the status of a job
+type Status string
+
+const (
+	// StatusNew - the job is new.
+	StatusNew Status = "NEW"
+	// StatusRunning - the job is still running.
+	StatusRunning Status = "RUNNING"
+	// StatusFailed - the job has failed.
+	StatusFailed Status = "FAILED"
+	// StatusSucceed - the job has succeeded.
+	StatusSucceed Status = "SUCCEED"
+)
+
+// Response details the job information received after submitting a request
+type Response struct {
+	Details Details `json:"details"`
+}
+
+// Details details the attributes of the specific job that is running on the service instance
+type Details struct {
+	JobID   string `json:"jobId"`
+	Message string `json:"message"`
+}
+
+// Message is an entry in the activity log of a job
+type Message struct {
+	// Date and time the activity was logged
+	ActivityDate string `json:"activityDate"`
+	// The logged activity
+	Message string `json:"message"`
+}
+
+// Job details the attributes related to a job
+type Job struct {
+	// Job ID
+	ID int `json:"jobId"`
+	// Status of the job
+	Status Status `json:"status"`
+	// ID of the activity log of the job
+	ActivityLogID int `json:"activityLogId"`
+	// Identity domain of the service instance
+	IdentityDomain string `json:"identityDomain"`
+	// User who started the job
+	InitiatedBy string `json:"initiatedBy"`
+	// The operation performed by the job, e.g. CREATE_SERVICE or SCALE_UP
+	OperationType string `json:"operationType"`
+	// Name of the service instance the job operates on
+	ServiceName string `json:"serviceName"`
+	// Type of the service, e.g. dbaas or jaas
+	ServiceType string `json:"serviceType"`
+	// Date and time the job started
+	StartDate string `json:"startDate"`
+	// Date and time the job ended
+	EndDate string `json:"endDate"`
+	// Summary of the outcome of the job, or of the step it failed at
+	SummaryMessage string `json:"summaryMessage"`
+	// The activity log of the job
+	Messages []Message `json:"messages"`
+}
+
+// ProgressFunc is called with each new activity log message of a job being waited on
+type ProgressFunc func(job *Job, message Message)
+
+// Error is returned when waiting on a job that fails
+type Error struct {
+	// The failed job
+	Job *Job
+	// The summary message of the step the job failed at
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("Job %d failed", e.Job.ID)
+	}
+	return fmt.Sprintf("Job %d failed: %s", e.Job.ID, e.Message)
+}
+
+func newError(job *Job) *Error {
+	message := strings.TrimSpace(job.SummaryMessage)
+	if messages := sortedMessages(job.Messages); message == "" && len(messages) > 0 {
+		message = strings.TrimSpace(messages[len(messages)-1].Message)
+	}
+	return &Error{Job: job, Message: message}
+}
+
+// sortedMessages returns the activity log messages in the order they were logged, as the
+// API returns the most recent first
+func sortedMessages(messages []Message) []Message {
+	sorted := append([]Message{}, messages...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ActivityDate < sorted[j].ActivityDate
+	})
+	return sorted
+}
+
+// Client is a client for the Service functions of the Job API.
+type Client struct {
+	client       *client.Client
+	PollInterval time.Duration
+	Timeout      time.Duration
+	// Called with the new activity log messages of jobs being waited on
+	// Optional
+	Progress ProgressFunc
+}
+
+// NewClient returns a Client for the jobs of the identity domain of the service client
+func NewClient(c *client.Client) *Client {
+	return &Client{client: c}
+}
+
+// GetJobInput specifies which job to retrieve
+type GetJobInput struct {
+	// ID of the job.
+	// Required.
+	ID string
+}
+
+// GetJob retrieves the job with the given id, including its activity log
+func (c *Client) GetJob(getInput *GetJobInput) (*Job, error) {
+	path := fmt.Sprintf(RootPath, *c.client.IdentityDomain, getInput.ID)
+	req, err := c.client.BuildRequestBody("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add(tenantHeader, *c.client.IdentityDomain)
+	c.client.DebugLogString(fmt.Sprintf("HTTP GET Req (%s)", path))
+
+	resp, err := c.client.ExecuteRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	if _, err = buf.ReadFrom(resp.Body); err != nil {
+		return nil, err
+	}
+	c.client.DebugLogString(fmt.Sprintf("HTTP Resp (%d): %s", resp.StatusCode, buf.String()))
+	var tmp interface{}
+	if err = json.NewDecoder(buf).Decode(&tmp); err != nil {
+		return nil, fmt.Errorf("Error decoding job %s: %s", getInput.ID, err)
+	}
+
+	// Use mapstructure to weakly decode into the job, as the API returns some numbers as strings
+	var job Job
+	msdcd, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &job,
+		TagName:          "json",
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := msdcd.Decode(tmp); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// WaitForJobInput specifies the job to wait for
+type WaitForJobInput struct {
+	// ID of the job.
+	// Required.
+	ID string
+	// Optional - Defaults to the PollInterval of the Client
+	PollInterval time.Duration
+	// Optional - Defaults to the Timeout of the Client
+	Timeout time.Duration
+	// Called with each new activity log message, in addition to the Progress of the Client
+	// Optional
+	Progress ProgressFunc
+}
+
+// WaitForJob waits for a job to succeed, reporting the new activity log messages to the progress
+// callbacks while polling. If the job fails an *Error with the failing step's summary is returned.
+func (c *Client) WaitForJob(input *WaitForJobInput) (*Job, error) {
+	pollInterval := input.PollInterval
+	if pollInterval == 0 {
+		pollInterval = c.PollInterval
+	}
+	if pollInterval == 0 {
+		pollInterval = waitForJobPollInterval
+	}
+	timeout := input.Timeout
+	if timeout == 0 {
+		timeout = c.Timeout
+	}
+	if timeout == 0 {
+		timeout = waitForJobTimeout
+	}
+
+	var job *Job
+	seen := map[Message]bool{}
+	err := c.client.WaitFor("job to complete", pollInterval, timeout, func() (bool, error) {
+		info, getErr := c.GetJob(&GetJobInput{ID: input.ID})
+		if getErr != nil {
+			return false, getErr
+		}
+		job = info
+		c.client.DebugLogString(fmt.Sprintf("job ID is %v", info.ID))
+
+		for _, message := range sortedMessages(info.Messages) {
+			if seen[message] {
+				continue
+			}
+			seen[message] = true
+			c.client.DebugLogString(fmt.Sprintf("Job %v: %s", info.ID, message.Message))
+			if c.Progress != nil {
+				c.Progress(info, message)
+			}
+			if input.Progress != nil {
+				input.Progress(info, message)
+			}
+		}
+
+		switch s := info.Status; s {
+		case StatusSucceed: // Target State
+			c.client.DebugLogString("Job Succeeded")
+			return true, nil
+		case StatusFailed:
+			c.client.DebugLogString("Job Failed")
+			return false, newError(info)
+		case StatusNew:
+			c.client.DebugLogString("Job New")
+			return false, nil
+		case StatusRunning:
+			c.client.DebugLogString("Job Running")
+			return false, nil
+		default:
+			c.client.DebugLogString(fmt.Sprintf("Unknown job state: %s, waiting", s))
+			return false, nil
+		}
+	})
+	return job, err
+}
+
+// WaitForJobCompletion waits for a job to succeed
+func (c *Client) WaitForJobCompletion(input *GetJobInput, pollInterval, timeoutSeconds time.Duration) error {
+	_, err := c.WaitForJob(&WaitForJobInput{
+		ID:           input.ID,
+		PollInterval: pollInterval,
+		Timeout:      timeoutSeconds,
+	})
+	return err
+}
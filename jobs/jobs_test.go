@@ -0,0 +1,115 @@
+package jobs
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testJobServer serves a job which logs one more message each time it's polled,
+// and then finishes with the final status and summary message
+type testJobServer struct {
+	mu       sync.Mutex
+	polls    int
+	messages []string
+	status   Status
+	summary  string
+}
+
+// newTestJobClient returns a client for a server with a single job, served by a testJobServer
+func newTestJobClient(t *testing.T, messages []string, status Status, summary string) *Client {
+	s := &testJobServer{messages: messages, status: status, summary: summary}
+	return getStubTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if r.URL.Path != "/paas/api/v1.1/activitylog/test-domain/job/1234" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get(tenantHeader) != "test-domain" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		s.polls++
+		logged := s.polls
+		status, summary := StatusRunning, ""
+		if logged >= len(s.messages) {
+			logged = len(s.messages)
+			status, summary = s.status, s.summary
+		}
+		// The activity log is returned most recent first
+		messages := []map[string]string{}
+		for i := logged - 1; i >= 0; i-- {
+			messages = append(messages, map[string]string{
+				"activityDate": time.Date(2018, 1, 1, 0, i, 0, 0, time.UTC).Format(time.RFC3339),
+				"message":      s.messages[i],
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jobId":          "1234",
+			"status":         status,
+			"operationType":  "SCALE_UP",
+			"serviceName":    "test-instance",
+			"summaryMessage": summary,
+			"messages":       messages,
+		})
+	})
+}
+
+func TestClient_WaitForJobProgress(t *testing.T) {
+	logged := []string{"Starting scale up", "Stopping instance", "Starting instance"}
+	jobClient := newTestJobClient(t, logged, StatusSucceed, "Scale up completed")
+
+	var clientMessages, inputMessages []string
+	jobClient.Progress = func(job *Job, message Message) {
+		clientMessages = append(clientMessages, message.Message)
+	}
+	job, err := jobClient.WaitForJob(&WaitForJobInput{
+		ID:           "1234",
+		PollInterval: 10 * time.Millisecond,
+		Timeout:      5 * time.Second,
+		Progress: func(job *Job, message Message) {
+			inputMessages = append(inputMessages, message.Message)
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error waiting for job: %s", err)
+	}
+
+	if job.ID != 1234 || job.Status != StatusSucceed || job.ServiceName != "test-instance" {
+		t.Fatalf("Unexpected job: %+v", job)
+	}
+	// Each message is reported once, as it's logged
+	for _, messages := range [][]string{clientMessages, inputMessages} {
+		if len(messages) != len(logged) {
+			t.Fatalf("Expected messages %v, got %v", logged, messages)
+		}
+		for i := range logged {
+			if messages[i] != logged[i] {
+				t.Fatalf("Expected messages %v, got %v", logged, messages)
+			}
+		}
+	}
+}
+
+func TestClient_WaitForJobFailure(t *testing.T) {
+	jobClient := newTestJobClient(t, []string{"Starting scale up", "Insufficient quota for shape"}, StatusFailed, "")
+	err := jobClient.WaitForJobCompletion(&GetJobInput{ID: "1234"}, 10*time.Millisecond, 5*time.Second)
+
+	var jobErr *Error
+	if !errors.As(err, &jobErr) {
+		t.Fatalf("Expected a job error, got %v", err)
+	}
+	// Without a summary message, the most recent activity explains the failure
+	if jobErr.Message != "Insufficient quota for shape" {
+		t.Fatalf("Unexpected failure message: %q", jobErr.Message)
+	}
+	if jobErr.Error() != "Job 1234 failed: Insufficient quota for shape" {
+		t.Fatalf("Unexpected error: %s", jobErr)
+	}
+}
@@ -2,9 +2,12 @@ package lbaas
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/hashicorp/go-oracle-terraform/client"
@@ -73,7 +76,37 @@ const (
 	QueryDetailed QueryProjection = "DETAILED"
 )
 
+// ListInput specifies the projection and page of a collection of resources to list
+type ListInput struct {
+	// The level of detail returned for each resource
+	// Optional
+	Projection QueryProjection
+	// The index of the first resource to return
+	// Optional
+	Offset int
+	// The maximum number of resources to return. If unset, every page of the collection
+	// from the Offset onwards is requested and returned.
+	// Optional
+	Limit int
+}
+
+// ListInfo describes the page of resources returned from a collection
+type ListInfo struct {
+	HasMore      bool `json:"has_more"`
+	Limit        int  `json:"limit"`
+	Offset       int  `json:"offset"`
+	TotalResults int  `json:"total_results"`
+}
+
+// collectionPage is a single page of a collection of resources, with the items left undecoded
+type collectionPage struct {
+	ListInfo `json:",squash"`
+	Items    []interface{} `json:"items"`
+}
+
 // Client implementation for Oracle Cloud Infrastructure Load Balancing Classic */
+// The client and the resource clients obtained from it can be shared between goroutines, as operations
+// apply their wait defaults and request settings to a copy of the resource client rather than changing it.
 type Client struct {
 	client       *client.Client
 	PollInterval time.Duration
@@ -83,15 +116,32 @@ type Client struct {
 // NewClient returns a new LBaaSClient
 func NewClient(c *opc.Config) (*Client, error) {
 	appClient := &Client{}
-	client, err := client.NewClient(c)
+	opcClient, err := client.NewClient(c)
 	if err != nil {
 		return nil, err
 	}
-	appClient.client = client
+	appClient.client = opcClient.WithAuthenticator(&client.BasicAuthenticator{Credentials: opcClient.Credentials()})
 
 	return appClient, nil
 }
 
+// withWaitDefaults returns a copy of the client with the given poll interval and timeout, unless it sets its own
+func (c *Client) withWaitDefaults(pollInterval, timeout time.Duration) *Client {
+	c2 := *c
+	client.SetWaitDefaults(&c2.PollInterval, &c2.Timeout, pollInterval, timeout)
+	return &c2
+}
+
+// WithContext returns a copy of the LBaaS client bound to the given context.
+// Resource clients obtained from the returned client will abort their requests
+// and state polling once the context is done.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	c2 := new(Client)
+	*c2 = *c
+	c2.client = c.client.WithContext(ctx)
+	return c2
+}
+
 func (c *Client) executeRequest(method, path, accept, contentType string, body interface{}) (*http.Response, error) {
 
 	reqBody, err := c.client.MarshallRequestBody(body)
@@ -111,12 +161,9 @@ func (c *Client) executeRequest(method, path, accept, contentType string, body i
 		req.Header.Set("Content-Type", contentType)
 		debugReqString = fmt.Sprintf("%s:\nContent-Type: %+v\nBody: %+v", debugReqString, contentType, string(reqBody))
 	}
-	// Log the request before the authentication header, so as not to leak credentials
+	// The authentication header is added by the client, so isn't leaked in the log
 	c.client.DebugLogString(debugReqString)
 
-	// Set the authentication headers
-	req.SetBasicAuth(*c.client.UserName, *c.client.Password)
-
 	resp, err := c.client.ExecuteRequest(req)
 	if err != nil {
 		return nil, err
@@ -145,12 +192,9 @@ func (c *Client) executeRequestWithMethodOverride(method, methodOverride, path,
 		req.Header.Set("Content-Type", contentType)
 		debugReqString = fmt.Sprintf("%s:\nContent-Type: %+v\nBody: %+v", debugReqString, contentType, string(reqBody))
 	}
-	// Log the request before the authentication header, so as not to leak credentials
+	// The authentication header is added by the client, so isn't leaked in the log
 	c.client.DebugLogString(debugReqString)
 
-	// Set the authentication headers
-	req.SetBasicAuth(*c.client.UserName, *c.client.Password)
-
 	resp, err := c.client.ExecuteRequest(req)
 	if err != nil {
 		return nil, err
@@ -179,8 +223,11 @@ func (c *Client) unmarshalResponseBody(resp *http.Response, iface interface{}) e
 	if err = dcd.Decode(&tmp); err != nil {
 		return err
 	}
+	return decodeResponse(tmp, iface)
+}
 
-	// Use mapstructure to weakly decode into the resulting interface
+// Use mapstructure to weakly decode the JSON decoded response into the resulting interface
+func decodeResponse(input interface{}, iface interface{}) error {
 	msdcd, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
 		WeaklyTypedInput: true,
 		Result:           iface,
@@ -190,12 +237,63 @@ func (c *Client) unmarshalResponseBody(resp *http.Response, iface interface{}) e
 		return err
 	}
 
-	if err := msdcd.Decode(tmp); err != nil {
+	if err := msdcd.Decode(input); err != nil {
 		return err
 	}
 	return nil
 }
 
+// executes the List requests to the LBaaS API, decoding the items of the requested pages
+// of the collection into the items slice
+func (c *Client) listResources(path, accept string, input *ListInput, items interface{}) (*ListInfo, error) {
+	if input == nil {
+		input = &ListInput{}
+	}
+
+	var allItems []interface{}
+	info := ListInfo{Offset: input.Offset, Limit: input.Limit}
+	offset := input.Offset
+	for {
+		query := url.Values{}
+		if input.Projection != "" {
+			query.Set("projection", string(input.Projection))
+		}
+		if offset > 0 {
+			query.Set("offset", strconv.Itoa(offset))
+		}
+		if input.Limit > 0 {
+			query.Set("limit", strconv.Itoa(input.Limit))
+		}
+		pagePath := path
+		if len(query) > 0 {
+			pagePath = fmt.Sprintf("%s?%s", path, query.Encode())
+		}
+
+		resp, err := c.executeRequest("GET", pagePath, accept, "", nil)
+		if err != nil {
+			return nil, err
+		}
+		var page collectionPage
+		if err := c.unmarshalResponseBody(resp, &page); err != nil {
+			return nil, err
+		}
+
+		allItems = append(allItems, page.Items...)
+		info.HasMore = page.HasMore
+		info.TotalResults = page.TotalResults
+		// a single page was requested, or there are no more pages to follow
+		if input.Limit > 0 || !page.HasMore || len(page.Items) == 0 {
+			break
+		}
+		offset += len(page.Items)
+	}
+
+	if err := decodeResponse(allItems, items); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
 // return true if a given LBaaSState is in a List of LBaaSStates
 func isStateInLBaaSStates(state LBaaSState, list []LBaaSState) bool {
 	for _, s := range list {
@@ -0,0 +1,64 @@
+package lbaas
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-oracle-terraform/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSSLCertificateClient_Concurrent creates server and trusted certificates in parallel through
+// a single client, checking each request is sent with the content type of its own certificate.
+// Run with -race to check the client isn't modified by its operations.
+func TestSSLCertificateClient_Concurrent(t *testing.T) {
+	var mu sync.Mutex
+	var mismatched []string
+	client := getStubTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var input CreateSSLCertificateInput
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// The update request body doesn't include whether the certificate is trusted, so it's named for it
+		expected := ContentTypeServerCertificateJSON
+		if strings.HasPrefix(input.Name, "trusted-") {
+			expected = ContentTypeTrustedCertificateJSON
+		}
+		if contentType := r.Header.Get("Content-Type"); contentType != expected {
+			mu.Lock()
+			mismatched = append(mismatched, fmt.Sprintf("%s: %s", input.Name, contentType))
+			mu.Unlock()
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"name":    input.Name,
+			"trusted": input.Trusted,
+			"state":   LBaaSStateHealthy,
+		})
+	})
+
+	sslCertificates := client.SSLCertificateClient()
+	helper.RunConcurrently(t, 20, func(i int) error {
+		name := fmt.Sprintf("server-%d", i)
+		trusted := i%2 == 0
+		if trusted {
+			name = fmt.Sprintf("trusted-%d", i)
+		}
+		if _, err := sslCertificates.CreateSSLCertificate(&CreateSSLCertificateInput{Name: name, Certificate: "cert", Trusted: trusted}); err != nil {
+			return fmt.Errorf("Error creating SSL certificate: %s", err)
+		}
+		if _, err := sslCertificates.UpdateSSLCertificate(name, &UpdateSSLCertificateInput{Name: name, Certificate: "cert", Trusted: trusted}); err != nil {
+			return fmt.Errorf("Error updating SSL certificate: %s", err)
+		}
+		return nil
+	})
+
+	assert.Empty(t, mismatched, "Expected each request to use the content type of its certificate")
+	assert.Empty(t, sslCertificates.ContentType, "Expected the shared client's content type to be left unset")
+	assert.Zero(t, client.PollInterval, "Expected the shared client's poll interval to be left unset")
+	assert.Zero(t, client.Timeout, "Expected the shared client's timeout to be left unset")
+}
@@ -58,7 +58,7 @@ func (c *LBaaSResourceClient) getResource(lbRegion, lbName, name string, respons
 	objectPath := c.getObjectPath(c.ResourceRootPath, lbRegion, lbName, name)
 	queryParams := ""
 	if c.Projection != "" {
-		queryParams = fmt.Sprintf("?projection=%s" + c.Projection)
+		queryParams = fmt.Sprintf("?projection=%s", c.Projection)
 	}
 	resp, err := c.executeRequest("GET", objectPath+queryParams, c.Accept, c.ContentType, nil)
 	if err != nil {
@@ -76,3 +76,8 @@ func (c *LBaaSResourceClient) deleteResource(lbRegion, lbName, name string, resp
 	}
 	return c.unmarshalResponseBody(resp, responseBody)
 }
+
+// executes the List requests to the LBaaS API
+func (c *LBaaSResourceClient) listResources(lbRegion, lbName string, input *ListInput, items interface{}) (*ListInfo, error) {
+	return c.Client.listResources(c.getContainerPath(c.ContainerPath, lbRegion, lbName), c.Accept, input, items)
+}
@@ -0,0 +1,109 @@
+package lbaas
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestCollectionServer serves the named items at the path as a paginated
+// collection, recording the query of each request
+func newTestCollectionServer(t *testing.T, path string, names []string, pageSize int) (*Client, *[]url.Values) {
+	var queries []url.Values
+	client := getStubTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		query := r.URL.Query()
+		queries = append(queries, query)
+
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		if limit == 0 || limit > pageSize {
+			limit = pageSize
+		}
+		end := offset + limit
+		if end > len(names) {
+			end = len(names)
+		}
+		items := []map[string]interface{}{}
+		for _, name := range names[offset:end] {
+			items = append(items, map[string]interface{}{"name": name, "state": "HEALTHY"})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"items":         items,
+			"has_more":      end < len(names),
+			"offset":        offset,
+			"limit":         limit,
+			"total_results": len(names),
+		})
+	})
+	return client, &queries
+}
+
+func testNames(prefix string, count int) []string {
+	names := make([]string, count)
+	for i := range names {
+		names[i] = fmt.Sprintf("%s-%d", prefix, i)
+	}
+	return names
+}
+
+func TestListLoadBalancers_AllPages(t *testing.T) {
+	names := testNames("lb", 5)
+	client, queries := newTestCollectionServer(t, "/vlbrs/uscom-central-1", names, 2)
+
+	output, err := client.LoadBalancerClient().ListLoadBalancers(&ListLoadBalancersInput{
+		Region:    "uscom-central-1",
+		ListInput: ListInput{Projection: QueryMinimal},
+	})
+	if err != nil {
+		t.Fatalf("Error listing load balancers: %s", err)
+	}
+
+	assert.Len(t, *queries, 3, "Every page should be requested")
+	for _, query := range *queries {
+		assert.Equal(t, "MINIMAL", query.Get("projection"))
+	}
+	assert.Len(t, output.LoadBalancers, 5)
+	for i, lb := range output.LoadBalancers {
+		assert.Equal(t, names[i], lb.Name)
+		assert.Equal(t, LBaaSStateHealthy, lb.State)
+	}
+	assert.False(t, output.HasMore)
+	assert.Equal(t, 5, output.TotalResults)
+}
+
+func TestListListeners_SinglePage(t *testing.T) {
+	client, queries := newTestCollectionServer(t, "/vlbrs/uscom-central-1/lb1/listeners", testNames("listener", 5), 10)
+	lb := LoadBalancerContext{Region: "uscom-central-1", Name: "lb1"}
+
+	output, err := client.ListenerClient().ListListeners(lb, &ListInput{Offset: 1, Limit: 2})
+	if err != nil {
+		t.Fatalf("Error listing listeners: %s", err)
+	}
+
+	assert.Len(t, *queries, 1, "Only the requested page should be requested")
+	assert.Equal(t, "1", (*queries)[0].Get("offset"))
+	assert.Equal(t, "2", (*queries)[0].Get("limit"))
+	assert.Equal(t, []string{"listener-1", "listener-2"}, []string{output.Listeners[0].Name, output.Listeners[1].Name})
+	assert.True(t, output.HasMore)
+	assert.Equal(t, 1, output.Offset)
+}
+
+func TestListSSLCertificates_Empty(t *testing.T) {
+	client, _ := newTestCollectionServer(t, "/certs", nil, 10)
+
+	output, err := client.SSLCertificateClient().ListSSLCertificates(nil)
+	if err != nil {
+		t.Fatalf("Error listing certificates: %s", err)
+	}
+	assert.Empty(t, output.SSLCertificates)
+	assert.False(t, output.HasMore)
+}
@@ -39,6 +39,13 @@ func (c *Client) ListenerClient() *ListenerClient {
 	}
 }
 
+// withWaitDefaults returns a copy of the client with the given poll interval and timeout, see Client.withWaitDefaults
+func (c *ListenerClient) withWaitDefaults(pollInterval, timeout time.Duration) *ListenerClient {
+	c2 := *c
+	c2.Client = c.Client.withWaitDefaults(pollInterval, timeout)
+	return &c2
+}
+
 type Protocol string
 
 const (
@@ -81,6 +88,12 @@ type ListenerInfo struct {
 	VirtualHosts           []string                   `json:"virtual_hosts"`
 }
 
+// ListListenersOutput specifies the Listeners obtained from a List request
+type ListListenersOutput struct {
+	ListInfo
+	Listeners []ListenerInfo
+}
+
 type CreateListenerInput struct {
 	BalancerProtocol     Protocol      `json:"balancer_protocol"`
 	Disabled             LBaaSDisabled `json:"disabled,omitempty"`
@@ -113,12 +126,7 @@ type UpdateListenerInput struct {
 // CreateListener creates a new listener
 func (c *ListenerClient) CreateListener(lb LoadBalancerContext, input *CreateListenerInput) (*ListenerInfo, error) {
 
-	if c.PollInterval == 0 {
-		c.PollInterval = waitForListenerReadyPollInterval
-	}
-	if c.Timeout == 0 {
-		c.Timeout = waitForListenerReadyTimeout
-	}
+	c = c.withWaitDefaults(waitForListenerReadyPollInterval, waitForListenerReadyTimeout)
 
 	info := &ListenerInfo{}
 	if err := c.createResource(lb.Region, lb.Name, &input, info); err != nil {
@@ -147,12 +155,7 @@ func (c *ListenerClient) CreateListener(lb LoadBalancerContext, input *CreateLis
 // DeleteListener deletes the listener with the specified input
 func (c *ListenerClient) DeleteListener(lb LoadBalancerContext, name string) (*ListenerInfo, error) {
 
-	if c.PollInterval == 0 {
-		c.PollInterval = waitForListenerDeletePollInterval
-	}
-	if c.Timeout == 0 {
-		c.Timeout = waitForListenerDeleteTimeout
-	}
+	c = c.withWaitDefaults(waitForListenerDeletePollInterval, waitForListenerDeleteTimeout)
 
 	info := &ListenerInfo{}
 	if err := c.deleteResource(lb.Region, lb.Name, name, info); err != nil {
@@ -189,15 +192,21 @@ func (c *ListenerClient) GetListener(lb LoadBalancerContext, name string) (*List
 	return info, nil
 }
 
+// ListListeners lists the Listeners of the Load Balancer
+func (c *ListenerClient) ListListeners(lb LoadBalancerContext, input *ListInput) (*ListListenersOutput, error) {
+	var output ListListenersOutput
+	info, err := c.listResources(lb.Region, lb.Name, input, &output.Listeners)
+	if err != nil {
+		return nil, err
+	}
+	output.ListInfo = *info
+	return &output, nil
+}
+
 // UpdateListener updated the listener
 func (c *ListenerClient) UpdateListener(lb LoadBalancerContext, name string, input *UpdateListenerInput) (*ListenerInfo, error) {
 
-	if c.PollInterval == 0 {
-		c.PollInterval = waitForListenerReadyPollInterval
-	}
-	if c.Timeout == 0 {
-		c.Timeout = waitForListenerReadyTimeout
-	}
+	c = c.withWaitDefaults(waitForListenerReadyPollInterval, waitForListenerReadyTimeout)
 
 	info := &ListenerInfo{}
 	if err := c.updateResource(lb.Region, lb.Name, name, &input, info); err != nil {
@@ -122,6 +122,20 @@ type UpdateLoadBalancerInput struct {
 	Tags               *[]string     `json:"tags,omitempty"`
 }
 
+// ListLoadBalancersInput specifies the Load Balancers to list
+type ListLoadBalancersInput struct {
+	// List only the Load Balancers in the region, rather than in all regions
+	// Optional
+	Region string
+	ListInput
+}
+
+// ListLoadBalancersOutput specifies the Load Balancers obtained from a List request
+type ListLoadBalancersOutput struct {
+	ListInfo
+	LoadBalancers []LoadBalancerInfo
+}
+
 // LoadBalancerContext represents a specific loadbalancer instance by region/name context
 type LoadBalancerContext struct {
 	Region string
@@ -131,12 +145,7 @@ type LoadBalancerContext struct {
 // CreateLoadBalancer creates a new Load Balancer instance
 func (c *LoadBalancerClient) CreateLoadBalancer(input *CreateLoadBalancerInput) (*LoadBalancerInfo, error) {
 
-	if c.PollInterval == 0 {
-		c.PollInterval = waitForLoadBalancerReadyPollInterval
-	}
-	if c.Timeout == 0 {
-		c.Timeout = waitForLoadBalancerReadyTimeout
-	}
+	c = c.withWaitDefaults(waitForLoadBalancerReadyPollInterval, waitForLoadBalancerReadyTimeout)
 
 	var info LoadBalancerInfo
 	if err := c.createResource(&input, &info); err != nil {
@@ -167,12 +176,7 @@ func (c *LoadBalancerClient) CreateLoadBalancer(input *CreateLoadBalancerInput)
 // DeleteLoadBalancer deletes the service instance with the specified input
 func (c *LoadBalancerClient) DeleteLoadBalancer(lb LoadBalancerContext) (*LoadBalancerInfo, error) {
 
-	if c.PollInterval == 0 {
-		c.PollInterval = waitForLoadBalancerDeletePollInterval
-	}
-	if c.Timeout == 0 {
-		c.Timeout = waitForLoadBalancerDeleteTimeout
-	}
+	c = c.withWaitDefaults(waitForLoadBalancerDeletePollInterval, waitForLoadBalancerDeleteTimeout)
 
 	var info LoadBalancerInfo
 	if err := c.deleteResource(lb.Region, lb.Name, &info); err != nil {
@@ -209,15 +213,29 @@ func (c *LoadBalancerClient) GetLoadBalancer(lb LoadBalancerContext) (*LoadBalan
 	return &info, nil
 }
 
+// ListLoadBalancers lists the Load Balancers, either in all regions or in a single region
+func (c *LoadBalancerClient) ListLoadBalancers(input *ListLoadBalancersInput) (*ListLoadBalancersOutput, error) {
+	if input == nil {
+		input = &ListLoadBalancersInput{}
+	}
+	path := c.ContainerPath
+	if input.Region != "" {
+		path = fmt.Sprintf(loadBalancerRegionPath, input.Region)
+	}
+
+	var output ListLoadBalancersOutput
+	info, err := c.listResources(path, c.Accept, &input.ListInput, &output.LoadBalancers)
+	if err != nil {
+		return nil, err
+	}
+	output.ListInfo = *info
+	return &output, nil
+}
+
 // UpdateLoadBalancer fetchs the instance details of the Load Balancer
 func (c *LoadBalancerClient) UpdateLoadBalancer(lb LoadBalancerContext, input *UpdateLoadBalancerInput) (*LoadBalancerInfo, error) {
 
-	if c.PollInterval == 0 {
-		c.PollInterval = waitForLoadBalancerReadyPollInterval
-	}
-	if c.Timeout == 0 {
-		c.Timeout = waitForLoadBalancerReadyTimeout
-	}
+	c = c.withWaitDefaults(waitForLoadBalancerReadyPollInterval, waitForLoadBalancerReadyTimeout)
 
 	var info LoadBalancerInfo
 	if err := c.updateResource(lb.Region, lb.Name, &input, &info); err != nil {
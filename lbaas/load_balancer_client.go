@@ -1,9 +1,13 @@
 package lbaas
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 const (
 	loadBalancerContainerPath = "/vlbrs"
+	loadBalancerRegionPath    = "/vlbrs/%s"
 	loadBalancerResourcePath  = "/vlbrs/%s/%s/"
 )
 
@@ -31,6 +35,13 @@ func (c *Client) LoadBalancerClient() *LoadBalancerClient {
 	}
 }
 
+// withWaitDefaults returns a copy of the client with the given poll interval and timeout, see Client.withWaitDefaults
+func (c *LoadBalancerClient) withWaitDefaults(pollInterval, timeout time.Duration) *LoadBalancerClient {
+	c2 := *c
+	c2.Client = c.Client.withWaitDefaults(pollInterval, timeout)
+	return &c2
+}
+
 func (c *LoadBalancerClient) getObjectPath(root, region, name string) string {
 	return fmt.Sprintf(root, region, name)
 }
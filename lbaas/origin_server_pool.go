@@ -41,6 +41,13 @@ func (c *Client) OriginServerPoolClient() *OriginServerPoolClient {
 	return OriginServerPoolClient
 }
 
+// withWaitDefaults returns a copy of the client with the given poll interval and timeout, see Client.withWaitDefaults
+func (c *OriginServerPoolClient) withWaitDefaults(pollInterval, timeout time.Duration) *OriginServerPoolClient {
+	c2 := *c
+	c2.Client = c.Client.withWaitDefaults(pollInterval, timeout)
+	return &c2
+}
+
 type OriginServerInfo struct {
 	Hostname string        `json:"hostname"`
 	Port     int           `json:"port"`
@@ -78,6 +85,12 @@ type OriginServerPoolInfo struct {
 	VnicSetName        string             `json:"vnic_set_name"`
 }
 
+// ListOriginServerPoolsOutput specifies the Origin Server Pools obtained from a List request
+type ListOriginServerPoolsOutput struct {
+	ListInfo
+	OriginServerPools []OriginServerPoolInfo
+}
+
 type CreateOriginServerPoolInput struct {
 	Name          string                    `json:"name"`
 	OriginServers []CreateOriginServerInput `json:"origin_servers,omitempty"`
@@ -100,12 +113,7 @@ type UpdateOriginServerPoolInput struct {
 // CreateOriginServerPool creates a new server pool
 func (c *OriginServerPoolClient) CreateOriginServerPool(lb LoadBalancerContext, input *CreateOriginServerPoolInput) (*OriginServerPoolInfo, error) {
 
-	if c.PollInterval == 0 {
-		c.PollInterval = waitForOriginServerPoolReadyPollInterval
-	}
-	if c.Timeout == 0 {
-		c.Timeout = waitForOriginServerPoolReadyTimeout
-	}
+	c = c.withWaitDefaults(waitForOriginServerPoolReadyPollInterval, waitForOriginServerPoolReadyTimeout)
 
 	info := &OriginServerPoolInfo{}
 	if err := c.createResource(lb.Region, lb.Name, &input, info); err != nil {
@@ -134,12 +142,7 @@ func (c *OriginServerPoolClient) CreateOriginServerPool(lb LoadBalancerContext,
 // DeleteOriginServerPool deletes the server pool with the specified input
 func (c *OriginServerPoolClient) DeleteOriginServerPool(lb LoadBalancerContext, name string) (*OriginServerPoolInfo, error) {
 
-	if c.PollInterval == 0 {
-		c.PollInterval = waitForOriginServerPoolDeletePollInterval
-	}
-	if c.Timeout == 0 {
-		c.Timeout = waitForOriginServerPoolDeleteTimeout
-	}
+	c = c.withWaitDefaults(waitForOriginServerPoolDeletePollInterval, waitForOriginServerPoolDeleteTimeout)
 
 	info := &OriginServerPoolInfo{}
 	if err := c.deleteResource(lb.Region, lb.Name, name, info); err != nil {
@@ -177,15 +180,21 @@ func (c *OriginServerPoolClient) GetOriginServerPool(lb LoadBalancerContext, nam
 	return info, nil
 }
 
+// ListOriginServerPools lists the Origin Server Pools of the Load Balancer
+func (c *OriginServerPoolClient) ListOriginServerPools(lb LoadBalancerContext, input *ListInput) (*ListOriginServerPoolsOutput, error) {
+	var output ListOriginServerPoolsOutput
+	info, err := c.listResources(lb.Region, lb.Name, input, &output.OriginServerPools)
+	if err != nil {
+		return nil, err
+	}
+	output.ListInfo = *info
+	return &output, nil
+}
+
 // UpdateOriginServerPool fetchs the server pool details
 func (c *OriginServerPoolClient) UpdateOriginServerPool(lb LoadBalancerContext, name string, input *UpdateOriginServerPoolInput) (*OriginServerPoolInfo, error) {
 
-	if c.PollInterval == 0 {
-		c.PollInterval = waitForOriginServerPoolReadyPollInterval
-	}
-	if c.Timeout == 0 {
-		c.Timeout = waitForOriginServerPoolReadyTimeout
-	}
+	c = c.withWaitDefaults(waitForOriginServerPoolReadyPollInterval, waitForOriginServerPoolReadyTimeout)
 
 	info := &OriginServerPoolInfo{}
 	if err := c.updateOriginServerPool(lb.Region, lb.Name, name, &input, info); err != nil {
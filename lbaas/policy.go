@@ -63,6 +63,13 @@ func (c *Client) PolicyClient() *PolicyClient {
 	}
 }
 
+// withWaitDefaults returns a copy of the client with the given poll interval and timeout, see Client.withWaitDefaults
+func (c *PolicyClient) withWaitDefaults(pollInterval, timeout time.Duration) *PolicyClient {
+	c2 := *c
+	c2.Client = c.Client.withWaitDefaults(pollInterval, timeout)
+	return &c2
+}
+
 type PolicyInfo struct {
 	Name  string     `json:"name,omitempty"`
 	State LBaaSState `json:"state,omitempty"`
@@ -120,6 +127,12 @@ type PolicyInfo struct {
 	TrustedCertificate string `json:"cert,omitempty"`
 }
 
+// ListPoliciesOutput specifies the Policies obtained from a List request
+type ListPoliciesOutput struct {
+	ListInfo
+	Policies []PolicyInfo
+}
+
 type CreatePolicyInput struct {
 	Name string `json:"name,omitempty"`
 	Type string `json:"type,omitempty"`
@@ -229,12 +242,7 @@ type TrustedCertificatePolicyInfo struct {
 // CreatePolicy creates a new listener
 func (c *PolicyClient) CreatePolicy(lb LoadBalancerContext, input *CreatePolicyInput) (*PolicyInfo, error) {
 
-	if c.PollInterval == 0 {
-		c.PollInterval = waitForPolicyReadyPollInterval
-	}
-	if c.Timeout == 0 {
-		c.Timeout = waitForPolicyReadyTimeout
-	}
+	c = c.withWaitDefaults(waitForPolicyReadyPollInterval, waitForPolicyReadyTimeout)
 
 	info := &PolicyInfo{}
 	// set the content type based on the policy type
@@ -268,12 +276,7 @@ func (c *PolicyClient) CreatePolicy(lb LoadBalancerContext, input *CreatePolicyI
 // DeletePolicy deletes the listener with the specified input
 func (c *PolicyClient) DeletePolicy(lb LoadBalancerContext, name string) (*PolicyInfo, error) {
 
-	if c.PollInterval == 0 {
-		c.PollInterval = waitForPolicyDeletePollInterval
-	}
-	if c.Timeout == 0 {
-		c.Timeout = waitForPolicyDeleteTimeout
-	}
+	c = c.withWaitDefaults(waitForPolicyDeletePollInterval, waitForPolicyDeleteTimeout)
 
 	info := &PolicyInfo{}
 	if err := c.deleteResource(lb.Region, lb.Name, name, info); err != nil {
@@ -310,15 +313,21 @@ func (c *PolicyClient) GetPolicy(lb LoadBalancerContext, name string) (*PolicyIn
 	return &info, nil
 }
 
+// ListPolicies lists the Policies of the Load Balancer
+func (c *PolicyClient) ListPolicies(lb LoadBalancerContext, input *ListInput) (*ListPoliciesOutput, error) {
+	var output ListPoliciesOutput
+	info, err := c.listResources(lb.Region, lb.Name, input, &output.Policies)
+	if err != nil {
+		return nil, err
+	}
+	output.ListInfo = *info
+	return &output, nil
+}
+
 // GetPolicy fetchs the listener details
 func (c *PolicyClient) UpdatePolicy(lb LoadBalancerContext, name, policyType string, input *UpdatePolicyInput) (*PolicyInfo, error) {
 
-	if c.PollInterval == 0 {
-		c.PollInterval = waitForPolicyReadyPollInterval
-	}
-	if c.Timeout == 0 {
-		c.Timeout = waitForPolicyReadyTimeout
-	}
+	c = c.withWaitDefaults(waitForPolicyReadyPollInterval, waitForPolicyReadyTimeout)
 
 	c.ContentType = c.getContentTypeForPolicyType(policyType)
 	info := &PolicyInfo{}
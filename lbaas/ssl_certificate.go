@@ -1,7 +1,12 @@
 package lbaas
 
 import (
+	"bytes"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-oracle-terraform/client"
@@ -19,6 +24,18 @@ type SSLCertificateInfo struct {
 	State            LBaaSState `json:"state"`
 	Trusted          bool       `json:"trusted"`
 	URI              string     `json:"uri"`
+
+	// Details parsed from the PEM encoded Certificate, if it could be parsed
+	CommonName              string    `json:"-"`
+	NotBefore               time.Time `json:"-"`
+	Expires                 time.Time `json:"-"`
+	SubjectAlternativeNames []string  `json:"-"`
+}
+
+// ListSSLCertificatesOutput specifies the SSL Certificates obtained from a List request
+type ListSSLCertificatesOutput struct {
+	ListInfo
+	SSLCertificates []SSLCertificateInfo
 }
 
 type CreateSSLCertificateInput struct {
@@ -33,11 +50,15 @@ type UpdateSSLCertificateInput struct {
 	Name        string `json:"name"`
 	Certificate string `json:"certificate"`
 	PrivateKey  string `json:"private_key,omitempty"`
+	// Set if the certificate being updated is a trusted certificate
+	Trusted bool `json:"-"`
 }
 
 // CreateSSLCertificate creates a new SSL certificate
 func (c *SSLCertificateClient) CreateSSLCertificate(input *CreateSSLCertificateInput) (*SSLCertificateInfo, error) {
 
+	c = c.withWaitDefaults(waitForSSLCertificateReadyPollInterval, waitForSSLCertificateReadyTimeout)
+
 	if input.Trusted {
 		c.ContentType = ContentTypeTrustedCertificateJSON
 	} else {
@@ -58,22 +79,19 @@ func (c *SSLCertificateClient) CreateSSLCertificate(input *CreateSSLCertificateI
 		return nil, err
 	}
 	if ready {
+		info.parseCertificate()
 		return &info, nil
 	}
 	// else poll till ready
 	err = c.WaitForSSLCertificateState(input.Name, createdStates, erroredStates, c.PollInterval, c.Timeout, &info)
+	info.parseCertificate()
 	return &info, err
 }
 
 // DeleteSSLCertificate deletes the SSL certificate with the specified name
 func (c *SSLCertificateClient) DeleteSSLCertificate(name string) (*SSLCertificateInfo, error) {
 
-	if c.PollInterval == 0 {
-		c.PollInterval = waitForSSLCertificateDeletePollInterval
-	}
-	if c.Timeout == 0 {
-		c.Timeout = waitForSSLCertificateDeleteTimeout
-	}
+	c = c.withWaitDefaults(waitForSSLCertificateDeletePollInterval, waitForSSLCertificateDeleteTimeout)
 
 	var info SSLCertificateInfo
 	if err := c.deleteResource(name, &info); err != nil {
@@ -106,18 +124,67 @@ func (c *SSLCertificateClient) GetSSLCertificate(name string) (*SSLCertificateIn
 	if err := c.getResource(name, &info); err != nil {
 		return nil, err
 	}
+	info.parseCertificate()
 	return &info, nil
 }
 
+// ListSSLCertificates lists the Server and Trusted SSL Certificates
+func (c *SSLCertificateClient) ListSSLCertificates(input *ListInput) (*ListSSLCertificatesOutput, error) {
+	var output ListSSLCertificatesOutput
+	info, err := c.listResources(c.ContainerPath, c.Accept, input, &output.SSLCertificates)
+	if err != nil {
+		return nil, err
+	}
+	for i := range output.SSLCertificates {
+		output.SSLCertificates[i].parseCertificate()
+	}
+	output.ListInfo = *info
+	return &output, nil
+}
+
+// UpdateSSLCertificate replaces the certificate and private key of an existing SSL Certificate
+func (c *SSLCertificateClient) UpdateSSLCertificate(name string, input *UpdateSSLCertificateInput) (*SSLCertificateInfo, error) {
+
+	c = c.withWaitDefaults(waitForSSLCertificateReadyPollInterval, waitForSSLCertificateReadyTimeout)
+
+	if input.Trusted {
+		c.ContentType = ContentTypeTrustedCertificateJSON
+	} else {
+		c.ContentType = ContentTypeServerCertificateJSON
+	}
+
+	var info SSLCertificateInfo
+	if err := c.updateResource(name, &input, &info); err != nil {
+		return nil, err
+	}
+
+	updatedStates := []LBaaSState{LBaaSStateCreated, LBaaSStateHealthy}
+	erroredStates := []LBaaSState{LBaaSStateModificaitonFailed, LBaaSStateDeletionInProgress, LBaaSStateDeleted, LBaaSStateDeletionFailed, LBaaSStateAbandon, LBaaSStateAutoAbandoned}
+
+	// check the initial response
+	ready, err := c.checkSSLCertificateState(&info, updatedStates, erroredStates)
+	if err != nil {
+		return nil, err
+	}
+	if ready {
+		info.parseCertificate()
+		return &info, nil
+	}
+	// else poll till ready
+	err = c.WaitForSSLCertificateState(name, updatedStates, erroredStates, c.PollInterval, c.Timeout, &info)
+	info.parseCertificate()
+	return &info, err
+}
+
 // WaitForSSLCertificateState waits for the resource to be in one of a set of desired states
 func (c *SSLCertificateClient) WaitForSSLCertificateState(name string, desiredStates, errorStates []LBaaSState, pollInterval, timeoutSeconds time.Duration, info *SSLCertificateInfo) error {
 
-	var getErr error
 	err := c.client.WaitFor("SSL Certificate status update", pollInterval, timeoutSeconds, func() (bool, error) {
-		info, getErr = c.GetSSLCertificate(name)
+		current, getErr := c.GetSSLCertificate(name)
 		if getErr != nil {
 			return false, getErr
 		}
+		*info = *current
 
 		return c.checkSSLCertificateState(info, desiredStates, errorStates)
 	})
@@ -142,3 +209,48 @@ func (c *SSLCertificateClient) checkSSLCertificateState(info *SSLCertificateInfo
 	// not ready lifecycleTimeout
 	return false, nil
 }
+
+// ParseSSLCertificate decodes the first certificate in a PEM encoded certificate, which
+// may itself be base64 encoded as accepted by the Load Balancer API
+func ParseSSLCertificate(certificate string) (*x509.Certificate, error) {
+	data := []byte(strings.TrimSpace(certificate))
+	if !bytes.HasPrefix(data, []byte("-----BEGIN")) {
+		decoded, err := base64.StdEncoding.DecodeString(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("Certificate is neither PEM nor base64 encoded PEM: %s", err)
+		}
+		data = decoded
+	}
+
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("No PEM encoded certificate found")
+		}
+		if block.Type == "CERTIFICATE" {
+			return x509.ParseCertificate(block.Bytes)
+		}
+	}
+}
+
+// parseCertificate sets the details parsed from the certificate, leaving them unset if
+// the certificate can't be parsed
+func (info *SSLCertificateInfo) parseCertificate() {
+	cert, err := ParseSSLCertificate(info.Certificate)
+	if err != nil {
+		return
+	}
+
+	info.CommonName = cert.Subject.CommonName
+	info.NotBefore = cert.NotBefore
+	info.Expires = cert.NotAfter
+	info.SubjectAlternativeNames = append([]string{}, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		info.SubjectAlternativeNames = append(info.SubjectAlternativeNames, ip.String())
+	}
+	info.SubjectAlternativeNames = append(info.SubjectAlternativeNames, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		info.SubjectAlternativeNames = append(info.SubjectAlternativeNames, uri.String())
+	}
+}
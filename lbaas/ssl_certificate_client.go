@@ -3,6 +3,7 @@ package lbaas
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 const (
@@ -39,6 +40,13 @@ func (c *Client) SSLCertificateClient() *SSLCertificateClient {
 	}
 }
 
+// withWaitDefaults returns a copy of the client with the given poll interval and timeout, see Client.withWaitDefaults
+func (c *SSLCertificateClient) withWaitDefaults(pollInterval, timeout time.Duration) *SSLCertificateClient {
+	c2 := *c
+	c2.Client = c.Client.withWaitDefaults(pollInterval, timeout)
+	return &c2
+}
+
 func (c *SSLCertificateClient) getObjectPath(root, name string) string {
 	return fmt.Sprintf(root, name)
 }
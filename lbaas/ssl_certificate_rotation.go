@@ -0,0 +1,125 @@
+package lbaas
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RotateSSLCertificateInput specifies the replacement of an SSL certificate used by the
+// listeners of a load balancer
+type RotateSSLCertificateInput struct {
+	// The load balancer whose listeners are repointed to the new certificate
+	// Required
+	LoadBalancer LoadBalancerContext
+	// Name of the certificate being replaced
+	// Required
+	OldCertificate string
+	// The new certificate to upload
+	// Required
+	NewCertificate *CreateSSLCertificateInput
+	// Keep the old certificate rather than deleting it once no listener references it,
+	// e.g. when it is still used by the listeners of other load balancers
+	// Optional
+	RetainOldCertificate bool
+}
+
+// RotateSSLCertificateOutput describes the outcome of a certificate rotation
+type RotateSSLCertificateOutput struct {
+	// The new certificate
+	Certificate *SSLCertificateInfo
+	// Names of the listeners which were repointed to the new certificate
+	UpdatedListeners []string
+	// Whether the old certificate was deleted
+	OldCertificateDeleted bool
+}
+
+// RotateSSLCertificate uploads a new certificate, repoints every listener of the load balancer
+// which references the old certificate to the new one, waits for the load balancer to be
+// healthy again and then deletes the old certificate.
+// The load balancer and listeners are waited for using the PollInterval and Timeout of the client, if set.
+// If the rotation fails part way, the output describes the changes made before the failure.
+func (c *SSLCertificateClient) RotateSSLCertificate(input *RotateSSLCertificateInput) (*RotateSSLCertificateOutput, error) {
+	if input.NewCertificate == nil {
+		return nil, fmt.Errorf("NewCertificate must be set")
+	}
+
+	oldCert, err := c.GetSSLCertificate(input.OldCertificate)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting SSL Certificate %s: %s", input.OldCertificate, err)
+	}
+
+	output := &RotateSSLCertificateOutput{}
+	newCert, err := c.CreateSSLCertificate(input.NewCertificate)
+	if err != nil {
+		return output, fmt.Errorf("Error creating SSL Certificate %s: %s", input.NewCertificate.Name, err)
+	}
+	output.Certificate = newCert
+
+	lbClient := c.Client.LoadBalancerClient().withWaitDefaults(waitForLoadBalancerReadyPollInterval, waitForLoadBalancerReadyTimeout)
+	lbInfo, err := lbClient.GetLoadBalancer(input.LoadBalancer)
+	if err != nil {
+		return output, fmt.Errorf("Error getting Load Balancer %s/%s: %s", input.LoadBalancer.Region, input.LoadBalancer.Name, err)
+	}
+
+	listenerClient := c.Client.ListenerClient()
+	for _, l := range lbInfo.Listeners {
+		listener, err := listenerClient.GetListener(input.LoadBalancer, l.Name)
+		if err != nil {
+			return output, fmt.Errorf("Error getting Listener %s: %s", l.Name, err)
+		}
+
+		sslCerts, replaced := replaceSSLCertificate(listener.SSLCerts, oldCert, newCert)
+		if !replaced {
+			continue
+		}
+		_, err = listenerClient.UpdateListener(input.LoadBalancer, listener.Name, &UpdateListenerInput{
+			Name:     listener.Name,
+			SSLCerts: &sslCerts,
+		})
+		if err != nil {
+			return output, fmt.Errorf("Error updating Listener %s to use SSL Certificate %s: %s", listener.Name, newCert.Name, err)
+		}
+		output.UpdatedListeners = append(output.UpdatedListeners, listener.Name)
+	}
+
+	healthyStates := []LBaaSState{LBaaSStateHealthy}
+	erroredStates := []LBaaSState{LBaaSStateModificaitonFailed, LBaaSStateAbandon, LBaaSStateAutoAbandoned, LBaaSStateAccessDenied, LBaaSStateAdministratorInterventionNeeded}
+	if healthy, _ := lbClient.checkLoadBalancerState(lbInfo, healthyStates, erroredStates); !healthy || len(output.UpdatedListeners) > 0 {
+		if err := lbClient.WaitForLoadBalancerState(input.LoadBalancer, healthyStates, erroredStates, lbInfo); err != nil {
+			return output, fmt.Errorf("Error waiting for Load Balancer %s/%s to be healthy: %s", input.LoadBalancer.Region, input.LoadBalancer.Name, err)
+		}
+	}
+
+	if input.RetainOldCertificate {
+		return output, nil
+	}
+	if _, err := c.DeleteSSLCertificate(oldCert.Name); err != nil {
+		return output, fmt.Errorf("Error deleting SSL Certificate %s: %s", oldCert.Name, err)
+	}
+	output.OldCertificateDeleted = true
+	return output, nil
+}
+
+// replaceSSLCertificate replaces the references to the old certificate, by name or URI,
+// with the same kind of reference to the new certificate
+func replaceSSLCertificate(sslCerts []string, oldCert, newCert *SSLCertificateInfo) ([]string, bool) {
+	replaced := false
+	result := make([]string, 0, len(sslCerts))
+	for _, ref := range sslCerts {
+		switch {
+		case ref == oldCert.Name:
+			result = append(result, newCert.Name)
+			replaced = true
+		case ref == oldCert.URI || strings.HasSuffix(ref, "/certs/"+oldCert.Name):
+			if newCert.URI != "" {
+				result = append(result, newCert.URI)
+			} else {
+				result = append(result, strings.TrimSuffix(ref, oldCert.Name)+newCert.Name)
+			}
+			replaced = true
+		default:
+			result = append(result, ref)
+		}
+	}
+	return result, replaced
+}
@@ -0,0 +1,247 @@
+package lbaas
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func generateTestCertificate(t *testing.T, notAfter time.Time) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "www.example.com"},
+		NotBefore:    notAfter.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+		DNSNames:     []string{"www.example.com", "example.com"},
+		IPAddresses:  []net.IP{net.ParseIP("10.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestParseSSLCertificate(t *testing.T) {
+	notAfter := time.Now().Add(90 * 24 * time.Hour).UTC().Truncate(time.Second)
+	certPEM := generateTestCertificate(t, notAfter)
+
+	for _, certificate := range []string{certPEM, base64.StdEncoding.EncodeToString([]byte(certPEM))} {
+		info := &SSLCertificateInfo{Certificate: certificate}
+		info.parseCertificate()
+
+		assert.Equal(t, "www.example.com", info.CommonName)
+		assert.True(t, notAfter.Equal(info.Expires), "Expiry should be %s, got %s", notAfter, info.Expires)
+		assert.Equal(t, []string{"www.example.com", "example.com", "10.0.0.1"}, info.SubjectAlternativeNames)
+	}
+
+	_, err := ParseSSLCertificate("not a certificate")
+	assert.Error(t, err)
+
+	info := &SSLCertificateInfo{Certificate: "not a certificate"}
+	info.parseCertificate()
+	assert.True(t, info.Expires.IsZero(), "Expiry should be unset for an invalid certificate")
+}
+
+func TestReplaceSSLCertificate(t *testing.T) {
+	oldCert := &SSLCertificateInfo{Name: "old-cert", URI: "https://lbaas.example.com/vlbrs/certs/old-cert"}
+	newCert := &SSLCertificateInfo{Name: "new-cert", URI: "https://lbaas.example.com/vlbrs/certs/new-cert"}
+
+	sslCerts, replaced := replaceSSLCertificate([]string{"old-cert", "other-cert"}, oldCert, newCert)
+	assert.True(t, replaced)
+	assert.Equal(t, []string{"new-cert", "other-cert"}, sslCerts)
+
+	sslCerts, replaced = replaceSSLCertificate([]string{"/vlbrs/certs/old-cert"}, oldCert, newCert)
+	assert.True(t, replaced)
+	assert.Equal(t, []string{newCert.URI}, sslCerts)
+
+	sslCerts, replaced = replaceSSLCertificate([]string{"other-cert"}, oldCert, newCert)
+	assert.False(t, replaced)
+	assert.Equal(t, []string{"other-cert"}, sslCerts)
+}
+
+const _TestRotationCertsURI = "https://lbaas.example.com/vlbrs/certs/"
+
+var _TestRotationLoadBalancer = LoadBalancerContext{Region: "uscom-central-1", Name: "lb1"}
+
+// rotationTestServer fakes the certificates and the listeners of a single load balancer
+type rotationTestServer struct {
+	mu        sync.Mutex
+	certs     map[string]bool
+	listeners map[string][]string
+	// The state of the load balancer
+	state LBaaSState
+	// The listener whose update fails
+	failListener string
+	// The requests received, by method and path
+	requests []string
+}
+
+func newRotationTestServer(t *testing.T) (*SSLCertificateClient, *rotationTestServer) {
+	s := &rotationTestServer{
+		certs: map[string]bool{"old-cert": true},
+		listeners: map[string][]string{
+			"by-name": {"old-cert"},
+			"by-uri":  {_TestRotationCertsURI + "old-cert"},
+			"other":   {"other-cert"},
+		},
+		state: LBaaSStateHealthy,
+	}
+	client := getStubTestClient(t, s.handle)
+	client.PollInterval = 10 * time.Millisecond
+	client.Timeout = time.Second
+	return client.SSLCertificateClient(), s
+}
+
+func (s *rotationTestServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+
+	const lbPath = "/vlbrs/uscom-central-1/lb1/"
+	switch {
+	case r.URL.Path == "/certs" && r.Method == "POST":
+		var input CreateSSLCertificateInput
+		json.NewDecoder(r.Body).Decode(&input)
+		s.certs[input.Name] = true
+		json.NewEncoder(w).Encode(map[string]interface{}{"name": input.Name, "uri": _TestRotationCertsURI + input.Name, "state": LBaaSStateHealthy})
+	case strings.HasPrefix(r.URL.Path, "/certs/"):
+		name := strings.TrimPrefix(r.URL.Path, "/certs/")
+		if !s.certs[name] {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		state := LBaaSStateHealthy
+		if r.Method == "DELETE" {
+			delete(s.certs, name)
+			state = LBaaSStateDeleted
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"name": name, "uri": _TestRotationCertsURI + name, "state": state})
+	case r.URL.Path == lbPath:
+		// The listeners are listed in a fixed order, so a failure part way is repeatable
+		listeners := []map[string]string{}
+		for _, name := range []string{"by-name", "by-uri", "other"} {
+			listeners = append(listeners, map[string]string{"name": name})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"name": "lb1", "state": s.state, "listeners": listeners})
+	case strings.HasPrefix(r.URL.Path, lbPath+"listeners/"):
+		name := strings.TrimPrefix(r.URL.Path, lbPath+"listeners/")
+		if r.Method == "PUT" {
+			if name == s.failListener {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			var input UpdateListenerInput
+			json.NewDecoder(r.Body).Decode(&input)
+			s.listeners[name] = *input.SSLCerts
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"name": name, "ssl_cert": s.listeners[name], "state": LBaaSStateHealthy})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *rotationTestServer) requested(method, path string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, request := range s.requests {
+		if request == method+" "+path {
+			return true
+		}
+	}
+	return false
+}
+
+func rotateTestCertificate(client *SSLCertificateClient, retain bool) (*RotateSSLCertificateOutput, error) {
+	return client.RotateSSLCertificate(&RotateSSLCertificateInput{
+		LoadBalancer:         _TestRotationLoadBalancer,
+		OldCertificate:       "old-cert",
+		NewCertificate:       &CreateSSLCertificateInput{Name: "new-cert", Certificate: "cert"},
+		RetainOldCertificate: retain,
+	})
+}
+
+func TestRotateSSLCertificate(t *testing.T) {
+	client, server := newRotationTestServer(t)
+
+	output, err := rotateTestCertificate(client, false)
+	if err != nil {
+		t.Fatalf("Error rotating SSL certificate: %s", err)
+	}
+
+	assert.Equal(t, "new-cert", output.Certificate.Name)
+	assert.Equal(t, []string{"by-name", "by-uri"}, output.UpdatedListeners)
+	assert.True(t, output.OldCertificateDeleted)
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	assert.True(t, server.certs["new-cert"], "Expected the new certificate to be created")
+	assert.False(t, server.certs["old-cert"], "Expected the old certificate to be deleted")
+	assert.Equal(t, map[string][]string{
+		"by-name": {"new-cert"},
+		"by-uri":  {_TestRotationCertsURI + "new-cert"},
+		"other":   {"other-cert"},
+	}, server.listeners)
+	for _, request := range server.requests {
+		assert.NotEqual(t, "PUT /vlbrs/uscom-central-1/lb1/listeners/other", request, "Expected only the listeners using the old certificate to be updated")
+	}
+}
+
+func TestRotateSSLCertificate_RetainOldCertificate(t *testing.T) {
+	client, server := newRotationTestServer(t)
+
+	output, err := rotateTestCertificate(client, true)
+	if err != nil {
+		t.Fatalf("Error rotating SSL certificate: %s", err)
+	}
+
+	assert.Equal(t, []string{"by-name", "by-uri"}, output.UpdatedListeners)
+	assert.False(t, output.OldCertificateDeleted)
+	assert.False(t, server.requested("DELETE", "/certs/old-cert"), "Expected the old certificate to be retained")
+}
+
+func TestRotateSSLCertificate_ListenerUpdateFails(t *testing.T) {
+	client, server := newRotationTestServer(t)
+	server.failListener = "by-uri"
+
+	output, err := rotateTestCertificate(client, false)
+	if err == nil {
+		t.Fatal("Expected an error updating the listener")
+	}
+
+	assert.Equal(t, []string{"by-name"}, output.UpdatedListeners, "Expected the listeners updated before the failure")
+	assert.False(t, output.OldCertificateDeleted)
+	assert.False(t, server.requested("DELETE", "/certs/old-cert"), "Expected the old certificate to be kept")
+}
+
+func TestRotateSSLCertificate_WaitFails(t *testing.T) {
+	client, server := newRotationTestServer(t)
+	server.state = LBaaSStateModificaitonFailed
+
+	output, err := rotateTestCertificate(client, false)
+	if err == nil || !strings.Contains(err.Error(), "Error waiting for Load Balancer") {
+		t.Fatalf("Expected an error waiting for the load balancer, got %v", err)
+	}
+
+	assert.Equal(t, []string{"by-name", "by-uri"}, output.UpdatedListeners, "Expected the listeners updated before the failure")
+	assert.False(t, output.OldCertificateDeleted)
+	assert.False(t, server.requested("DELETE", "/certs/old-cert"), "Expected the old certificate to be kept")
+}
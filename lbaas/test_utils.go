@@ -3,6 +3,7 @@ package lbaas
 import (
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"strings"
@@ -46,6 +47,28 @@ func GetTestClient(c *opc.Config) (*Client, error) {
 	return NewClient(c)
 }
 
+// getStubTestClient returns a client for a test server with the handler, which is closed when the test completes
+func getStubTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	endpoint, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := GetTestClient(&opc.Config{
+		IdentityDomain: opc.String("test-domain"),
+		Username:       opc.String("user"),
+		Password:       opc.String("password"),
+		APIEndpoint:    endpoint,
+		HTTPClient:     server.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+	return client
+}
+
 func getLoadBalancerClient() (*LoadBalancerClient, error) {
 	client, err := GetTestClient(&opc.Config{})
 	if err != nil {
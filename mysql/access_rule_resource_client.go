@@ -23,6 +23,15 @@ type AccessRulesResourceClient struct {
 	ServiceInstanceID   string
 }
 
+// forServiceInstance returns a copy of the client addressing the given service instance, or its own if the ID is empty
+func (c *AccessRulesClient) forServiceInstance(id string) *AccessRulesClient {
+	c2 := *c
+	if id != "" {
+		c2.ServiceInstanceID = id
+	}
+	return &c2
+}
+
 func (c *AccessRulesResourceClient) createResource(requestBody interface{}, responseBody interface{}) error {
 
 	var objectPath = c.getContainerPath(c.ContainerPath)
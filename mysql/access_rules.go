@@ -134,9 +134,7 @@ type CreateAccessRuleInput struct {
 // The API returns a http 202 on success.
 func (c *AccessRulesClient) CreateAccessRule(input *CreateAccessRuleInput) error {
 
-	if input.ServiceInstanceID != "" {
-		c.ServiceInstanceID = input.ServiceInstanceID
-	}
+	c = c.forServiceInstance(input.ServiceInstanceID)
 
 	if err := c.createResource(input, nil); err != nil {
 		return err
@@ -176,9 +174,7 @@ type GetAccessRuleInput struct {
 // We make use of the same GetAccessRuleInput, but we ignore the name attribute.
 func (c *AccessRulesClient) GetAllAccessRules(input *GetAccessRuleInput) (*AccessRuleList, error) {
 
-	if input.ServiceInstanceID != "" {
-		c.ServiceInstanceID = input.ServiceInstanceID
-	}
+	c = c.forServiceInstance(input.ServiceInstanceID)
 
 	var accessRules AccessRuleList
 	if err := c.getResource(&accessRules); err != nil {
@@ -195,9 +191,7 @@ func (c *AccessRulesClient) GetAllAccessRules(input *GetAccessRuleInput) (*Acces
 // The method gets the full list and iterates locally for the matching rule name.
 func (c *AccessRulesClient) GetAccessRule(input *GetAccessRuleInput) (*AccessRuleInfo, error) {
 
-	if input.ServiceInstanceID != "" {
-		c.ServiceInstanceID = input.ServiceInstanceID
-	}
+	c = c.forServiceInstance(input.ServiceInstanceID)
 
 	var accessRules AccessRuleList
 	if err := c.getResource(&accessRules); err != nil {
@@ -222,6 +216,7 @@ func (c *AccessRulesClient) GetAccessRule(input *GetAccessRuleInput) (*AccessRul
 // The creation typically takes some time before the rule is available, so we get into a wait loop until
 // the access rule is ready.
 func (c *AccessRulesClient) WaitForAccessRuleReady(input *GetAccessRuleInput, pollInterval time.Duration, timeoutSeconds time.Duration) error {
+	c = c.forServiceInstance(input.ServiceInstanceID)
 
 	err := c.client.WaitFor("access rule to be created.", pollInterval, timeoutSeconds, func() (bool, error) {
 
@@ -288,9 +283,7 @@ type UpdateAccessRuleInput struct {
 // UpdateAccessRule updates an AccessRule with the provided input struct. Returns a fully populated Info struct
 // and any errors encountered
 func (c *AccessRulesClient) UpdateAccessRule(input *UpdateAccessRuleInput) (*AccessRuleInfo, error) {
-	if input.ServiceInstanceID != "" {
-		c.ServiceInstanceID = input.ServiceInstanceID
-	}
+	c = c.forServiceInstance(input.ServiceInstanceID)
 
 	// Since this is strictly an Update call, set the Operation constant
 	input.Operation = AccessRuleUpdate
@@ -325,9 +318,7 @@ type DeleteAccessRuleInput struct {
 
 // DeleteAccessRule deletes an AccessRule with the provided input struct. Returns any errors that occurred.
 func (c *AccessRulesClient) DeleteAccessRule(input *DeleteAccessRuleInput) error {
-	if input.ServiceInstanceID != "" {
-		c.ServiceInstanceID = input.ServiceInstanceID
-	}
+	c = c.forServiceInstance(input.ServiceInstanceID)
 
 	c.client.DebugLogString(fmt.Sprintf("[DEBUG] Deleting AccessRule : %s", input.Name))
 
@@ -369,6 +360,7 @@ func (c *AccessRulesClient) DeleteAccessRule(input *DeleteAccessRuleInput) error
 // WaitForAccessRuleDeleted waits for the access rule to be delete completely. As the operations are asynchronous, we invoke the
 // delete an poll the API to check that the AccessRule is completely removed from the access rule list.
 func (c *AccessRulesClient) WaitForAccessRuleDeleted(input *GetAccessRuleInput, pollInterval time.Duration, timeout time.Duration) (*AccessRuleInfo, error) {
+	c = c.forServiceInstance(input.ServiceInstanceID)
 	var info *AccessRuleInfo
 	//var getErr error
 	err := c.client.WaitFor("access rule to be deleted", pollInterval, timeout, func() (bool, error) {
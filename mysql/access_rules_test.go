@@ -1,8 +1,11 @@
 package mysql
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/rand"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/go-oracle-terraform/helper"
@@ -179,3 +182,37 @@ func destroyAccessRule(t *testing.T, client *AccessRulesClient, serviceInstance,
 		t.Fatalf("Error deleting Access Rule: %s", err)
 	}
 }
+
+// TestAccessRules_ConcurrentServiceInstances gets access rules of several service instances in parallel
+// through a single client, checking each request is sent to the path of its own service instance.
+// Run with -race to check the client isn't modified by its operations.
+func TestAccessRules_ConcurrentServiceInstances(t *testing.T) {
+	const instancesPath = "/paas/api/v1.1/instancemgmt/test-domain/services/MySQLCS/instances/"
+	client := getStubTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		instance := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, instancesPath), "/accessrules")
+		if r.Method != "GET" || instance == r.URL.Path || strings.Contains(instance, "/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"accessRules": []map[string]string{{"ruleName": instance + "-rule"}},
+		})
+	})
+
+	accessRules := client.AccessRules()
+	helper.RunConcurrently(t, 20, func(i int) error {
+		instance := fmt.Sprintf("test-mysql-%d", i)
+		rule, err := accessRules.GetAccessRule(&GetAccessRuleInput{ServiceInstanceID: instance, Name: instance + "-rule"})
+		if err != nil {
+			return err
+		}
+		if rule == nil {
+			return fmt.Errorf("Expected to find the access rule of %s", instance)
+		}
+		return nil
+	})
+
+	if accessRules.ServiceInstanceID != "" {
+		t.Fatalf("Expected the shared client's service instance to be left unset, got %s", accessRules.ServiceInstanceID)
+	}
+}
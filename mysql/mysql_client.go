@@ -1,10 +1,12 @@
 package mysql
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
 	"github.com/hashicorp/go-oracle-terraform/client"
+	"github.com/hashicorp/go-oracle-terraform/jobs"
 	"github.com/hashicorp/go-oracle-terraform/opc"
 )
 
@@ -14,25 +16,40 @@ const CONTENT_TYPE_JSON = "application/json"
 const CONTENT_TYPE_ORA_JSON = "application/vnd.com.oracle.oracloud.provisioning.Service+json"
 
 /** This is the main client that deals with interacting with the OPC MySQL Services. It works with mySQL and mySQL-AccessRules
+ * The client and the resource clients obtained from it can be shared between goroutines, as operations
+ * apply their wait defaults and service instance to a copy of the resource client rather than changing it.
  */
 
 type MySQLClient struct {
 	client            *client.Client
 	ServiceInstanceID string
 	authHeader        *string
+	// Called with the new activity log messages of the jobs waited on by the client
+	// Optional
+	JobProgress jobs.ProgressFunc
 }
 
 func NewMySQLClient(c *opc.Config) (*MySQLClient, error) {
 	mysqlClient := &MySQLClient{}
-	client, err := client.NewClient(c)
+	opcClient, err := client.NewClient(c)
 	if err != nil {
 		return nil, err
 	}
-	mysqlClient.client = client
+	mysqlClient.client = opcClient.WithAuthenticator(&client.BasicAuthenticator{Credentials: opcClient.Credentials()})
 
 	return mysqlClient, nil
 }
 
+// WithContext returns a copy of the MySQL client bound to the given context.
+// Resource clients obtained from the returned client will abort their requests,
+// job polling and WaitFor loops once the context is done.
+func (c *MySQLClient) WithContext(ctx context.Context) *MySQLClient {
+	c2 := new(MySQLClient)
+	*c2 = *c
+	c2.client = c.client.WithContext(ctx)
+	return c2
+}
+
 func (c *MySQLClient) executeRequest(method, path string, body interface{}) (*http.Response, error) {
 
 	resp, err := c.executeRequestWithContentType(method, path, body, CONTENT_TYPE_ORA_JSON)
@@ -62,10 +79,9 @@ func (c *MySQLClient) executeRequestWithContentType(method, path string, body in
 	// Log the request before the authentication header, so as not to leak credentials
 	c.client.DebugLogString(fmt.Sprintf("[DEBUG] : RequestString (%+v)", debugReqString))
 
-	// Set the authentication headers
+	// Set the content type and tenant headers
 	req.Header.Add("Content-Type", contentType)
 	req.Header.Add("Accept", CONTENT_TYPE_JSON)
-	req.SetBasicAuth(*c.client.UserName, *c.client.Password)
 	req.Header.Add(TENANT_HEADER, *c.client.IdentityDomain)
 
 	resp, err := c.client.ExecuteRequest(req)
@@ -45,6 +45,16 @@ func (c *ResourceClient) getResource(instanceName string, responseBody interface
 
 }
 
+// updateResource submits a request to the path relative to the named resource, such as the scaling or
+// lifecycle operations of a ServiceInstance, and decodes the response into the responseBody.
+func (c *ResourceClient) updateResource(name, path, method string, requestBody interface{}, responseBody interface{}) error {
+	resp, err := c.executeRequest(method, fmt.Sprintf("%s%s", c.getObjectPath(c.ResourceRootPath, name), path), requestBody)
+	if err != nil {
+		return err
+	}
+	return c.unmarshalResponseBody(resp, responseBody)
+}
+
 // ServiceInstance needs a PUT and a body to be destroyed
 func (c *ResourceClient) deleteResource(name string, requestBody interface{}) error {
 	var objectPath string
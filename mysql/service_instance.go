@@ -3,6 +3,7 @@ package mysql
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/hashicorp/go-oracle-terraform/client"
@@ -24,6 +25,9 @@ const WaitForServiceInstanceDeleteTimeout = time.Duration(3600 * time.Second)
 var (
 	ServiceInstanceContainerPath = "/paas/api/v1.1/instancemgmt/%[1]s/services/MySQLCS/instances/"
 	ServiceInstanceResourcePath  = "/paas/api/v1.1/instancemgmt/%[1]s/services/MySQLCS/instances/%[2]s"
+
+	ServiceInstanceScalePath        = "/hosts/scale"
+	ServiceInstanceDesiredStatePath = "/hosts/%s"
 )
 
 // ServiceInstanceClient is a client for the Service functions of the MySQL API.
@@ -44,6 +48,13 @@ func (c *MySQLClient) ServiceInstanceClient() *ServiceInstanceClient {
 		}}
 }
 
+// withWaitDefaults returns a copy of the client with the given poll interval and timeout, unless it sets its own
+func (c *ServiceInstanceClient) withWaitDefaults(pollInterval, timeout time.Duration) *ServiceInstanceClient {
+	c2 := *c
+	client.SetWaitDefaults(&c2.PollInterval, &c2.Timeout, pollInterval, timeout)
+	return &c2
+}
+
 // Constants for whether the Enterprise Monitor should be installed
 type ServiceInstanceEnterpriseMonitor string
 
@@ -83,6 +94,18 @@ const (
 	ServiceInstanceTerminating  ServiceInstanceState = "TERMINATING"
 )
 
+// ServiceInstanceLifecycleState defines the constants for the lifecycle operations of a Service Instance
+type ServiceInstanceLifecycleState string
+
+const (
+	// ServiceInstanceLifecycleStateStop - stop: Stops the MySQL CS Service Instance.
+	ServiceInstanceLifecycleStateStop ServiceInstanceLifecycleState = "stop"
+	// ServiceInstanceLifecycleStateStart - start: Starts the MySQL CS Service Instance.
+	ServiceInstanceLifecycleStateStart ServiceInstanceLifecycleState = "start"
+	// ServiceInstanceLifecycleStateRestart - restart: Restarts the MySQL CS Service Instance.
+	ServiceInstanceLifecycleStateRestart ServiceInstanceLifecycleState = "restart"
+)
+
 // ActivityLogInfo describes the list of activities that have occurred on the ServiceInstance.
 type ActivityLogInfo struct {
 	ActivityLogId  string                `json:"activityLogId"`
@@ -350,18 +373,17 @@ func (c *ServiceInstanceClient) CreateServiceInstance(input *CreateServiceInstan
 		serviceInstanceError error
 	)
 
-	if c.PollInterval == 0 {
-		c.PollInterval = WaitForServiceInstanceReadyPollInterval
-	}
-	if c.Timeout == 0 {
-		c.Timeout = WaitForServiceInstanceReadyTimeout
-	}
+	c = c.withWaitDefaults(WaitForServiceInstanceReadyPollInterval, WaitForServiceInstanceReadyTimeout)
 
 	// Since these CloudStorageUsername and CloudStoragePassword are sensitive we'll read them
 	// from the client if they haven't specified in the config.
 	if input.ServiceParameters.CloudStorageContainer != "" && input.ServiceParameters.CloudStorageUsername == "" && input.ServiceParameters.CloudStoragePassword == "" {
-		input.ServiceParameters.CloudStorageUsername = *c.ResourceClient.MySQLClient.client.UserName
-		input.ServiceParameters.CloudStoragePassword = *c.ResourceClient.MySQLClient.client.Password
+		creds, err := c.ResourceClient.MySQLClient.client.Credentials().Retrieve(c.ResourceClient.MySQLClient.client.Context())
+		if err != nil {
+			return nil, err
+		}
+		input.ServiceParameters.CloudStorageUsername = creds.Username
+		input.ServiceParameters.CloudStoragePassword = creds.Password
 	}
 
 	for i := 0; i < *c.MySQLClient.client.MaxRetries; i++ {
@@ -449,12 +471,7 @@ type DeleteServiceInput struct {
 
 // DeleteServiceInstance delete the MySQL instance, then waits for the actual instance to be removed before returning.
 func (c *ServiceInstanceClient) DeleteServiceInstance(serviceName string) error {
-	if c.Timeout == 0 {
-		c.Timeout = WaitForServiceInstanceDeleteTimeout
-	}
-	if c.PollInterval == 0 {
-		c.PollInterval = WaitForServiceInstanceDeletePollInterval
-	}
+	c = c.withWaitDefaults(WaitForServiceInstanceDeletePollInterval, WaitForServiceInstanceDeleteTimeout)
 
 	c.client.DebugLogString(fmt.Sprintf("Deleting Instance : %s", serviceName))
 
@@ -503,3 +520,133 @@ func (c *ServiceInstanceClient) WaitForServiceInstanceDeleted(input *GetServiceI
 		}
 	})
 }
+
+// ScaleServiceInstanceInput defines the attributes for scaling the MySQL CS Service Instance up or down
+// to a different compute shape.
+type ScaleServiceInstanceInput struct {
+	// Name of the MySQL CS Service Instance.
+	// Required.
+	Name string `json:"-"`
+	// Groups the properties for the MySQL component.
+	// Required
+	Components ScaleComponent `json:"components"`
+}
+
+// ScaleComponent groups the properties for the MySQL component when scaling the Service Instance.
+type ScaleComponent struct {
+	// Properties for the MySQL component.
+	// Required
+	Mysql ScaleMysql `json:"mysql"`
+}
+
+// ScaleMysql defines the properties for scaling the MySQL component.
+type ScaleMysql struct {
+	// Desired compute shape, e.g. oc4.
+	// Optional
+	Shape string `json:"shape,omitempty"`
+	// Additional storage (in GB) to attach to the host. The storage is added as a new block
+	// storage volume, and can't be removed once added.
+	// Optional
+	AdditionalStorage string `json:"additionalStorage,omitempty"`
+	// The usage of the additional storage, e.g. mysql_data or mysql_backup. Default: mysql_data
+	// Optional
+	Usage string `json:"usage,omitempty"`
+}
+
+// ScaleServiceInstance scales the MySQL CS Service Instance up or down to the shape given in the input,
+// and waits for the scaling job to complete. The Service Instance is restarted while it's scaled.
+func (c *ServiceInstanceClient) ScaleServiceInstance(input *ScaleServiceInstanceInput) error {
+	if input.Components.Mysql.Shape == "" {
+		return fmt.Errorf("A shape must be given to scale MySQL Service Instance %q", input.Name)
+	}
+	return c.scaleServiceInstance(input)
+}
+
+// AddStorageInput defines the additional storage to attach to the MySQL CS Service Instance.
+type AddStorageInput struct {
+	// Name of the MySQL CS Service Instance.
+	// Required.
+	Name string
+	// Additional storage (in GB) to attach to the Service Instance.
+	// Required
+	AdditionalStorage int
+	// The usage of the additional storage, e.g. mysql_data or mysql_backup.
+	// Optional
+	Usage string
+}
+
+// AddStorage expands the storage of the MySQL CS Service Instance by attaching a new block storage volume
+// of the given size, and waits for the job to complete.
+func (c *ServiceInstanceClient) AddStorage(input *AddStorageInput) error {
+	if input.AdditionalStorage <= 0 {
+		return fmt.Errorf("The additional storage of MySQL Service Instance %q must be greater than 0, got %d", input.Name, input.AdditionalStorage)
+	}
+	return c.scaleServiceInstance(&ScaleServiceInstanceInput{
+		Name: input.Name,
+		Components: ScaleComponent{
+			Mysql: ScaleMysql{
+				AdditionalStorage: strconv.Itoa(input.AdditionalStorage),
+				Usage:             input.Usage,
+			},
+		},
+	})
+}
+
+func (c *ServiceInstanceClient) scaleServiceInstance(input *ScaleServiceInstanceInput) error {
+	var jobResponse JobResponse
+	c = c.withWaitDefaults(WaitForServiceInstanceReadyPollInterval, WaitForServiceInstanceReadyTimeout)
+
+	if err := c.updateResource(input.Name, ServiceInstanceScalePath, "POST", input, &jobResponse); err != nil {
+		return fmt.Errorf("Unable to scale MySQL Service Instance %q: %+v", input.Name, err)
+	}
+
+	getJobInput := &GetJobInput{
+		ID: jobResponse.Details.JobID,
+	}
+
+	if err := c.MySQLClient.Jobs().WaitForJobCompletion(getJobInput, c.PollInterval, c.Timeout); err != nil {
+		return fmt.Errorf("Error scaling MySQL Service Instance %q: %w", input.Name, err)
+	}
+
+	return nil
+}
+
+// DesiredStateInput defines the lifecycle operation to perform on the MySQL CS Service Instance.
+type DesiredStateInput struct {
+	// Name of the MySQL CS Service Instance.
+	// Required.
+	Name string `json:"-"`
+	// The lifecycle operation to perform, stop, start or restart.
+	// Required
+	LifecycleState ServiceInstanceLifecycleState `json:"-"`
+	// Flag that specifies whether to control all the hosts of the Service Instance. This attribute is not
+	// applicable to the restart operation.
+	// Optional
+	AllServiceHosts bool `json:"allServiceHosts,omitempty"`
+}
+
+// UpdateDesiredState stops, starts or restarts the MySQL CS Service Instance, and waits for the job to complete.
+func (c *ServiceInstanceClient) UpdateDesiredState(input *DesiredStateInput) error {
+	var jobResponse JobResponse
+	c = c.withWaitDefaults(WaitForServiceInstanceReadyPollInterval, WaitForServiceInstanceReadyTimeout)
+
+	switch input.LifecycleState {
+	case ServiceInstanceLifecycleStateStop, ServiceInstanceLifecycleStateStart, ServiceInstanceLifecycleStateRestart:
+	default:
+		return fmt.Errorf("Unknown lifecycle state %q for MySQL Service Instance %q", input.LifecycleState, input.Name)
+	}
+
+	if err := c.updateResource(input.Name, fmt.Sprintf(ServiceInstanceDesiredStatePath, input.LifecycleState), "POST", input, &jobResponse); err != nil {
+		return fmt.Errorf("Unable to %s MySQL Service Instance %q: %+v", input.LifecycleState, input.Name, err)
+	}
+
+	getJobInput := &GetJobInput{
+		ID: jobResponse.Details.JobID,
+	}
+
+	if err := c.MySQLClient.Jobs().WaitForJobCompletion(getJobInput, c.PollInterval, c.Timeout); err != nil {
+		return fmt.Errorf("Error waiting to %s MySQL Service Instance %q: %w", input.LifecycleState, input.Name, err)
+	}
+
+	return nil
+}
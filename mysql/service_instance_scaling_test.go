@@ -0,0 +1,98 @@
+package mysql
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-oracle-terraform/jobs"
+)
+
+// newScalingTestClient returns a client for a server with a single service instance, recording the body
+// of each POST request by path. Jobs for the restart operation fail, all other jobs succeed.
+func newScalingTestClient(t *testing.T) (*ServiceInstanceClient, map[string]string) {
+	const instancePath = "/paas/api/v1.1/instancemgmt/test-domain/services/MySQLCS/instances/test-mysql"
+	posted := map[string]string{}
+	client := getStubTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && strings.HasPrefix(r.URL.Path, instancePath+"/hosts/"):
+			body, _ := ioutil.ReadAll(r.Body)
+			path := strings.TrimPrefix(r.URL.Path, instancePath)
+			posted[path] = string(body)
+			w.WriteHeader(http.StatusAccepted)
+			if path == "/hosts/restart" {
+				w.Write([]byte(`{"details": {"message": "Submitted job", "jobId": "2"}}`))
+				return
+			}
+			w.Write([]byte(`{"details": {"message": "Submitted job", "jobId": "1"}}`))
+		case r.Method == "GET" && r.URL.Path == "/paas/api/v1.1/activitylog/test-domain/job/1":
+			w.Write([]byte(`{"jobId": "1", "status": "SUCCEED"}`))
+		case r.Method == "GET" && r.URL.Path == "/paas/api/v1.1/activitylog/test-domain/job/2":
+			w.Write([]byte(`{"jobId": "2", "status": "FAILED", "summaryMessage": "Host is not running"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	siClient := client.ServiceInstanceClient()
+	siClient.PollInterval = 10 * time.Millisecond
+	siClient.Timeout = 5 * time.Second
+	return siClient, posted
+}
+
+func TestServiceInstanceClient_ScaleAndAddStorage(t *testing.T) {
+	siClient, posted := newScalingTestClient(t)
+
+	err := siClient.ScaleServiceInstance(&ScaleServiceInstanceInput{
+		Name:       "test-mysql",
+		Components: ScaleComponent{Mysql: ScaleMysql{Shape: "oc4"}},
+	})
+	if err != nil {
+		t.Fatalf("Error scaling service instance: %s", err)
+	}
+	if posted["/hosts/scale"] != `{"components":{"mysql":{"shape":"oc4"}}}` {
+		t.Fatalf("Unexpected scale request: %s", posted["/hosts/scale"])
+	}
+
+	if err := siClient.AddStorage(&AddStorageInput{Name: "test-mysql", AdditionalStorage: 50, Usage: "mysql_data"}); err != nil {
+		t.Fatalf("Error adding storage: %s", err)
+	}
+	if posted["/hosts/scale"] != `{"components":{"mysql":{"additionalStorage":"50","usage":"mysql_data"}}}` {
+		t.Fatalf("Unexpected add storage request: %s", posted["/hosts/scale"])
+	}
+
+	if err := siClient.ScaleServiceInstance(&ScaleServiceInstanceInput{Name: "test-mysql"}); err == nil {
+		t.Fatalf("Expected an error scaling without a shape")
+	}
+	if err := siClient.AddStorage(&AddStorageInput{Name: "test-mysql"}); err == nil {
+		t.Fatalf("Expected an error adding no storage")
+	}
+}
+
+func TestServiceInstanceClient_UpdateDesiredState(t *testing.T) {
+	siClient, posted := newScalingTestClient(t)
+
+	err := siClient.UpdateDesiredState(&DesiredStateInput{
+		Name:            "test-mysql",
+		LifecycleState:  ServiceInstanceLifecycleStateStop,
+		AllServiceHosts: true,
+	})
+	if err != nil {
+		t.Fatalf("Error stopping service instance: %s", err)
+	}
+	if posted["/hosts/stop"] != `{"allServiceHosts":true}` {
+		t.Fatalf("Unexpected stop request: %s", posted["/hosts/stop"])
+	}
+
+	err = siClient.UpdateDesiredState(&DesiredStateInput{Name: "test-mysql", LifecycleState: ServiceInstanceLifecycleStateRestart})
+	var jobErr *jobs.Error
+	if !errors.As(err, &jobErr) || jobErr.Message != "Host is not running" {
+		t.Fatalf("Expected the restart job to fail, got %v", err)
+	}
+
+	if err := siClient.UpdateDesiredState(&DesiredStateInput{Name: "test-mysql", LifecycleState: "pause"}); err == nil {
+		t.Fatalf("Expected an error for an unknown lifecycle state")
+	}
+}
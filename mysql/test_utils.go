@@ -3,8 +3,10 @@ package mysql
 import (
 	"github.com/hashicorp/go-oracle-terraform/opc"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
+	"testing"
 	"time"
 )
 
@@ -44,3 +46,26 @@ func GetMySQLTestClient(c *opc.Config) (*MySQLClient, error) {
 
 	return NewMySQLClient(c)
 }
+
+// getStubTestClient returns a client for a test server with the handler, which is closed when the test completes
+// nolint: deadcode
+func getStubTestClient(t *testing.T, handler http.HandlerFunc) *MySQLClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	endpoint, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := GetMySQLTestClient(&opc.Config{
+		IdentityDomain: opc.String("test-domain"),
+		Username:       opc.String("user"),
+		Password:       opc.String("password"),
+		APIEndpoint:    endpoint,
+		HTTPClient:     server.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+	return client
+}
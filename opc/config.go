@@ -12,10 +12,15 @@ type Config struct {
 	IdentityDomain *string
 	APIEndpoint    *url.URL
 	MaxRetries     *int
+	RetryPolicy    *RetryPolicy
 	LogLevel       LogLevelType
 	Logger         Logger
 	HTTPClient     *http.Client
 	UserAgent      *string
+	Middleware     []Middleware
+	// CredentialsProvider supplies the credentials used to authenticate, in place of the
+	// Username and Password, so they can be rotated without rebuilding the clients
+	CredentialsProvider CredentialsProvider
 }
 
 // NewConfig returns a blank config to populate with the neccessary fields to authenitcate with Oracle's API
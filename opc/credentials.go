@@ -0,0 +1,186 @@
+package opc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultUsernameEnvVar is the environment variable the username is read from, if unspecified
+	DefaultUsernameEnvVar = "OPC_USERNAME"
+	// DefaultPasswordEnvVar is the environment variable the password is read from, if unspecified
+	DefaultPasswordEnvVar = "OPC_PASSWORD"
+)
+
+// Credentials are the username and password used to authenticate with the API
+type Credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	// The time the credentials expire and must be retrieved again. Credentials
+	// without an expiry are retrieved again only once they are rejected by the API.
+	Expires time.Time `json:"expires,omitempty"`
+}
+
+// Expired returns whether the credentials have passed their expiry time
+func (c *Credentials) Expired() bool {
+	return !c.Expires.IsZero() && !time.Now().Before(c.Expires)
+}
+
+// CredentialsProvider supplies the credentials used to authenticate with the API.
+// Providers are called again whenever the credentials expire or are rejected,
+// so rotated passwords are picked up without rebuilding the clients.
+type CredentialsProvider interface {
+	Retrieve(ctx context.Context) (*Credentials, error)
+}
+
+// CredentialsProviderFunc adapts a function into a CredentialsProvider
+type CredentialsProviderFunc func(ctx context.Context) (*Credentials, error)
+
+// Retrieve calls f(ctx)
+func (f CredentialsProviderFunc) Retrieve(ctx context.Context) (*Credentials, error) {
+	return f(ctx)
+}
+
+// StaticCredentialsProvider always supplies the same credentials
+type StaticCredentialsProvider struct {
+	Username string
+	Password string
+}
+
+// Retrieve returns the static credentials
+func (p *StaticCredentialsProvider) Retrieve(ctx context.Context) (*Credentials, error) {
+	return &Credentials{Username: p.Username, Password: p.Password}, nil
+}
+
+// EnvCredentialsProvider reads the credentials from environment variables
+type EnvCredentialsProvider struct {
+	// Optional - Defaults to DefaultUsernameEnvVar
+	UsernameVar string
+	// Optional - Defaults to DefaultPasswordEnvVar
+	PasswordVar string
+}
+
+// Retrieve reads the credentials from the environment
+func (p *EnvCredentialsProvider) Retrieve(ctx context.Context) (*Credentials, error) {
+	usernameVar := p.UsernameVar
+	if usernameVar == "" {
+		usernameVar = DefaultUsernameEnvVar
+	}
+	passwordVar := p.PasswordVar
+	if passwordVar == "" {
+		passwordVar = DefaultPasswordEnvVar
+	}
+
+	creds := &Credentials{
+		Username: os.Getenv(usernameVar),
+		Password: os.Getenv(passwordVar),
+	}
+	if creds.Username == "" || creds.Password == "" {
+		return nil, fmt.Errorf("Both %s and %s must be set in the environment", usernameVar, passwordVar)
+	}
+	return creds, nil
+}
+
+// FileCredentialsProvider reads the credentials from a JSON file with `username`,
+// `password` and an optional RFC 3339 `expires` field
+type FileCredentialsProvider struct {
+	Path string
+}
+
+// Retrieve reads the credentials from the file
+func (p *FileCredentialsProvider) Retrieve(ctx context.Context) (*Credentials, error) {
+	data, err := ioutil.ReadFile(p.Path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading credentials file: %s", err)
+	}
+	creds, err := parseCredentials(data)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing credentials file %s: %s", p.Path, err)
+	}
+	return creds, nil
+}
+
+// CommandCredentialsProvider runs an external command, e.g. a secrets manager CLI, which writes
+// the credentials to stdout in the same JSON format read by FileCredentialsProvider
+type CommandCredentialsProvider struct {
+	Command string
+	Args    []string
+}
+
+// Retrieve runs the command and parses the credentials from its output
+func (p *CommandCredentialsProvider) Retrieve(ctx context.Context) (*Credentials, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.Command, p.Args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("Error running credentials command %s: %s: %s", p.Command, err, strings.TrimSpace(stderr.String()))
+	}
+	creds, err := parseCredentials(stdout.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing output of credentials command %s: %s", p.Command, err)
+	}
+	return creds, nil
+}
+
+func parseCredentials(data []byte) (*Credentials, error) {
+	var creds Credentials
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return nil, err
+	}
+	if creds.Username == "" || creds.Password == "" {
+		return nil, fmt.Errorf("Both username and password must be set")
+	}
+	return &creds, nil
+}
+
+// CredentialsCache caches the credentials supplied by a provider until they expire or are
+// invalidated after being rejected by the API. It is safe for concurrent use.
+type CredentialsCache struct {
+	provider CredentialsProvider
+
+	mu    sync.Mutex
+	creds *Credentials
+}
+
+// NewCredentialsCache returns a cache of the credentials supplied by the provider
+func NewCredentialsCache(provider CredentialsProvider) *CredentialsCache {
+	return &CredentialsCache{provider: provider}
+}
+
+// Retrieve returns the cached credentials, retrieving them from the provider
+// if they haven't been retrieved yet, have expired or were invalidated
+func (c *CredentialsCache) Retrieve(ctx context.Context) (*Credentials, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.creds != nil && !c.creds.Expired() {
+		return c.creds, nil
+	}
+	creds, err := c.provider.Retrieve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.creds = creds
+	return creds, nil
+}
+
+// Invalidate discards the cached credentials if they match the given, rejected, credentials.
+// Credentials which have already been replaced by a concurrent refresh are left alone.
+func (c *CredentialsCache) Invalidate(creds *Credentials) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.creds != nil && creds != nil &&
+		c.creds.Username == creds.Username && c.creds.Password == creds.Password {
+		c.creds = nil
+	}
+}
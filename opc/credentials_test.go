@@ -0,0 +1,148 @@
+package opc
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type countingProvider struct {
+	creds []*Credentials
+	calls int
+}
+
+func (p *countingProvider) Retrieve(ctx context.Context) (*Credentials, error) {
+	creds := p.creds[p.calls%len(p.creds)]
+	p.calls++
+	return creds, nil
+}
+
+func TestEnvCredentialsProvider(t *testing.T) {
+	t.Setenv("TEST_OPC_USERNAME", "env-user")
+	t.Setenv("TEST_OPC_PASSWORD", "env-password")
+	provider := &EnvCredentialsProvider{UsernameVar: "TEST_OPC_USERNAME", PasswordVar: "TEST_OPC_PASSWORD"}
+
+	creds, err := provider.Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("Error retrieving credentials: %s", err)
+	}
+	if creds.Username != "env-user" || creds.Password != "env-password" {
+		t.Fatalf("Unexpected credentials: %+v", creds)
+	}
+
+	// The environment is read again on every retrieval
+	t.Setenv("TEST_OPC_PASSWORD", "rotated-password")
+	if creds, _ = provider.Retrieve(context.Background()); creds.Password != "rotated-password" {
+		t.Fatalf("Expected the rotated password, got %s", creds.Password)
+	}
+
+	t.Setenv("TEST_OPC_PASSWORD", "")
+	if _, err = provider.Retrieve(context.Background()); err == nil {
+		t.Fatalf("Expected an error for a missing password")
+	}
+}
+
+func TestFileCredentialsProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	content := `{"username": "file-user", "password": "file-password", "expires": "2030-01-02T15:04:05Z"}`
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	creds, err := (&FileCredentialsProvider{Path: path}).Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("Error retrieving credentials: %s", err)
+	}
+	if creds.Username != "file-user" || creds.Password != "file-password" {
+		t.Fatalf("Unexpected credentials: %+v", creds)
+	}
+	if !creds.Expires.Equal(time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC)) {
+		t.Fatalf("Unexpected expiry: %s", creds.Expires)
+	}
+
+	if err := ioutil.WriteFile(path, []byte(`{"username": "file-user"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = (&FileCredentialsProvider{Path: path}).Retrieve(context.Background()); err == nil {
+		t.Fatalf("Expected an error for a missing password")
+	}
+	if _, err = (&FileCredentialsProvider{Path: path + ".missing"}).Retrieve(context.Background()); err == nil {
+		t.Fatalf("Expected an error for a missing file")
+	}
+}
+
+func TestCommandCredentialsProvider(t *testing.T) {
+	if _, err := os.Stat("/bin/sh"); err != nil {
+		t.Skip("No shell available")
+	}
+
+	provider := &CommandCredentialsProvider{
+		Command: "/bin/sh",
+		Args:    []string{"-c", `echo '{"username": "cmd-user", "password": "cmd-password"}'`},
+	}
+	creds, err := provider.Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("Error retrieving credentials: %s", err)
+	}
+	if creds.Username != "cmd-user" || creds.Password != "cmd-password" {
+		t.Fatalf("Unexpected credentials: %+v", creds)
+	}
+
+	provider.Args = []string{"-c", "echo 'vault sealed' >&2; exit 1"}
+	if _, err = provider.Retrieve(context.Background()); err == nil {
+		t.Fatalf("Expected an error from a failing command")
+	}
+}
+
+func TestCredentialsCache(t *testing.T) {
+	first := &Credentials{Username: "user", Password: "first"}
+	second := &Credentials{Username: "user", Password: "second"}
+	provider := &countingProvider{creds: []*Credentials{first, second}}
+	cache := NewCredentialsCache(provider)
+
+	for i := 0; i < 3; i++ {
+		creds, err := cache.Retrieve(context.Background())
+		if err != nil {
+			t.Fatalf("Error retrieving credentials: %s", err)
+		}
+		if creds != first {
+			t.Fatalf("Expected the cached credentials, got %+v", creds)
+		}
+	}
+	if provider.calls != 1 {
+		t.Fatalf("Expected 1 call to the provider, got %d", provider.calls)
+	}
+
+	// Invalidating credentials which have already been replaced has no effect
+	cache.Invalidate(&Credentials{Username: "user", Password: "stale"})
+	if creds, _ := cache.Retrieve(context.Background()); creds != first {
+		t.Fatalf("Expected the cached credentials, got %+v", creds)
+	}
+
+	cache.Invalidate(&Credentials{Username: "user", Password: "first"})
+	if creds, _ := cache.Retrieve(context.Background()); creds != second {
+		t.Fatalf("Expected the refreshed credentials, got %+v", creds)
+	}
+	if provider.calls != 2 {
+		t.Fatalf("Expected 2 calls to the provider, got %d", provider.calls)
+	}
+}
+
+func TestCredentialsCache_Expiry(t *testing.T) {
+	provider := &countingProvider{creds: []*Credentials{
+		{Username: "user", Password: "password", Expires: time.Now().Add(-time.Second)},
+	}}
+	cache := NewCredentialsCache(provider)
+
+	for i := 0; i < 2; i++ {
+		if _, err := cache.Retrieve(context.Background()); err != nil {
+			t.Fatalf("Error retrieving credentials: %s", err)
+		}
+	}
+	if provider.calls != 2 {
+		t.Fatalf("Expected expired credentials to be retrieved again, got %d calls", provider.calls)
+	}
+}
@@ -1,13 +1,205 @@
 package opc
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Sentinel errors which an OracleError can be matched against with errors.Is,
+// e.g. errors.Is(err, opc.ErrNotFound)
+var (
+	// ErrNotFound - the requested resource does not exist
+	ErrNotFound = errors.New("resource not found")
+	// ErrConflict - the request conflicts with the current state of the resource
+	ErrConflict = errors.New("resource conflict")
+	// ErrThrottled - the request was rejected due to rate limiting
+	ErrThrottled = errors.New("request throttled")
+	// ErrAuthFailure - the request could not be authenticated or authorized
+	ErrAuthFailure = errors.New("authentication failure")
+)
+
+// Headers which the various Oracle services use to return a request identifier
+var requestIDHeaders = []string{
+	"X-Trans-Id",
+	"X-Openstack-Request-Id",
+	"Opc-Request-Id",
+	"X-Oracle-Dms-Ecid",
+	"X-Request-Id",
+}
 
 // OracleError details the parameters of an error returned from Oracle's API
 type OracleError struct {
+	// The HTTP status code of the response
 	StatusCode int
-	Message    string
+	// The raw response body
+	Message string
+	// The service specific error code, if one was returned
+	Code string
+	// The human readable error message parsed from the response body
+	ErrorMessage string
+	// The request ID returned by the service, e.g. the Storage `X-Trans-Id` header
+	RequestID string
+	// Per-field validation errors, if any were returned
+	Details []ErrorDetail
+}
+
+// ErrorDetail details a single field level error returned by the API
+type ErrorDetail struct {
+	// The request field the error relates to, if known
+	Field string
+	// The error code for this field, if any
+	Code string
+	// The error message for this field
+	Message string
+}
+
+// NewOracleError builds an OracleError from the status code, headers and body
+// of an unsuccessful API response. JSON error bodies returned by Compute, PaaS,
+// LBaaS and Storage are parsed into their code, message and detail fields.
+func NewOracleError(statusCode int, header http.Header, body []byte) *OracleError {
+	e := &OracleError{
+		StatusCode: statusCode,
+		Message:    string(body),
+	}
+
+	for _, h := range requestIDHeaders {
+		if v := header.Get(h); v != "" {
+			e.RequestID = v
+			break
+		}
+	}
+
+	var parsed map[string]interface{}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		// Storage and some gateway errors are plain text or HTML
+		e.ErrorMessage = stripMarkup(string(body))
+		return e
+	}
+	e.parse(parsed)
+
+	return e
+}
+
+var markupRegexp = regexp.MustCompile(`<[^>]*>`)
+
+func stripMarkup(body string) string {
+	return strings.Join(strings.Fields(markupRegexp.ReplaceAllString(body, " ")), " ")
+}
+
+// parse populates the error from a decoded JSON error body. The services
+// disagree on the shape of their errors, so a number of common keys are checked,
+// descending into a nested `details` object as used by the PaaS services.
+func (e *OracleError) parse(body map[string]interface{}) {
+	if e.Code == "" {
+		e.Code = firstString(body, "code", "errorCode", "error_code", "o:errorCode")
+	}
+	if e.ErrorMessage == "" {
+		e.ErrorMessage = firstString(body, "message", "errorMessage", "error_message", "detail", "o:errorDetails", "error")
+	}
+
+	for _, key := range []string{"errors", "fieldErrors", "details"} {
+		switch v := body[key].(type) {
+		case map[string]interface{}:
+			e.parse(v)
+		case []interface{}:
+			for _, item := range v {
+				if m, ok := item.(map[string]interface{}); ok {
+					e.Details = append(e.Details, ErrorDetail{
+						Field:   firstString(m, "field", "fieldName", "path", "o:errorPath"),
+						Code:    firstString(m, "code", "errorCode", "o:errorCode"),
+						Message: firstString(m, "message", "errorMessage", "detail", "o:errorDetails"),
+					})
+				}
+			}
+		}
+	}
+}
+
+func firstString(body map[string]interface{}, keys ...string) string {
+	for _, k := range keys {
+		switch v := body[k].(type) {
+		case string:
+			if v != "" {
+				return v
+			}
+		case float64:
+			return fmt.Sprintf("%v", v)
+		}
+	}
+	return ""
 }
 
 func (e OracleError) Error() string {
 	return fmt.Sprintf("%d: %s", e.StatusCode, e.Message)
 }
+
+// Is allows an OracleError to be matched against the sentinel errors
+// ErrNotFound, ErrConflict, ErrThrottled and ErrAuthFailure with errors.Is
+func (e OracleError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		// The PaaS services report some missing resources with a non-404 status code
+		return e.StatusCode == http.StatusNotFound || strings.Contains(e.Message, "No such service exits")
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrThrottled:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrAuthFailure:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	}
+	return false
+}
+
+// Retryable returns true if the error represents a transient failure which may
+// succeed if the request is retried
+func (e OracleError) Retryable() bool {
+	switch e.StatusCode {
+	case http.StatusRequestTimeout,
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// IsNotFound returns true if the error, or any error it wraps, reports a missing resource
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsConflict returns true if the error, or any error it wraps, reports a resource conflict
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsThrottled returns true if the error, or any error it wraps, reports the request was rate limited
+func IsThrottled(err error) bool {
+	return errors.Is(err, ErrThrottled)
+}
+
+// IsAuthFailure returns true if the error, or any error it wraps, reports an authentication
+// or authorization failure
+func IsAuthFailure(err error) bool {
+	return errors.Is(err, ErrAuthFailure)
+}
+
+// IsRetryable returns true if the error, or any error it wraps, is an OracleError
+// representing a transient failure
+func IsRetryable(err error) bool {
+	var oracleErr *OracleError
+	if errors.As(err, &oracleErr) {
+		return oracleErr.Retryable()
+	}
+	var oracleErrValue OracleError
+	if errors.As(err, &oracleErrValue) {
+		return oracleErrValue.Retryable()
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package opc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestNewOracleError_Compute(t *testing.T) {
+	body := `{"message": "No such instance /Compute-test/test/foo"}`
+	err := NewOracleError(404, http.Header{}, []byte(body))
+
+	if err.ErrorMessage != "No such instance /Compute-test/test/foo" {
+		t.Fatalf("Unexpected error message: %q", err.ErrorMessage)
+	}
+	if err.Message != body {
+		t.Fatalf("Expected raw body to be kept in Message, got: %q", err.Message)
+	}
+	if !IsNotFound(err) {
+		t.Fatalf("Expected error to be a not found error")
+	}
+}
+
+func TestNewOracleError_PaaS(t *testing.T) {
+	body := `{
+  "status": "Failed",
+  "details": {
+    "message": "Validation failed",
+    "code": "PSM-GEN-0001",
+    "errors": [
+      {"field": "serviceName", "message": "Service name already in use"}
+    ]
+  }
+}`
+	err := NewOracleError(409, http.Header{"Opc-Request-Id": []string{"abc-123"}}, []byte(body))
+
+	if err.Code != "PSM-GEN-0001" {
+		t.Fatalf("Unexpected error code: %q", err.Code)
+	}
+	if err.ErrorMessage != "Validation failed" {
+		t.Fatalf("Unexpected error message: %q", err.ErrorMessage)
+	}
+	if err.RequestID != "abc-123" {
+		t.Fatalf("Unexpected request ID: %q", err.RequestID)
+	}
+	if len(err.Details) != 1 || err.Details[0].Field != "serviceName" {
+		t.Fatalf("Unexpected error details: %+v", err.Details)
+	}
+	if !IsConflict(err) {
+		t.Fatalf("Expected error to be a conflict error")
+	}
+}
+
+func TestNewOracleError_Storage(t *testing.T) {
+	body := "<html><h1>Unauthorized</h1><p>This server could not verify that you are authorized.</p></html>"
+	err := NewOracleError(401, http.Header{"X-Trans-Id": []string{"tx123"}}, []byte(body))
+
+	if err.ErrorMessage != "Unauthorized This server could not verify that you are authorized." {
+		t.Fatalf("Unexpected error message: %q", err.ErrorMessage)
+	}
+	if err.RequestID != "tx123" {
+		t.Fatalf("Unexpected request ID: %q", err.RequestID)
+	}
+	if !IsAuthFailure(err) {
+		t.Fatalf("Expected error to be an auth failure")
+	}
+}
+
+func TestOracleError_Predicates(t *testing.T) {
+	var err error = NewOracleError(429, http.Header{}, nil)
+	throttled := fmt.Errorf("creating instance: %w", err)
+	if !IsThrottled(throttled) || !IsRetryable(throttled) {
+		t.Fatalf("Expected wrapped 429 to be throttled and retryable")
+	}
+	if IsNotFound(throttled) {
+		t.Fatalf("Expected wrapped 429 not to be a not found error")
+	}
+
+	var oracleErr *OracleError
+	if !errors.As(throttled, &oracleErr) || oracleErr.StatusCode != 429 {
+		t.Fatalf("Expected errors.As to find the OracleError")
+	}
+
+	if IsRetryable(NewOracleError(400, http.Header{}, nil)) {
+		t.Fatalf("Expected 400 not to be retryable")
+	}
+	if IsRetryable(errors.New("some other error")) {
+		t.Fatalf("Expected non OracleError not to be retryable")
+	}
+
+	legacy := NewOracleError(400, http.Header{}, []byte("No such service exits"))
+	if !IsNotFound(legacy) {
+		t.Fatalf("Expected PaaS missing service error to be a not found error")
+	}
+}
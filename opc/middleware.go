@@ -0,0 +1,25 @@
+package opc
+
+import "net/http"
+
+// Middleware wraps the RoundTripper used to send every request made by a client,
+// allowing headers to be injected, requests and responses to be inspected or
+// requests to be delayed. Middleware is invoked for each attempt, including retries.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc is an adapter allowing an ordinary function to be used as an http.RoundTripper
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req)
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Chain wraps the RoundTripper with the given middleware. The first middleware is
+// the outermost, and so sees each request first and each response last.
+func Chain(rt http.RoundTripper, middleware ...Middleware) http.RoundTripper {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		rt = middleware[i](rt)
+	}
+	return rt
+}
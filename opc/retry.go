@@ -0,0 +1,110 @@
+package opc
+
+import (
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// DefaultRetryableStatusCodes are the HTTP status codes retried when a RetryPolicy
+// doesn't specify its own
+var DefaultRetryableStatusCodes = []int{
+	http.StatusRequestTimeout,
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// BackoffFunc returns the time to wait before the given retry attempt.
+// The first retry is attempt 1.
+type BackoffFunc func(attempt int) time.Duration
+
+// RetryAttempt details a single request attempt, as reported to RetryPolicy.OnAttempt
+type RetryAttempt struct {
+	// The request being attempted
+	Request *http.Request
+	// The attempt number, starting at 1
+	Attempt int
+	// The response received, nil if the request failed to complete
+	Response *http.Response
+	// The transport error or OracleError the attempt failed with, nil on success
+	Err error
+	// Whether the request will be retried
+	Retrying bool
+	// The time the client will wait before retrying
+	Wait time.Duration
+}
+
+// RetryPolicy controls how the client retries failed requests.
+// The number of attempts is set by Config.MaxRetries.
+type RetryPolicy struct {
+	// HTTP status codes which should be retried.
+	// Defaults to DefaultRetryableStatusCodes if empty.
+	RetryableStatusCodes []int
+	// Retry requests which fail with a network or transport error, e.g. a connection reset or timeout
+	RetryNetworkErrors bool
+	// Allow non-idempotent requests (POST and PATCH) to be retried.
+	// Retrying them can create duplicate resources if the original request was processed.
+	RetryNonIdempotent bool
+	// Wait for the duration given by a `Retry-After` response header, when present, instead of the backoff
+	HonorRetryAfter bool
+	// Stop retrying once this much time has passed since the first attempt. Zero means no limit.
+	MaxElapsedTime time.Duration
+	// Returns the time to wait before each retry.
+	// Defaults to an exponential backoff with jitter from 1 to ~60 seconds.
+	Backoff BackoffFunc
+	// Called after every attempt, successful or not
+	OnAttempt func(*RetryAttempt)
+}
+
+// DefaultRetryPolicy returns a RetryPolicy which retries throttling, server and
+// network errors for idempotent requests, honoring any `Retry-After` header
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		RetryableStatusCodes: DefaultRetryableStatusCodes,
+		RetryNetworkErrors:   true,
+		HonorRetryAfter:      true,
+	}
+}
+
+// ShouldRetryStatus returns true if the policy retries the given HTTP status code
+func (p *RetryPolicy) ShouldRetryStatus(statusCode int) bool {
+	codes := p.RetryableStatusCodes
+	if len(codes) == 0 {
+		codes = DefaultRetryableStatusCodes
+	}
+	for _, c := range codes {
+		if c == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// CanRetryMethod returns true if requests with the given HTTP method may be retried
+func (p *RetryPolicy) CanRetryMethod(method string) bool {
+	if p.RetryNonIdempotent {
+		return true
+	}
+	return method != http.MethodPost && method != http.MethodPatch
+}
+
+// ExponentialBackoff returns a BackoffFunc which doubles the wait for each attempt,
+// starting at base and adding up to 50% jitter, capped at max
+func ExponentialBackoff(base, max time.Duration) BackoffFunc {
+	return func(attempt int) time.Duration {
+		wait := base
+		for i := 1; i < attempt && wait < max; i++ {
+			wait *= 2
+		}
+		if wait > 0 {
+			wait += time.Duration(rand.Int63n(int64(wait))) / 2
+		}
+		if wait > max {
+			wait = max
+		}
+		return wait
+	}
+}
@@ -0,0 +1,38 @@
+package opc
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_Defaults(t *testing.T) {
+	policy := &RetryPolicy{}
+
+	if !policy.ShouldRetryStatus(http.StatusServiceUnavailable) {
+		t.Fatalf("Expected 503 to be retried by default")
+	}
+	if policy.ShouldRetryStatus(http.StatusConflict) {
+		t.Fatalf("Expected 409 not to be retried by default")
+	}
+	if policy.CanRetryMethod(http.MethodPost) {
+		t.Fatalf("Expected POST not to be retried by default")
+	}
+	if !policy.CanRetryMethod(http.MethodDelete) {
+		t.Fatalf("Expected DELETE to be retried by default")
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(1*time.Second, 10*time.Second)
+
+	for attempt, min := range []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second} {
+		wait := backoff(attempt + 1)
+		if wait < min || wait > 10*time.Second {
+			t.Fatalf("Attempt %d: expected a wait between %s and 10s, got %s", attempt+1, min, wait)
+		}
+	}
+	if wait := backoff(10); wait != 10*time.Second {
+		t.Fatalf("Expected wait to be capped at 10s, got %s", wait)
+	}
+}
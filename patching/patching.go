@@ -0,0 +1,291 @@
+// Package patching lists, prechecks, applies and rolls back the patches of PaaS service
+// instances (Database and Java Cloud Service) through the shared patching API.
+package patching
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-oracle-terraform/client"
+	"github.com/hashicorp/go-oracle-terraform/jobs"
+	"github.com/mitchellh/mapstructure"
+)
+
+// API URI Paths for the patches of a service instance, relative to the service instance
+const (
+	availablePatchesPath = "/patches/available"
+	appliedPatchesPath   = "/patches/applied"
+	patchPath            = "/patches/%s"
+	precheckPatchPath    = "/patches/%s/precheck"
+	rollbackPatchPath    = "/patches/%s/rollback"
+)
+
+// Default Poll Interval and Timeout values, patching restarts the service instance so can take some time
+const waitForPatchPollInterval = 30 * time.Second
+const waitForPatchTimeout = 120 * time.Minute
+
+const tenantHeader = "X-ID-TENANT-NAME"
+
+// Status defines the constants for the status of a patch applied to a service instance
+type Status string
+
+const (
+	// StatusInProgress - the patch is being applied or rolled back.
+	StatusInProgress Status = "IN_PROGRESS"
+	// StatusCompleted - the patch was applied.
+	StatusCompleted Status = "COMPLETED"
+	// StatusFailed - the patch failed to apply.
+	StatusFailed Status = "FAILED"
+	// StatusRolledBack - the patch was rolled back.
+	StatusRolledBack Status = "ROLLED_BACK"
+)
+
+// Patch details a patch available for a service instance
+type Patch struct {
+	// ID of the patch, used to precheck, apply and roll back the patch.
+	ID string `json:"patchId"`
+	// Number of the patch.
+	Number string `json:"patchNumber"`
+	// Category of the patch, e.g. PSU.
+	Category string `json:"patchCategory"`
+	// Description of the patch.
+	Description string `json:"patchDescription"`
+	// Type of the patch, e.g. Mandatory or Optional.
+	Type string `json:"patchType"`
+	// Date the patch was released.
+	ReleaseDate string `json:"releaseDate"`
+	// URL of the release notes of the patch.
+	ReleaseURL string `json:"patchReleaseUrl"`
+	// Whether applying the patch restarts the service instance.
+	RequiresRestart bool `json:"requiresRestart"`
+	// Size of the patch.
+	Size string `json:"patchSize"`
+	// Version of the service instance after the patch is applied.
+	Version string `json:"version"`
+}
+
+// AvailablePatches holds the patches available for a service instance
+type AvailablePatches struct {
+	Patches []Patch `json:"availablePatches"`
+}
+
+// AppliedPatch details a patch which has been applied to, or rolled back from, a service instance
+type AppliedPatch struct {
+	Patch `json:",squash"`
+	// Status of the patch.
+	Status Status `json:"status"`
+	// Date and time the patching operation started.
+	StartDate string `json:"startDate"`
+	// Date and time the patching operation ended.
+	EndDate string `json:"endDate"`
+	// User who applied the patch.
+	AppliedBy string `json:"appliedBy"`
+	// Whether the patch can be rolled back.
+	RollbackAvailable bool `json:"rollbackAvailable"`
+}
+
+// AppliedPatches holds the patching history of a service instance
+type AppliedPatches struct {
+	Patches []AppliedPatch `json:"appliedPatches"`
+}
+
+// Client is a client for the patches of the service instances of a PaaS service.
+type Client struct {
+	client       *client.Client
+	jobs         *jobs.Client
+	instancePath string
+	PollInterval time.Duration
+	Timeout      time.Duration
+}
+
+// NewClient returns a Client for the patches of service instances at the instancePath, formatted
+// with the identity domain and service instance name, which waits for the patching jobs with the jobClient
+func NewClient(c *client.Client, jobClient *jobs.Client, instancePath string) *Client {
+	return &Client{
+		client:       c,
+		jobs:         jobClient,
+		instancePath: instancePath,
+	}
+}
+
+// ListPatchesInput specifies the service instance to list the patches of
+type ListPatchesInput struct {
+	// Name of the service instance.
+	// Required
+	ServiceInstanceID string
+}
+
+// ListAvailablePatches lists the patches which can be applied to the service instance
+func (c *Client) ListAvailablePatches(input *ListPatchesInput) (*AvailablePatches, error) {
+	var patches AvailablePatches
+	if err := c.executeRequest("GET", input.ServiceInstanceID, availablePatchesPath, nil, &patches); err != nil {
+		return nil, err
+	}
+	return &patches, nil
+}
+
+// ListAppliedPatches lists the patching history of the service instance, with the status of each patch
+func (c *Client) ListAppliedPatches(input *ListPatchesInput) (*AppliedPatches, error) {
+	var patches AppliedPatches
+	if err := c.executeRequest("GET", input.ServiceInstanceID, appliedPatchesPath, nil, &patches); err != nil {
+		return nil, err
+	}
+	return &patches, nil
+}
+
+// GetPatchStatusInput specifies the patch to get the status of
+type GetPatchStatusInput struct {
+	// Name of the service instance.
+	// Required
+	ServiceInstanceID string
+	// ID of the patch.
+	// Required
+	PatchID string
+}
+
+// GetPatchStatus returns the most recent patching history of the patch, or nil if the
+// patch has never been applied to the service instance
+func (c *Client) GetPatchStatus(input *GetPatchStatusInput) (*AppliedPatch, error) {
+	patches, err := c.ListAppliedPatches(&ListPatchesInput{ServiceInstanceID: input.ServiceInstanceID})
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *AppliedPatch
+	for i, patch := range patches.Patches {
+		if patch.ID != input.PatchID {
+			continue
+		}
+		if latest == nil || patch.StartDate > latest.StartDate {
+			latest = &patches.Patches[i]
+		}
+	}
+	return latest, nil
+}
+
+// PatchInput specifies the patch to precheck, apply or roll back
+type PatchInput struct {
+	// Name of the service instance.
+	// Required
+	ServiceInstanceID string
+	// ID of the patch.
+	// Required
+	PatchID string
+	// Optional - Defaults to the PollInterval of the Client
+	PollInterval time.Duration
+	// Optional - Defaults to the Timeout of the Client
+	Timeout time.Duration
+	// Called with each new activity log message of the patching job
+	// Optional
+	Progress jobs.ProgressFunc
+}
+
+// PrecheckPatch checks the patch can be applied to the service instance, and waits for the
+// check to complete. If the check fails the returned *jobs.Error explains why.
+func (c *Client) PrecheckPatch(input *PatchInput) (*jobs.Job, error) {
+	return c.patchOperation("precheck", fmt.Sprintf(precheckPatchPath, input.PatchID), input)
+}
+
+// ApplyPatch applies the patch to the service instance, and waits for it to be applied
+func (c *Client) ApplyPatch(input *PatchInput) (*jobs.Job, error) {
+	return c.patchOperation("apply", fmt.Sprintf(patchPath, input.PatchID), input)
+}
+
+// RollbackPatch rolls the patch back from the service instance, and waits for it to be rolled back
+func (c *Client) RollbackPatch(input *PatchInput) (*jobs.Job, error) {
+	return c.patchOperation("roll back", fmt.Sprintf(rollbackPatchPath, input.PatchID), input)
+}
+
+func (c *Client) patchOperation(operation, path string, input *PatchInput) (*jobs.Job, error) {
+	var jobResponse jobs.Response
+	if err := c.executeRequest("PUT", input.ServiceInstanceID, path, struct{}{}, &jobResponse); err != nil {
+		return nil, fmt.Errorf("Unable to %s patch %s of service instance %q: %+v", operation, input.PatchID, input.ServiceInstanceID, err)
+	}
+
+	pollInterval := input.PollInterval
+	if pollInterval == 0 {
+		pollInterval = c.PollInterval
+	}
+	if pollInterval == 0 {
+		pollInterval = waitForPatchPollInterval
+	}
+	timeout := input.Timeout
+	if timeout == 0 {
+		timeout = c.Timeout
+	}
+	if timeout == 0 {
+		timeout = waitForPatchTimeout
+	}
+
+	job, err := c.jobs.WaitForJob(&jobs.WaitForJobInput{
+		ID:           jobResponse.Details.JobID,
+		PollInterval: pollInterval,
+		Timeout:      timeout,
+		Progress:     input.Progress,
+	})
+	if err != nil {
+		return job, fmt.Errorf("Error waiting to %s patch %s of service instance %q: %w", operation, input.PatchID, input.ServiceInstanceID, err)
+	}
+	return job, nil
+}
+
+func (c *Client) executeRequest(method, serviceInstanceID, path string, body interface{}, responseBody interface{}) error {
+	reqBody, err := c.client.MarshallRequestBody(body)
+	if err != nil {
+		return err
+	}
+
+	objectPath := fmt.Sprintf(c.instancePath, *c.client.IdentityDomain, serviceInstanceID) + path
+	req, err := c.client.BuildRequestBody(method, objectPath, reqBody)
+	if err != nil {
+		return err
+	}
+
+	debugReqString := fmt.Sprintf("HTTP %s Req (%s)", method, objectPath)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+		debugReqString = fmt.Sprintf("%s:\nBody: %+v", debugReqString, string(reqBody))
+	}
+	c.client.DebugLogString(debugReqString)
+
+	// Set the tenant header
+	req.Header.Add(tenantHeader, *c.client.IdentityDomain)
+	resp, err := c.client.ExecuteRequest(req)
+	if err != nil {
+		return err
+	}
+	return c.unmarshalResponseBody(resp, responseBody)
+}
+
+func (c *Client) unmarshalResponseBody(resp *http.Response, iface interface{}) error {
+	buf := new(bytes.Buffer)
+	_, err := buf.ReadFrom(resp.Body)
+	if err != nil {
+		return err
+	}
+	c.client.DebugLogString(fmt.Sprintf("HTTP Resp (%d): %s", resp.StatusCode, buf.String()))
+	// JSON decode response into interface
+	var tmp interface{}
+	dcd := json.NewDecoder(buf)
+	if err = dcd.Decode(&tmp); err != nil {
+		return fmt.Errorf("Error decoding: %s\n%+v", err.Error(), resp)
+	}
+
+	// Use mapstructure to weakly decode into the resulting interface
+	msdcd, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           iface,
+		TagName:          "json",
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := msdcd.Decode(tmp); err != nil {
+		return err
+	}
+	return nil
+}
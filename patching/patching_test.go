@@ -0,0 +1,123 @@
+package patching
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-oracle-terraform/jobs"
+)
+
+const testInstancePath = "/paas/api/v1.1/instancemgmt/%s/services/dbaas/instances/%s"
+
+// newTestClient returns a client for a server with a single service instance, where prechecks fail
+// and applying or rolling back a patch succeeds. The requests made are recorded.
+func newTestClient(t *testing.T) (*Client, *[]string) {
+	const base = "/paas/api/v1.1/instancemgmt/test-domain/services/dbaas/instances/test-db"
+	var mu sync.Mutex
+	var requests []string
+	patchClient := getStubTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+
+		if r.Header.Get(tenantHeader) != "test-domain" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET " + base + "/patches/available":
+			w.Write([]byte(`{"availablePatches": [{"patchId": "27", "patchNumber": "28317326", "requiresRestart": "true"}]}`))
+		case "GET " + base + "/patches/applied":
+			w.Write([]byte(`{"appliedPatches": [
+				{"patchId": "26", "status": "ROLLED_BACK", "startDate": "2018-01-01T00:00:00Z"},
+				{"patchId": "26", "status": "COMPLETED", "startDate": "2018-04-01T00:00:00Z"}
+			]}`))
+		case "PUT " + base + "/patches/27/precheck":
+			w.Write([]byte(`{"details": {"jobId": "1"}}`))
+		case "PUT " + base + "/patches/27", "PUT " + base + "/patches/27/rollback":
+			w.Write([]byte(`{"details": {"jobId": "2"}}`))
+		case "GET /paas/api/v1.1/activitylog/test-domain/job/1":
+			w.Write([]byte(`{"jobId": "1", "status": "FAILED", "summaryMessage": "Insufficient space in /u01",
+				"messages": [{"activityDate": "2018-04-02T00:00:00Z", "message": "Running precheck"}]}`))
+		case "GET /paas/api/v1.1/activitylog/test-domain/job/2":
+			w.Write([]byte(`{"jobId": "2", "status": "SUCCEED",
+				"messages": [{"activityDate": "2018-04-02T00:00:00Z", "message": "Applying patch"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}, testInstancePath)
+	patchClient.PollInterval = 10 * time.Millisecond
+	patchClient.Timeout = 5 * time.Second
+	return patchClient, &requests
+}
+
+func TestClient_ListPatches(t *testing.T) {
+	patchClient, _ := newTestClient(t)
+
+	available, err := patchClient.ListAvailablePatches(&ListPatchesInput{ServiceInstanceID: "test-db"})
+	if err != nil {
+		t.Fatalf("Error listing available patches: %s", err)
+	}
+	if len(available.Patches) != 1 || available.Patches[0].ID != "27" || !available.Patches[0].RequiresRestart {
+		t.Fatalf("Unexpected available patches: %+v", available)
+	}
+
+	status, err := patchClient.GetPatchStatus(&GetPatchStatusInput{ServiceInstanceID: "test-db", PatchID: "26"})
+	if err != nil {
+		t.Fatalf("Error getting patch status: %s", err)
+	}
+	if status == nil || status.Status != StatusCompleted {
+		t.Fatalf("Expected the most recent status of the patch, got %+v", status)
+	}
+
+	status, err = patchClient.GetPatchStatus(&GetPatchStatusInput{ServiceInstanceID: "test-db", PatchID: "27"})
+	if err != nil || status != nil {
+		t.Fatalf("Expected no status for a patch which hasn't been applied, got %+v, %v", status, err)
+	}
+}
+
+func TestClient_ApplyAndRollbackPatch(t *testing.T) {
+	patchClient, requests := newTestClient(t)
+
+	var progress []string
+	input := &PatchInput{
+		ServiceInstanceID: "test-db",
+		PatchID:           "27",
+		Progress: func(job *jobs.Job, message jobs.Message) {
+			progress = append(progress, message.Message)
+		},
+	}
+	job, err := patchClient.ApplyPatch(input)
+	if err != nil {
+		t.Fatalf("Error applying patch: %s", err)
+	}
+	if job.Status != jobs.StatusSucceed {
+		t.Fatalf("Unexpected job: %+v", job)
+	}
+	if len(progress) != 1 || progress[0] != "Applying patch" {
+		t.Fatalf("Unexpected progress: %v", progress)
+	}
+
+	if _, err := patchClient.RollbackPatch(input); err != nil {
+		t.Fatalf("Error rolling back patch: %s", err)
+	}
+	if (*requests)[len(*requests)-2] != "PUT /paas/api/v1.1/instancemgmt/test-domain/services/dbaas/instances/test-db/patches/27/rollback" {
+		t.Fatalf("Unexpected requests: %v", *requests)
+	}
+}
+
+func TestClient_PrecheckPatchFailure(t *testing.T) {
+	patchClient, _ := newTestClient(t)
+
+	_, err := patchClient.PrecheckPatch(&PatchInput{ServiceInstanceID: "test-db", PatchID: "27"})
+	var jobErr *jobs.Error
+	if !errors.As(err, &jobErr) {
+		t.Fatalf("Expected a job error, got %v", err)
+	}
+	if jobErr.Message != "Insufficient space in /u01" {
+		t.Fatalf("Unexpected failure message: %q", jobErr.Message)
+	}
+}
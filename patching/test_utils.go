@@ -0,0 +1,36 @@
+package patching
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/go-oracle-terraform/client"
+	"github.com/hashicorp/go-oracle-terraform/jobs"
+	"github.com/hashicorp/go-oracle-terraform/opc"
+)
+
+// getStubTestClient returns a client for the patches of the service instances at the instancePath, on a
+// test server with the handler, which is closed when the test completes
+// nolint: deadcode
+func getStubTestClient(t *testing.T, handler http.HandlerFunc, instancePath string) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	endpoint, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opcClient, err := client.NewClient(&opc.Config{
+		IdentityDomain: opc.String("test-domain"),
+		Username:       opc.String("user"),
+		Password:       opc.String("password"),
+		APIEndpoint:    endpoint,
+		HTTPClient:     server.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+	return NewClient(opcClient, jobs.NewClient(opcClient), instancePath)
+}
@@ -1,28 +1,36 @@
 package storage
 
 import (
+	"context"
 	"fmt"
 	"time"
+
+	"github.com/hashicorp/go-oracle-terraform/client"
+	"github.com/hashicorp/go-oracle-terraform/opc"
 )
 
-// Get a new auth token for the storage client
-func (c *Client) getAuthenticationToken() error {
+// The authentication token expires after 30 minutes, so is refreshed before then
+const authTokenTTL = 25 * time.Minute
+
+// Get a new auth token for the storage client. The client must not authenticate its own requests.
+func (c *Client) getAuthenticationToken(ctx context.Context, creds *opc.Credentials) (*client.Token, error) {
 	authHeaders := make(map[string]string)
-	authHeaders["X-Storage-User"] = c.getUserName()
-	authHeaders["X-Storage-Pass"] = *c.client.Password
+	authHeaders["X-Storage-User"] = fmt.Sprintf(strUsername, *c.client.IdentityDomain, creds.Username)
+	authHeaders["X-Storage-Pass"] = creds.Password
 
-	rsp, err := c.executeRequest("GET", "/auth/v1.0", authHeaders)
+	rsp, err := c.WithContext(ctx).executeRequest("GET", "/auth/v1.0", authHeaders)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	authToken := rsp.Header.Get("X-Auth-Token")
 	if authToken == "" {
-		return fmt.Errorf("No authentication token found in response %#v", rsp)
+		return nil, fmt.Errorf("No authentication token found in response %#v", rsp)
 	}
 
 	c.client.DebugLogString("Successfully authenticated to IaaS Storage")
-	c.authToken = &authToken
-	c.tokenIssued = time.Now()
-	return nil
+	return &client.Token{
+		Value:   authToken,
+		Expires: time.Now().Add(authTokenTTL),
+	}, nil
 }
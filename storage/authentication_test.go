@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"testing"
 
 	"github.com/hashicorp/go-oracle-terraform/helper"
@@ -16,7 +17,8 @@ func TestAccObtainAuthenticationToken(t *testing.T) {
 		t.Fatalf("Authentication failed: %s", err)
 	}
 
-	if client.authToken == nil {
-		t.Fatal("Authentication token not set")
+	token, err := client.auth.Token(context.Background())
+	if err != nil || token.Value == "" {
+		t.Fatalf("Authentication token not set: %v", err)
 	}
 }
@@ -0,0 +1,295 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// ContainerSummary describes a container returned when listing an account
+type ContainerSummary struct {
+	// Name of the container
+	Name string `json:"name"`
+	// Number of objects in the container
+	Count int `json:"count"`
+	// Total number of bytes stored in the container
+	Bytes int64 `json:"bytes"`
+	// Date and time the container was last modified
+	LastModified string `json:"last_modified"`
+}
+
+// ObjectSummary describes an object returned when listing a container.
+// When listing with a Delimiter, pseudo-directories are returned with only Subdir set.
+type ObjectSummary struct {
+	// Name of the object
+	Name string `json:"name"`
+	// MD5 checksum of the object content
+	Hash string `json:"hash"`
+	// Length of the object in bytes
+	Bytes int64 `json:"bytes"`
+	// Type of the content
+	ContentType string `json:"content_type"`
+	// Date and time the object was last modified
+	LastModified string `json:"last_modified"`
+	// The pseudo-directory, i.e. the common prefix up to and including the delimiter,
+	// grouping the objects below it
+	Subdir string `json:"subdir"`
+}
+
+// marker returns the value to resume listing after this entry
+func (o ObjectSummary) marker() string {
+	if o.Subdir != "" {
+		return o.Subdir
+	}
+	return o.Name
+}
+
+// ListContainersInput defines the containers to list in the account
+type ListContainersInput struct {
+	// Only containers whose names begin with this prefix are returned
+	// Optional
+	Prefix string
+	// Only containers whose names sort after this value are returned
+	// Optional
+	Marker string
+	// Only containers whose names sort before this value are returned
+	// Optional
+	EndMarker string
+	// The maximum number of containers returned by each request to the API
+	// Optional - Defaults to the service's maximum, usually 10000
+	Limit int
+}
+
+// ListObjectsInput defines the objects to list in a container
+type ListObjectsInput struct {
+	// Name of the container
+	// Required
+	Container string
+	// Only objects whose names begin with this prefix are returned
+	// Optional
+	Prefix string
+	// Group objects whose names contain this character after the Prefix into
+	// pseudo-directories, returning the directory rather than the objects below it
+	// Optional
+	Delimiter string
+	// Only objects whose names sort after this value are returned
+	// Optional
+	Marker string
+	// Only objects whose names sort before this value are returned
+	// Optional
+	EndMarker string
+	// The maximum number of objects returned by each request to the API
+	// Optional - Defaults to the service's maximum, usually 10000
+	Limit int
+}
+
+// ListContainers returns every container in the account matching the input,
+// paging through the results as needed
+func (c *Client) ListContainers(input *ListContainersInput) ([]ContainerSummary, error) {
+	containers := []ContainerSummary{}
+	it := c.ContainerIterator(input)
+	for it.Next() {
+		containers = append(containers, it.Container())
+	}
+	return containers, it.Err()
+}
+
+// ListContainersPage returns a single page of containers matching the input, as
+// returned by a single request to the API. Set the Marker to the name of the last
+// container to request the next page.
+func (c *Client) ListContainersPage(input *ListContainersInput) ([]ContainerSummary, error) {
+	query := listQuery(input.Prefix, "", input.Marker, input.EndMarker, input.Limit)
+	containers := []ContainerSummary{}
+	if err := c.list(fmt.Sprintf("%s%s", apiVersion, c.getAccount()), query, &containers); err != nil {
+		return nil, err
+	}
+	return containers, nil
+}
+
+// ListObjects returns every object in the container matching the input,
+// paging through the results as needed
+func (c *ObjectClient) ListObjects(input *ListObjectsInput) ([]ObjectSummary, error) {
+	objects := []ObjectSummary{}
+	it := c.ObjectIterator(input)
+	for it.Next() {
+		objects = append(objects, it.Object())
+	}
+	return objects, it.Err()
+}
+
+// ListObjectsPage returns a single page of objects matching the input, as returned
+// by a single request to the API. Set the Marker to the name (or Subdir) of the last
+// object to request the next page.
+func (c *ObjectClient) ListObjectsPage(input *ListObjectsInput) ([]ObjectSummary, error) {
+	if input.Container == "" {
+		return nil, fmt.Errorf("Container must be set")
+	}
+	query := listQuery(input.Prefix, input.Delimiter, input.Marker, input.EndMarker, input.Limit)
+	objects := []ObjectSummary{}
+	if err := c.list(c.getQualifiedName(input.Container), query, &objects); err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+func listQuery(prefix, delimiter, marker, endMarker string, limit int) url.Values {
+	query := url.Values{}
+	query.Set("format", "json")
+	if prefix != "" {
+		query.Set("prefix", prefix)
+	}
+	if delimiter != "" {
+		query.Set("delimiter", delimiter)
+	}
+	if marker != "" {
+		query.Set("marker", marker)
+	}
+	if endMarker != "" {
+		query.Set("end_marker", endMarker)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	return query
+}
+
+// list requests a JSON listing of the account or container at the given path
+func (c *Client) list(path string, query url.Values, result interface{}) error {
+	resp, err := c.executeRequest("GET", fmt.Sprintf("%s?%s", path, query.Encode()), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	buf := new(bytes.Buffer)
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
+		return err
+	}
+	// Empty accounts and containers may be returned as a 204 with no body
+	if buf.Len() == 0 {
+		return nil
+	}
+	return json.Unmarshal(buf.Bytes(), result)
+}
+
+// ContainerIterator pages through the containers in an account
+type ContainerIterator struct {
+	client  *Client
+	input   ListContainersInput
+	page    []ContainerSummary
+	index   int
+	current ContainerSummary
+	done    bool
+	err     error
+}
+
+// ContainerIterator returns an iterator over the containers in the account matching the input.
+// Pages of containers are requested from the API as the iterator advances.
+func (c *Client) ContainerIterator(input *ListContainersInput) *ContainerIterator {
+	it := &ContainerIterator{client: c}
+	if input != nil {
+		it.input = *input
+	}
+	return it
+}
+
+// Next advances the iterator to the next container, returning false when there are no
+// more containers or an error occurred
+func (it *ContainerIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if it.index >= len(it.page) {
+		if it.done {
+			return false
+		}
+		page, err := it.client.ListContainersPage(&it.input)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.done = len(page) == 0 || (it.input.Limit > 0 && len(page) < it.input.Limit)
+		if len(page) == 0 {
+			return false
+		}
+		it.page = page
+		it.index = 0
+		it.input.Marker = page[len(page)-1].Name
+	}
+
+	it.current = it.page[it.index]
+	it.index++
+	return true
+}
+
+// Container returns the current container
+func (it *ContainerIterator) Container() ContainerSummary {
+	return it.current
+}
+
+// Err returns the error which stopped the iterator, if any
+func (it *ContainerIterator) Err() error {
+	return it.err
+}
+
+// ObjectIterator pages through the objects in a container
+type ObjectIterator struct {
+	client  *ObjectClient
+	input   ListObjectsInput
+	page    []ObjectSummary
+	index   int
+	current ObjectSummary
+	done    bool
+	err     error
+}
+
+// ObjectIterator returns an iterator over the objects in a container matching the input.
+// Pages of objects are requested from the API as the iterator advances.
+func (c *ObjectClient) ObjectIterator(input *ListObjectsInput) *ObjectIterator {
+	it := &ObjectIterator{client: c}
+	if input != nil {
+		it.input = *input
+	}
+	return it
+}
+
+// Next advances the iterator to the next object, returning false when there are no
+// more objects or an error occurred
+func (it *ObjectIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if it.index >= len(it.page) {
+		if it.done {
+			return false
+		}
+		page, err := it.client.ListObjectsPage(&it.input)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.done = len(page) == 0 || (it.input.Limit > 0 && len(page) < it.input.Limit)
+		if len(page) == 0 {
+			return false
+		}
+		it.page = page
+		it.index = 0
+		it.input.Marker = page[len(page)-1].marker()
+	}
+
+	it.current = it.page[it.index]
+	it.index++
+	return true
+}
+
+// Object returns the current object
+func (it *ObjectIterator) Object() ObjectSummary {
+	return it.current
+}
+
+// Err returns the error which stopped the iterator, if any
+func (it *ObjectIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,203 @@
+package storage
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const _TestListContainer = "list-container"
+
+func getListTestServer(t *testing.T, objects ...string) (*testServer, *Client) {
+	server := newTestServer()
+	client := server.getClient(t)
+	if _, err := client.CreateContainer(&CreateContainerInput{Name: _TestListContainer}); err != nil {
+		server.Close()
+		t.Fatalf("Error creating container: %s", err)
+	}
+	for _, name := range objects {
+		_, err := client.Objects().CreateObject(&CreateObjectInput{
+			Name:      name,
+			Container: _TestListContainer,
+			Body:      strings.NewReader(name),
+		})
+		if err != nil {
+			server.Close()
+			t.Fatalf("Error creating object %q: %s", name, err)
+		}
+	}
+	return server, client
+}
+
+func objectNames(objects []ObjectSummary) []string {
+	names := []string{}
+	for _, object := range objects {
+		if object.Subdir != "" {
+			names = append(names, object.Subdir)
+		} else {
+			names = append(names, object.Name)
+		}
+	}
+	return names
+}
+
+func TestClient_ListContainers(t *testing.T) {
+	server, client := getListTestServer(t)
+	defer server.Close()
+
+	for i := 0; i < 4; i++ {
+		if _, err := client.CreateContainer(&CreateContainerInput{Name: fmt.Sprintf("container-%d", i)}); err != nil {
+			t.Fatalf("Error creating container: %s", err)
+		}
+	}
+
+	containers, err := client.ListContainers(&ListContainersInput{Prefix: "container-", Limit: 3})
+	if err != nil {
+		t.Fatalf("Error listing containers: %s", err)
+	}
+
+	names := []string{}
+	for _, container := range containers {
+		names = append(names, container.Name)
+	}
+	assert.Equal(t, []string{"container-0", "container-1", "container-2", "container-3"}, names)
+	assert.Equal(t, 2, server.requestCount("GET", "/v1/Storage-"+_TestServerDomain))
+}
+
+func TestClient_ListContainersPage(t *testing.T) {
+	server, client := getListTestServer(t)
+	defer server.Close()
+
+	for _, name := range []string{"a", "b", "c", "d"} {
+		if _, err := client.CreateContainer(&CreateContainerInput{Name: name}); err != nil {
+			t.Fatalf("Error creating container: %s", err)
+		}
+	}
+
+	containers, err := client.ListContainersPage(&ListContainersInput{Marker: "a", EndMarker: "d"})
+	if err != nil {
+		t.Fatalf("Error listing containers: %s", err)
+	}
+	assert.Len(t, containers, 2)
+	assert.Equal(t, "b", containers[0].Name)
+	assert.Equal(t, "c", containers[1].Name)
+}
+
+func TestObjectClient_ListObjects(t *testing.T) {
+	names := []string{}
+	for i := 0; i < 25; i++ {
+		names = append(names, fmt.Sprintf("object-%02d", i))
+	}
+	server, client := getListTestServer(t, names...)
+	defer server.Close()
+
+	path := "/v1/Storage-" + _TestServerDomain + "/" + _TestListContainer
+	requests := server.requestCount("GET", path)
+	objects, err := client.Objects().ListObjects(&ListObjectsInput{
+		Container: _TestListContainer,
+		Limit:     10,
+	})
+	if err != nil {
+		t.Fatalf("Error listing objects: %s", err)
+	}
+
+	assert.Equal(t, names, objectNames(objects))
+	assert.Equal(t, requests+3, server.requestCount("GET", path))
+	assert.Equal(t, int64(len("object-00")), objects[0].Bytes)
+	assert.Equal(t, testMD5([]byte("object-00")), objects[0].Hash)
+}
+
+func TestObjectClient_ListObjectsEmpty(t *testing.T) {
+	server, client := getListTestServer(t)
+	defer server.Close()
+
+	objects, err := client.Objects().ListObjects(&ListObjectsInput{Container: _TestListContainer})
+	if err != nil {
+		t.Fatalf("Error listing objects: %s", err)
+	}
+	assert.Empty(t, objects)
+}
+
+func TestObjectClient_ListObjectsDelimiter(t *testing.T) {
+	server, client := getListTestServer(t,
+		"photos/2017/a.jpg",
+		"photos/2017/b.jpg",
+		"photos/2018/c.jpg",
+		"photos/index.html",
+		"videos/d.mp4",
+		"readme.txt",
+	)
+	defer server.Close()
+
+	objects, err := client.Objects().ListObjects(&ListObjectsInput{
+		Container: _TestListContainer,
+		Delimiter: "/",
+		Limit:     1,
+	})
+	if err != nil {
+		t.Fatalf("Error listing objects: %s", err)
+	}
+	assert.Equal(t, []string{"photos/", "readme.txt", "videos/"}, objectNames(objects))
+	assert.Empty(t, objects[0].Name)
+	assert.Equal(t, "readme.txt", objects[1].Name)
+
+	objects, err = client.Objects().ListObjects(&ListObjectsInput{
+		Container: _TestListContainer,
+		Prefix:    "photos/",
+		Delimiter: "/",
+	})
+	if err != nil {
+		t.Fatalf("Error listing objects: %s", err)
+	}
+	assert.Equal(t, []string{"photos/2017/", "photos/2018/", "photos/index.html"}, objectNames(objects))
+}
+
+func TestObjectClient_ObjectIterator(t *testing.T) {
+	server, client := getListTestServer(t, "a", "b", "c", "d", "e")
+	defer server.Close()
+
+	it := client.Objects().ObjectIterator(&ListObjectsInput{
+		Container: _TestListContainer,
+		Marker:    "a",
+		EndMarker: "e",
+		Limit:     2,
+	})
+	names := []string{}
+	for it.Next() {
+		names = append(names, it.Object().Name)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Error iterating objects: %s", err)
+	}
+	assert.Equal(t, []string{"b", "c", "d"}, names)
+}
+
+func TestObjectClient_ObjectIteratorError(t *testing.T) {
+	server, client := getListTestServer(t, "a", "b", "c")
+	defer server.Close()
+
+	it := client.Objects().ObjectIterator(&ListObjectsInput{
+		Container: _TestListContainer,
+		Limit:     2,
+	})
+	assert.True(t, it.Next())
+	assert.True(t, it.Next())
+
+	server.intercept = func(r *http.Request) int {
+		return http.StatusInternalServerError
+	}
+	assert.False(t, it.Next())
+	assert.Error(t, it.Err())
+	assert.False(t, it.Next())
+}
+
+func TestObjectClient_ListObjectsMissingContainer(t *testing.T) {
+	server, client := getListTestServer(t)
+	defer server.Close()
+
+	_, err := client.Objects().ListObjects(&ListObjectsInput{Container: "missing"})
+	assert.Error(t, err)
+}
@@ -23,9 +23,8 @@ type ObjectClient struct {
 func (c *Client) Objects() *ObjectClient {
 	return &ObjectClient{
 		Client: Client{
-			client:      c.client,
-			authToken:   c.authToken,
-			tokenIssued: c.tokenIssued,
+			client: c.client,
+			auth:   c.auth,
 		},
 	}
 }
@@ -45,6 +44,7 @@ const (
 	hNewest             = "X-Newest"
 	hObjectManifest     = "X-Object-Manifest"
 	hRange              = "Range"
+	hStaticLargeObject  = "X-Static-Large-Object"
 	hTimestamp          = "X-Timestamp"
 	hTransactionID      = "X-Trans-Id"
 	hTransferEncoding   = "Transfer-Encoding"
@@ -84,6 +84,8 @@ type ObjectInfo struct {
 	DeleteAt int
 	// Optional: The dynamic large object manifest object.
 	ObjectManifest string
+	// Whether the object is a static large object manifest
+	StaticLargeObject bool
 	// Optional: The map of object metadata name values pairs for X-Object-Meta-{name}
 	ObjectMetadata map[string]string
 	// Date and time in UNIX EPOCH when the account, container, _or_ object
@@ -127,6 +129,10 @@ type CreateObjectInput struct {
 	// MD5 checksum value of the request body. Unquoted
 	// Strongly recommended, not required.
 	ETag string
+	// Create a dynamic large object manifest, whose content is the concatenation of the
+	// objects with the given `container/prefix`. The Body may be empty.
+	// Optional
+	ObjectManifest string
 	// TODO: If-None-Match.
 
 	// Sets the transfer encoding. Can only be "chunked" or nil.
@@ -138,9 +144,28 @@ type CreateObjectInput struct {
 
 // CreateObject creates a new Object inside of a container.
 func (c *ObjectClient) CreateObject(input *CreateObjectInput) (*ObjectInfo, error) {
-	headers := make(map[string]string)
-
 	name := c.getQualifiedName(fmt.Sprintf("%s/%s", input.Container, input.Name))
+	headers := c.createObjectHeaders(input)
+
+	if input.Body == nil && input.CopyFrom == "" && input.ObjectManifest == "" {
+		return nil, fmt.Errorf("Body cannot be nil")
+	}
+
+	if err := c.createResourceBody(name, headers, input.Body); err != nil {
+		return nil, err
+	}
+
+	getInput := &GetObjectInput{
+		Name:      input.Name,
+		Container: input.Container,
+	}
+
+	return c.GetObject(getInput)
+}
+
+// createObjectHeaders returns the request headers used to create an object
+func (c *ObjectClient) createObjectHeaders(input *CreateObjectInput) map[string]string {
+	headers := make(map[string]string)
 
 	if input.ContentDisposition != "" {
 		headers[hContentDisposition] = input.ContentDisposition
@@ -160,6 +185,9 @@ func (c *ObjectClient) CreateObject(input *CreateObjectInput) (*ObjectInfo, erro
 	if input.CopyFrom != "" {
 		headers[hCopyFrom] = input.CopyFrom
 	}
+	if input.ObjectManifest != "" {
+		headers[hObjectManifest] = input.ObjectManifest
+	}
 	if input.DeleteAt != 0 {
 		headers[hDeleteAt] = fmt.Sprintf("%d", input.DeleteAt)
 	}
@@ -172,20 +200,7 @@ func (c *ObjectClient) CreateObject(input *CreateObjectInput) (*ObjectInfo, erro
 		}
 	}
 
-	if input.Body == nil && input.CopyFrom == "" {
-		return nil, fmt.Errorf("Body cannot be nil")
-	}
-
-	if err := c.createResourceBody(name, headers, input.Body); err != nil {
-		return nil, err
-	}
-
-	getInput := &GetObjectInput{
-		Name:      input.Name,
-		Container: input.Container,
-	}
-
-	return c.GetObject(getInput)
+	return headers
 }
 
 // GetObjectInput details on a storage object
@@ -290,6 +305,7 @@ func (c *ObjectClient) success(resp *http.Response, object *ObjectInfo) (*Object
 	object.Etag = resp.Header.Get(hETag)
 	object.LastModified = resp.Header.Get(hLastModified)
 	object.ObjectManifest = resp.Header.Get(hObjectManifest)
+	object.StaticLargeObject = strings.EqualFold(resp.Header.Get(hStaticLargeObject), "true")
 	object.Timestamp = resp.Header.Get(hTimestamp)
 	object.TransactionID = resp.Header.Get(hTransactionID)
 
@@ -0,0 +1,453 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-oracle-terraform/opc"
+)
+
+const (
+	_TestServerDomain = "test-domain"
+	_TestServerToken  = "test-token"
+)
+
+// testServer is an in-memory fake of the Storage API, supporting containers,
+// objects and static and dynamic large objects
+type testServer struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	containers map[string]*testContainer
+	// Called before each authenticated request is handled. Returning a non-zero
+	// status code fails the request with that status.
+	intercept func(r *http.Request) int
+	// Counts the requests received, by method and path
+	requests map[string]int
+}
+
+type testContainer struct {
+	headers http.Header
+	objects map[string]*testObject
+}
+
+type testObject struct {
+	data    []byte
+	headers http.Header
+	// The segments of a static large object
+	segments []string
+}
+
+func newTestServer() *testServer {
+	s := &testServer{
+		containers: map[string]*testContainer{},
+		requests:   map[string]int{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+func (s *testServer) getClient(t *testing.T) *Client {
+	endpoint, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := NewStorageClient(&opc.Config{
+		IdentityDomain: opc.String(_TestServerDomain),
+		Username:       opc.String("test-user"),
+		Password:       opc.String("test-password"),
+		APIEndpoint:    endpoint,
+		HTTPClient:     s.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Error creating storage client: %s", err)
+	}
+	return client
+}
+
+func (s *testServer) requestCount(method, path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[method+" "+path]
+}
+
+func (s *testServer) handle(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/auth/v1.0" {
+		w.Header().Set("X-Auth-Token", _TestServerToken)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.URL.Query().Get(qTempURLSig) != "" {
+		if !s.verifyTempURL(r) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+	} else if r.Header.Get(authHeader) != _TestServerToken {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	s.requests[r.Method+" "+r.URL.Path]++
+	if s.intercept != nil {
+		if status := s.intercept(r); status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+	}
+
+	prefix := fmt.Sprintf("/%s/Storage-%s/", apiVersion, _TestServerDomain)
+	if r.URL.Path == strings.TrimSuffix(prefix, "/") && r.Method == "GET" {
+		s.listContainers(w, r)
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, prefix), "/", 2)
+	if len(parts) == 1 {
+		s.handleContainer(w, r, parts[0])
+	} else {
+		s.handleObject(w, r, parts[0], parts[1])
+	}
+}
+
+func (s *testServer) handleContainer(w http.ResponseWriter, r *http.Request, name string) {
+	container, ok := s.containers[name]
+	switch r.Method {
+	case "PUT", "POST":
+		if !ok {
+			container = &testContainer{headers: http.Header{}, objects: map[string]*testObject{}}
+			s.containers[name] = container
+		}
+		for k, v := range r.Header {
+			if strings.HasPrefix(k, "X-Container-") {
+				container.headers[k] = v
+			}
+		}
+		w.WriteHeader(http.StatusCreated)
+	case "GET", "HEAD":
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		for k, v := range container.headers {
+			w.Header()[k] = v
+		}
+		w.Header().Set("X-Container-Object-Count", strconv.Itoa(len(container.objects)))
+		if r.Method == "GET" {
+			s.listObjects(w, r, container)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case "DELETE":
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if len(container.objects) > 0 {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		delete(s.containers, name)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *testServer) handleObject(w http.ResponseWriter, r *http.Request, containerName, name string) {
+	container, ok := s.containers[containerName]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	object, ok := container.objects[name]
+
+	switch r.Method {
+	case "PUT":
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		object = &testObject{data: data, headers: http.Header{}}
+		for k, v := range r.Header {
+			if k == hContentType || k == hObjectManifest || strings.HasPrefix(k, hMetadataPrefix) {
+				object.headers[k] = v
+			}
+		}
+
+		if r.URL.Query().Get("multipart-manifest") == "put" {
+			if status := s.createStaticLargeObject(object, data); status != 0 {
+				w.WriteHeader(status)
+				return
+			}
+		} else {
+			etag := testMD5(data)
+			if expected := r.Header.Get(hETag); expected != "" && expected != etag {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			object.headers.Set(hETag, etag)
+		}
+
+		container.objects[name] = object
+		w.Header().Set(hETag, object.headers.Get(hETag))
+		w.WriteHeader(http.StatusCreated)
+	case "GET", "HEAD":
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		data := s.objectContent(object)
+		for k, v := range object.headers {
+			w.Header()[k] = v
+		}
+		w.Header().Set(hContentLength, strconv.Itoa(len(data)))
+		w.WriteHeader(http.StatusOK)
+		if r.Method == "GET" {
+			_, _ = w.Write(data)
+		}
+	case "DELETE":
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(container.objects, name)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// verifyTempURL checks the signature, expiry and scope of a temporary URL against
+// the keys of the container it refers to
+func (s *testServer) verifyTempURL(r *http.Request) bool {
+	query := r.URL.Query()
+	expires, err := strconv.ParseInt(query.Get(qTempURLExpires), 10, 64)
+	if err != nil || time.Now().Unix() >= expires {
+		return false
+	}
+
+	parts := strings.SplitN(r.URL.Path, "/", 5)
+	if len(parts) != 5 {
+		return false
+	}
+	container, ok := s.containers[parts[3]]
+	if !ok {
+		return false
+	}
+
+	path := r.URL.Path
+	if prefix, ok := query[qTempURLPrefix]; ok {
+		if !strings.HasPrefix(parts[4], prefix[0]) {
+			return false
+		}
+		path = "prefix:" + strings.Join(parts[:4], "/") + "/" + prefix[0]
+	}
+
+	bodyPrefix := ""
+	if ipRange := query.Get(qTempURLIPRange); ipRange != "" {
+		host, _, _ := net.SplitHostPort(r.RemoteAddr)
+		ip := net.ParseIP(host)
+		if _, cidr, err := net.ParseCIDR(ipRange); err == nil {
+			if !cidr.Contains(ip) {
+				return false
+			}
+		} else if !ip.Equal(net.ParseIP(ipRange)) {
+			return false
+		}
+		bodyPrefix = fmt.Sprintf("ip=%s\n", ipRange)
+	}
+
+	digest := sha1.New
+	if len(query.Get(qTempURLSig)) == sha256.Size*2 {
+		digest = sha256.New
+	}
+
+	methods := []string{r.Method}
+	if r.Method == "HEAD" {
+		methods = append(methods, "GET")
+	}
+	for _, key := range []string{container.headers.Get(hTempURLKey), container.headers.Get(hTempURLKey2)} {
+		if key == "" {
+			continue
+		}
+		for _, method := range methods {
+			mac := hmac.New(digest, []byte(key))
+			fmt.Fprintf(mac, "%s%s\n%d\n%s", bodyPrefix, method, expires, path)
+			if hmac.Equal([]byte(hex.EncodeToString(mac.Sum(nil))), []byte(query.Get(qTempURLSig))) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *testServer) listContainers(w http.ResponseWriter, r *http.Request) {
+	listing := []map[string]interface{}{}
+	for _, name := range listNames(r.URL.Query(), s.containers) {
+		container := s.containers[name]
+		listing = append(listing, map[string]interface{}{
+			"name":  name,
+			"count": len(container.objects),
+			"bytes": 0,
+		})
+	}
+	writeListing(w, listing)
+}
+
+func (s *testServer) listObjects(w http.ResponseWriter, r *http.Request, container *testContainer) {
+	listing := []map[string]interface{}{}
+	for _, name := range listNames(r.URL.Query(), container.objects) {
+		object, ok := container.objects[name]
+		if !ok {
+			listing = append(listing, map[string]interface{}{"subdir": name})
+			continue
+		}
+		listing = append(listing, map[string]interface{}{
+			"name":         name,
+			"hash":         strings.Trim(object.headers.Get(hETag), `"`),
+			"bytes":        len(s.objectContent(object)),
+			"content_type": object.headers.Get(hContentType),
+		})
+	}
+	writeListing(w, listing)
+}
+
+// listNames returns the sorted keys of a map of containers or objects which match
+// the listing query, grouping names into pseudo-directories when a delimiter is given
+func listNames(query url.Values, entries interface{}) []string {
+	var names []string
+	switch entries := entries.(type) {
+	case map[string]*testContainer:
+		for name := range entries {
+			names = append(names, name)
+		}
+	case map[string]*testObject:
+		for name := range entries {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	prefix, delimiter := query.Get("prefix"), query.Get("delimiter")
+	marker, endMarker := query.Get("marker"), query.Get("end_marker")
+	limit := 10000
+	if l, err := strconv.Atoi(query.Get("limit")); err == nil {
+		limit = l
+	}
+
+	result := []string{}
+	for _, name := range names {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if delimiter != "" {
+			if i := strings.Index(name[len(prefix):], delimiter); i >= 0 {
+				name = name[:len(prefix)+i+len(delimiter)]
+			}
+		}
+		if name <= marker || (endMarker != "" && name >= endMarker) {
+			continue
+		}
+		if len(result) > 0 && result[len(result)-1] == name {
+			continue
+		}
+		if len(result) == limit {
+			break
+		}
+		result = append(result, name)
+	}
+	return result
+}
+
+func writeListing(w http.ResponseWriter, listing []map[string]interface{}) {
+	if len(listing) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set(hContentType, "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(listing)
+}
+
+func (s *testServer) createStaticLargeObject(object *testObject, manifest []byte) int {
+	var segments []segment
+	if err := json.Unmarshal(manifest, &segments); err != nil {
+		return http.StatusBadRequest
+	}
+
+	var etags string
+	for _, seg := range segments {
+		parts := strings.SplitN(strings.TrimPrefix(seg.Path, "/"), "/", 2)
+		container, ok := s.containers[parts[0]]
+		if !ok {
+			return http.StatusBadRequest
+		}
+		segObject, ok := container.objects[parts[1]]
+		if !ok || segObject.headers.Get(hETag) != seg.ETag || int64(len(segObject.data)) != seg.Size {
+			return http.StatusBadRequest
+		}
+		object.segments = append(object.segments, seg.Path)
+		etags += seg.ETag
+	}
+
+	object.data = nil
+	object.headers.Set(hETag, fmt.Sprintf("%q", testMD5([]byte(etags))))
+	object.headers.Set(hStaticLargeObject, "True")
+	return 0
+}
+
+// objectContent returns the content of an object, joining the segments of large objects
+func (s *testServer) objectContent(object *testObject) []byte {
+	var buf bytes.Buffer
+	switch {
+	case object.segments != nil:
+		for _, path := range object.segments {
+			parts := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)
+			buf.Write(s.containers[parts[0]].objects[parts[1]].data)
+		}
+	case object.headers.Get(hObjectManifest) != "":
+		parts := strings.SplitN(object.headers.Get(hObjectManifest), "/", 2)
+		container, ok := s.containers[parts[0]]
+		if !ok {
+			break
+		}
+		names := []string{}
+		for name := range container.objects {
+			if strings.HasPrefix(name, parts[1]) {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			buf.Write(container.objects[name].data)
+		}
+	default:
+		buf.Write(object.data)
+	}
+	return buf.Bytes()
+}
+
+func testMD5(data []byte) string {
+	sum := md5.Sum(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -1,11 +1,11 @@
 package storage
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
-	"time"
 
 	"github.com/hashicorp/go-oracle-terraform/client"
 	"github.com/hashicorp/go-oracle-terraform/opc"
@@ -19,27 +19,46 @@ const apiVersion = "v1"
 
 // Client represents an authenticated storage client, with storage credentials and an api client.
 type Client struct {
-	client      *client.Client
-	authToken   *string
-	tokenIssued time.Time
+	client *client.Client
+	auth   *client.TokenManager
 }
 
 // NewStorageClient returns an authenticate storage client
 func NewStorageClient(c *opc.Config) (*Client, error) {
-	sClient := &Client{}
 	opcClient, err := client.NewClient(c)
 	if err != nil {
 		return nil, err
 	}
-	sClient.client = opcClient
 
-	if err := sClient.getAuthenticationToken(); err != nil {
+	// Authentication requests are made by a client which doesn't authenticate itself
+	authClient := &Client{client: opcClient}
+	auth := &client.TokenManager{
+		Header:      authHeader,
+		Credentials: opcClient.Credentials(),
+		Fetch:       authClient.getAuthenticationToken,
+	}
+	sClient := &Client{
+		client: opcClient.WithAuthenticator(auth),
+		auth:   auth,
+	}
+
+	if _, err := auth.Token(opcClient.Context()); err != nil {
 		return nil, err
 	}
 
 	return sClient, nil
 }
 
+// WithContext returns a copy of the storage client bound to the given context.
+// Container and object clients obtained from the returned client will abort
+// their requests once the context is done.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	c2 := new(Client)
+	*c2 = *c
+	c2.client = c.client.WithContext(ctx)
+	return c2
+}
+
 // Execute a request with a nil body
 func (c *Client) executeRequest(method, path string, headers interface{}) (*http.Response, error) {
 	return c.executeRequestBody(method, path, headers, nil)
@@ -66,20 +85,11 @@ func (c *Client) executeRequestBody(method, path string, headers interface{}, bo
 		debugReqString = fmt.Sprintf("%s\n%s", debugReqString, debugHeaders)
 	}
 
+	// Don't leak credentials, the authentication token is added by the client
 	if !strings.Contains(path, "/auth/") {
 		c.client.DebugLogString(debugReqString)
 	}
 
-	// If we have an authentication token, let's authenticate, refreshing cookie if need be
-	if c.authToken != nil {
-		if time.Since(c.tokenIssued).Minutes() > 25 {
-			if err = c.getAuthenticationToken(); err != nil {
-				return nil, err
-			}
-		}
-		req.Header.Add(authHeader, *c.authToken)
-	}
-
 	resp, err := c.client.ExecuteRequest(req)
 	if err != nil {
 		return nil, err
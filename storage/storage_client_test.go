@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-oracle-terraform/helper"
+)
+
+// TestClient_Concurrent creates containers and objects in parallel through a single client, while its
+// authentication token is rejected and refreshed. Run with -race to check the client isn't modified
+// by its operations.
+func TestClient_Concurrent(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+	client := server.getClient(t)
+
+	// The first request received creates a container, so doesn't have a body to send again
+	rejected := false
+	server.intercept = func(r *http.Request) int {
+		if rejected {
+			return 0
+		}
+		rejected = true
+		return http.StatusUnauthorized
+	}
+
+	objects := client.Objects()
+	helper.RunConcurrently(t, 20, func(i int) error {
+		container := fmt.Sprintf("container-%d", i)
+		if _, err := client.CreateContainer(&CreateContainerInput{Name: container}); err != nil {
+			return fmt.Errorf("Error creating container %s: %s", container, err)
+		}
+		_, err := objects.CreateObject(&CreateObjectInput{
+			Name:      "object",
+			Container: container,
+			Body:      strings.NewReader(container),
+		})
+		if err != nil {
+			return fmt.Errorf("Error creating object in %s: %s", container, err)
+		}
+		info, err := objects.GetObject(&GetObjectInput{Name: "object", Container: container})
+		if err != nil {
+			return fmt.Errorf("Error getting object in %s: %s", container, err)
+		}
+		if info.Container != container || info.ContentLength != len(container) {
+			return fmt.Errorf("Expected the object in %s, got %+v", container, info)
+		}
+		return nil
+	})
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if !rejected {
+		t.Fatal("Expected the authentication token to be rejected")
+	}
+}
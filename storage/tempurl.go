@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TempURLDigest defines the HMAC digest used to sign a temporary URL
+type TempURLDigest string
+
+const (
+	// TempURLDigestSHA1 signs temporary URLs with HMAC-SHA1, supported by every version of the service
+	TempURLDigestSHA1 TempURLDigest = "sha1"
+	// TempURLDigestSHA256 signs temporary URLs with HMAC-SHA256
+	TempURLDigestSHA256 TempURLDigest = "sha256"
+)
+
+// Query parameters of a temporary URL
+const (
+	qTempURLSig     = "temp_url_sig"
+	qTempURLExpires = "temp_url_expires"
+	qTempURLIPRange = "temp_url_ip_range"
+	qTempURLPrefix  = "temp_url_prefix"
+)
+
+// CreateTempURLInput defines a temporary URL granting access to an object, or to
+// every object with a given prefix, without authenticating
+type CreateTempURLInput struct {
+	// Name of the container
+	// Required
+	Container string
+	// Name of the object
+	// Required, unless Prefix is set
+	Name string
+	// Sign the URL for every object whose name begins with this prefix, instead of a single object.
+	// Use TempURL.ObjectURL to get the URL of an object under the prefix.
+	// Optional
+	Prefix string
+	// The HTTP method the URL may be used with: GET, PUT, HEAD or DELETE.
+	// A URL signed for GET may also be used with HEAD.
+	// Optional - Defaults to GET
+	Method string
+	// The time the URL expires
+	// Required, unless TTL is set
+	Expires time.Time
+	// The duration, from now, the URL is valid for
+	// Required, unless Expires is set
+	TTL time.Duration
+	// Only allow requests from this IP address or CIDR range, e.g. 192.168.0.0/24
+	// Optional
+	IPRange string
+	// The secret key to sign the URL with
+	// Optional - Defaults to the container's PrimaryKey, or SecondaryKey if no primary key is set
+	Key string
+	// The digest to sign the URL with
+	// Optional - Defaults to TempURLDigestSHA1
+	Digest TempURLDigest
+}
+
+// TempURL describes a signed temporary URL
+type TempURL struct {
+	// The signed URL of the object, or of the prefix for prefix-scoped URLs
+	URL string
+	// The HTTP method the URL is signed for
+	Method string
+	// The time the URL expires
+	Expires time.Time
+
+	endpoint *url.URL
+	query    url.Values
+}
+
+// ObjectURL returns the signed URL of an object under the prefix of a prefix-scoped
+// temporary URL, or of the signed object if the URL isn't prefix-scoped
+func (t *TempURL) ObjectURL(name string) (string, error) {
+	prefix := t.query.Get(qTempURLPrefix)
+	if prefix == "" {
+		return t.URL, nil
+	}
+	if !strings.HasPrefix(name, prefix) {
+		return "", fmt.Errorf("Object %q is not under the signed prefix %q", name, prefix)
+	}
+	u := *t.endpoint
+	u.Path = strings.TrimSuffix(u.Path, prefix) + name
+	u.RawPath = ""
+	u.RawQuery = t.query.Encode()
+	return u.String(), nil
+}
+
+// CreateTempURL signs a temporary URL allowing the object, or every object with the
+// given prefix, to be accessed without authenticating until the URL expires
+func (c *ObjectClient) CreateTempURL(input *CreateTempURLInput) (*TempURL, error) {
+	if input.Container == "" {
+		return nil, fmt.Errorf("Container must be set")
+	}
+	if input.Name == "" && input.Prefix == "" {
+		return nil, fmt.Errorf("Either Name or Prefix must be set")
+	}
+
+	method := strings.ToUpper(input.Method)
+	switch method {
+	case "":
+		method = "GET"
+	case "GET", "PUT", "HEAD", "DELETE":
+	default:
+		return nil, fmt.Errorf("Unsupported temporary URL method %q", input.Method)
+	}
+
+	expires := input.Expires
+	if expires.IsZero() {
+		if input.TTL <= 0 {
+			return nil, fmt.Errorf("Either Expires or TTL must be set")
+		}
+		expires = time.Now().Add(input.TTL)
+	}
+
+	var digest func() hash.Hash
+	switch input.Digest {
+	case "", TempURLDigestSHA1:
+		digest = sha1.New
+	case TempURLDigestSHA256:
+		digest = sha256.New
+	default:
+		return nil, fmt.Errorf("Unsupported temporary URL digest %q", input.Digest)
+	}
+
+	key := input.Key
+	if key == "" {
+		container, err := c.GetContainer(&GetContainerInput{Name: input.Container})
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving temporary URL key for container %s: %s", input.Container, err)
+		}
+		key = container.PrimaryKey
+		if key == "" {
+			key = container.SecondaryKey
+		}
+		if key == "" {
+			return nil, fmt.Errorf("Container %s has no temporary URL key", input.Container)
+		}
+	}
+
+	name := input.Name
+	if input.Prefix != "" {
+		name = input.Prefix
+	}
+	// The signature covers the unescaped path of the URL
+	endpoint := c.client.APIEndpoint.ResolveReference(&url.URL{
+		Path: fmt.Sprintf("%s/%s", c.getQualifiedName(input.Container), name),
+	})
+	path := endpoint.Path
+	if input.Prefix != "" {
+		path = "prefix:" + path
+	}
+
+	body := fmt.Sprintf("%s\n%d\n%s", method, expires.Unix(), path)
+	if input.IPRange != "" {
+		body = fmt.Sprintf("ip=%s\n%s", input.IPRange, body)
+	}
+	mac := hmac.New(digest, []byte(key))
+	_, _ = mac.Write([]byte(body))
+
+	query := url.Values{}
+	query.Set(qTempURLSig, hex.EncodeToString(mac.Sum(nil)))
+	query.Set(qTempURLExpires, strconv.FormatInt(expires.Unix(), 10))
+	if input.IPRange != "" {
+		query.Set(qTempURLIPRange, input.IPRange)
+	}
+	if input.Prefix != "" {
+		query.Set(qTempURLPrefix, input.Prefix)
+	}
+
+	u := *endpoint
+	u.RawQuery = query.Encode()
+	return &TempURL{
+		URL:      u.String(),
+		Method:   method,
+		Expires:  expires,
+		endpoint: endpoint,
+		query:    query,
+	}, nil
+}
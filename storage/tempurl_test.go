@@ -0,0 +1,278 @@
+package storage
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	_TestTempURLContainer = "tempurl-container"
+	_TestTempURLKey       = "test-secret"
+)
+
+func getTempURLTestServer(t *testing.T, key string, objects ...string) (*testServer, *ObjectClient) {
+	server := newTestServer()
+	client := server.getClient(t)
+	_, err := client.CreateContainer(&CreateContainerInput{
+		Name:       _TestTempURLContainer,
+		PrimaryKey: key,
+	})
+	if err != nil {
+		server.Close()
+		t.Fatalf("Error creating container: %s", err)
+	}
+	for _, name := range objects {
+		_, err := client.Objects().CreateObject(&CreateObjectInput{
+			Name:      name,
+			Container: _TestTempURLContainer,
+			Body:      strings.NewReader(name),
+		})
+		if err != nil {
+			server.Close()
+			t.Fatalf("Error creating object %q: %s", name, err)
+		}
+	}
+	return server, client.Objects()
+}
+
+// doTempURLRequest makes an unauthenticated request to the URL, returning the status code
+func doTempURLRequest(t *testing.T, server *testServer, method, url, body string) int {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("Error requesting temporary URL: %s", err)
+	}
+	defer resp.Body.Close()
+	_, _ = ioutil.ReadAll(resp.Body)
+	return resp.StatusCode
+}
+
+func TestObjectClient_CreateTempURL(t *testing.T) {
+	server, client := getTempURLTestServer(t, _TestTempURLKey, "object")
+	defer server.Close()
+
+	tempURL, err := client.CreateTempURL(&CreateTempURLInput{
+		Container: _TestTempURLContainer,
+		Name:      "object",
+		TTL:       time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("Error creating temporary URL: %s", err)
+	}
+
+	assert.Equal(t, "GET", tempURL.Method)
+	assert.True(t, strings.HasPrefix(tempURL.URL, server.URL+"/v1/Storage-"+_TestServerDomain+"/"+_TestTempURLContainer+"/object?"))
+	assert.Equal(t, http.StatusOK, doTempURLRequest(t, server, "GET", tempURL.URL, ""))
+	assert.Equal(t, http.StatusOK, doTempURLRequest(t, server, "HEAD", tempURL.URL, ""))
+	assert.Equal(t, http.StatusUnauthorized, doTempURLRequest(t, server, "DELETE", tempURL.URL, ""))
+}
+
+// The signatures of the object and IP range examples are those published in the OpenStack Swift
+// tempurl middleware documentation, which signs with the key "mykey". The prefix signature is the
+// HMAC-SHA256 of the documented "prefix:" body with the same key and expiry, computed with Python's hmac.
+// The containers are qualified names, so that the signed path is the documented /v1/AUTH_account one.
+func TestObjectClient_CreateTempURLKnownAnswers(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+	client := server.getClient(t).Objects()
+
+	for _, test := range []struct {
+		name      string
+		input     CreateTempURLInput
+		signature string
+	}{
+		{
+			name: "object",
+			input: CreateTempURLInput{
+				Container: "v1/AUTH_account/container",
+				Name:      "object",
+				Expires:   time.Unix(1512508563, 0),
+			},
+			signature: "732fcac368abb10c78a4cbe95c3fab7f311584532bf779abd5074e13cbe8b88b",
+		},
+		{
+			name: "ip range",
+			input: CreateTempURLInput{
+				Container: "v1/AUTH_account/container",
+				Name:      "object",
+				Expires:   time.Unix(1648082711, 0),
+				IPRange:   "1.2.3.4",
+			},
+			signature: "3f48476acaf5ec272acd8e99f7b5bad96c52ddba53ed27c60613711774a06f0c",
+		},
+		{
+			name: "prefix",
+			input: CreateTempURLInput{
+				Container: "v1/AUTH_account/container",
+				Prefix:    "pre",
+				Expires:   time.Unix(1512508563, 0),
+			},
+			signature: "32f398a48a1a8ca6f2711efcca444100723360239733c6e7b31d868f62f66b47",
+		},
+	} {
+		test.input.Key = "mykey"
+		test.input.Digest = TempURLDigestSHA256
+		tempURL, err := client.CreateTempURL(&test.input)
+		if err != nil {
+			t.Fatalf("Error creating temporary URL: %s", err)
+		}
+		u, err := url.Parse(tempURL.URL)
+		if err != nil {
+			t.Fatalf("Error parsing temporary URL: %s", err)
+		}
+		assert.Equal(t, test.signature, u.Query().Get(qTempURLSig), test.name)
+		assert.Equal(t, strconv.FormatInt(test.input.Expires.Unix(), 10), u.Query().Get(qTempURLExpires), test.name)
+	}
+}
+
+func TestObjectClient_CreateTempURLMethods(t *testing.T) {
+	server, client := getTempURLTestServer(t, _TestTempURLKey)
+	defer server.Close()
+
+	for _, digest := range []TempURLDigest{TempURLDigestSHA1, TempURLDigestSHA256} {
+		put, err := client.CreateTempURL(&CreateTempURLInput{
+			Container: _TestTempURLContainer,
+			Name:      "uploaded",
+			Method:    "put",
+			TTL:       time.Hour,
+			Digest:    digest,
+		})
+		if err != nil {
+			t.Fatalf("Error creating temporary URL: %s", err)
+		}
+		assert.Equal(t, http.StatusCreated, doTempURLRequest(t, server, "PUT", put.URL, "content"))
+		assert.Equal(t, http.StatusUnauthorized, doTempURLRequest(t, server, "GET", put.URL, ""))
+
+		del, err := client.CreateTempURL(&CreateTempURLInput{
+			Container: _TestTempURLContainer,
+			Name:      "uploaded",
+			Method:    "DELETE",
+			Expires:   time.Now().Add(time.Minute),
+			Key:       _TestTempURLKey,
+			Digest:    digest,
+		})
+		if err != nil {
+			t.Fatalf("Error creating temporary URL: %s", err)
+		}
+		assert.Equal(t, http.StatusNoContent, doTempURLRequest(t, server, "DELETE", del.URL, ""))
+	}
+}
+
+func TestObjectClient_CreateTempURLExpired(t *testing.T) {
+	server, client := getTempURLTestServer(t, _TestTempURLKey, "object")
+	defer server.Close()
+
+	tempURL, err := client.CreateTempURL(&CreateTempURLInput{
+		Container: _TestTempURLContainer,
+		Name:      "object",
+		Expires:   time.Now().Add(-time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("Error creating temporary URL: %s", err)
+	}
+	assert.Equal(t, http.StatusUnauthorized, doTempURLRequest(t, server, "GET", tempURL.URL, ""))
+}
+
+func TestObjectClient_CreateTempURLIPRange(t *testing.T) {
+	server, client := getTempURLTestServer(t, _TestTempURLKey, "object")
+	defer server.Close()
+
+	for ipRange, status := range map[string]int{
+		"127.0.0.0/8":  http.StatusOK,
+		"127.0.0.1":    http.StatusOK,
+		"10.0.0.0/24":  http.StatusUnauthorized,
+		"192.168.0.10": http.StatusUnauthorized,
+	} {
+		tempURL, err := client.CreateTempURL(&CreateTempURLInput{
+			Container: _TestTempURLContainer,
+			Name:      "object",
+			TTL:       time.Hour,
+			IPRange:   ipRange,
+		})
+		if err != nil {
+			t.Fatalf("Error creating temporary URL: %s", err)
+		}
+		assert.Equal(t, status, doTempURLRequest(t, server, "GET", tempURL.URL, ""), ipRange)
+	}
+}
+
+func TestObjectClient_CreateTempURLPrefix(t *testing.T) {
+	server, client := getTempURLTestServer(t, _TestTempURLKey, "reports/a.csv", "reports/b.csv", "secret.txt")
+	defer server.Close()
+
+	tempURL, err := client.CreateTempURL(&CreateTempURLInput{
+		Container: _TestTempURLContainer,
+		Prefix:    "reports/",
+		TTL:       time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("Error creating temporary URL: %s", err)
+	}
+
+	for _, name := range []string{"reports/a.csv", "reports/b.csv"} {
+		objectURL, err := tempURL.ObjectURL(name)
+		if err != nil {
+			t.Fatalf("Error creating object URL: %s", err)
+		}
+		assert.Equal(t, http.StatusOK, doTempURLRequest(t, server, "GET", objectURL, ""), name)
+	}
+
+	_, err = tempURL.ObjectURL("secret.txt")
+	assert.Error(t, err)
+	forged := strings.Replace(tempURL.URL, "/reports/?", "/secret.txt?", 1)
+	assert.Equal(t, http.StatusUnauthorized, doTempURLRequest(t, server, "GET", forged, ""))
+}
+
+func TestObjectClient_CreateTempURLSecondaryKey(t *testing.T) {
+	server, client := getTempURLTestServer(t, "", "object")
+	defer server.Close()
+
+	_, err := client.CreateTempURL(&CreateTempURLInput{
+		Container: _TestTempURLContainer,
+		Name:      "object",
+		TTL:       time.Hour,
+	})
+	assert.Error(t, err, "Expected an error for a container without a key")
+
+	_, err = client.UpdateContainer(&UpdateContainerInput{
+		Name:         _TestTempURLContainer,
+		SecondaryKey: "rotated-secret",
+	})
+	if err != nil {
+		t.Fatalf("Error updating container: %s", err)
+	}
+	tempURL, err := client.CreateTempURL(&CreateTempURLInput{
+		Container: _TestTempURLContainer,
+		Name:      "object",
+		TTL:       time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("Error creating temporary URL: %s", err)
+	}
+	assert.Equal(t, http.StatusOK, doTempURLRequest(t, server, "GET", tempURL.URL, ""))
+}
+
+func TestObjectClient_CreateTempURLValidation(t *testing.T) {
+	client := &ObjectClient{}
+	inputs := []*CreateTempURLInput{
+		{Name: "object", TTL: time.Hour},
+		{Container: "container", TTL: time.Hour},
+		{Container: "container", Name: "object"},
+		{Container: "container", Name: "object", TTL: time.Hour, Method: "POST"},
+		{Container: "container", Name: "object", TTL: time.Hour, Digest: "md5"},
+	}
+	for _, input := range inputs {
+		_, err := client.CreateTempURL(input)
+		assert.Error(t, err, "%+v", input)
+	}
+}
@@ -0,0 +1,428 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-oracle-terraform/opc"
+)
+
+// LargeObjectType defines the type of manifest used to join the segments of a large object
+type LargeObjectType string
+
+const (
+	// StaticLargeObject - the manifest lists each segment, along with its ETag and size
+	StaticLargeObject LargeObjectType = "static"
+	// DynamicLargeObject - the manifest includes every object under the segment prefix
+	DynamicLargeObject LargeObjectType = "dynamic"
+)
+
+const (
+	// DefaultSegmentSize is the size of the segments an object is uploaded in, if unspecified
+	DefaultSegmentSize = 64 * 1024 * 1024
+	// MaxSegmentSize is the largest object, and so segment, which can be created with a single request
+	MaxSegmentSize = 5 * 1024 * 1024 * 1024
+	// DefaultUploadConcurrency is the number of segments uploaded in parallel, if unspecified
+	DefaultUploadConcurrency = 4
+	// DefaultSegmentRetries is the number of times a failed segment upload is retried, if unspecified
+	DefaultSegmentRetries = 3
+
+	segmentContainerSuffix = "_segments"
+)
+
+var segmentBackoff = opc.ExponentialBackoff(1*time.Second, 30*time.Second)
+
+// UploadObjectInput defines an object to be uploaded from a stream, splitting it
+// into segments joined by a large object manifest if it exceeds a single segment.
+type UploadObjectInput struct {
+	// Name of the object.
+	// Required
+	Name string
+	// Name of the container to place the object
+	// Required
+	Container string
+	// Stream to read the object's content from. The stream is read once, one segment at a time.
+	// Required
+	Body io.Reader
+	// Size of each segment in bytes, at most MaxSegmentSize. Up to twice the concurrency
+	// segments are held in memory at a time.
+	// Optional - Defaults to DefaultSegmentSize
+	SegmentSize int64
+	// Name of the container to place the segments in. It is created if it doesn't exist.
+	// Optional - Defaults to the container name with a `_segments` suffix
+	SegmentContainer string
+	// The type of manifest to join the segments with
+	// Optional - Defaults to StaticLargeObject
+	ManifestType LargeObjectType
+	// The number of segments to upload in parallel
+	// Optional - Defaults to DefaultUploadConcurrency
+	Concurrency int
+	// The number of times to retry a segment which fails to upload, or whose ETag doesn't
+	// match the MD5 checksum of its content. Use opc.Int(0) to upload each segment only once.
+	// Optional - Defaults to DefaultSegmentRetries
+	SegmentRetries *int
+	// Identifies the segments of this upload, which are named `{Name}/{UploadID}/{index}`.
+	// Optional - Defaults to a timestamp. Required when resuming an upload.
+	UploadID string
+	// Resume a previous upload with the same UploadID, skipping segments which have already
+	// been uploaded with matching content. The Body must supply the same content again.
+	// Optional
+	Resume bool
+	// Override the behavior of the browser.
+	// Optional
+	ContentDisposition string
+	// Set the content-encoding metadata
+	// Optional
+	ContentEncoding string
+	// Changes the MIME type for the object
+	// Optional
+	ContentType string
+	// Specify the date and time in UNIX Epoch time stamp format when the system
+	// removes the object
+	// Optional
+	DeleteAt int
+	// Specify the map of object metadata name values pairs for X-Object-Meta-{name}
+	// Optional
+	ObjectMetadata map[string]string
+}
+
+// UploadError is returned when an object upload fails. The upload can be resumed by
+// uploading the object again with the same UploadID and Resume set.
+type UploadError struct {
+	// The UploadID of the failed upload
+	UploadID string
+	// The index of the segment which failed to upload
+	Segment int
+	// The error the segment failed with
+	Err error
+}
+
+func (e *UploadError) Error() string {
+	return fmt.Sprintf("Error uploading segment %d of upload %s: %s", e.Segment, e.UploadID, e.Err)
+}
+
+// Unwrap returns the error the segment failed with
+func (e *UploadError) Unwrap() error {
+	return e.Err
+}
+
+// segment is an uploaded segment, as listed in a static large object manifest
+type segment struct {
+	Path string `json:"path"`
+	ETag string `json:"etag"`
+	Size int64  `json:"size_bytes"`
+}
+
+// UploadObject uploads an object from a stream. Objects larger than a single segment
+// are uploaded as segments in parallel, each verified against its MD5 checksum and retried
+// on failure, before being joined by a static or dynamic large object manifest.
+func (c *ObjectClient) UploadObject(input *UploadObjectInput) (*ObjectInfo, error) {
+	if input.Body == nil {
+		return nil, fmt.Errorf("Body cannot be nil")
+	}
+	if input.Name == "" || input.Container == "" {
+		return nil, fmt.Errorf("Both Name and Container must be set")
+	}
+
+	if input.SegmentSize == 0 {
+		input.SegmentSize = DefaultSegmentSize
+	}
+	if input.SegmentSize < 0 || input.SegmentSize > MaxSegmentSize {
+		return nil, fmt.Errorf("SegmentSize must be between 1 and %d bytes", MaxSegmentSize)
+	}
+	if input.SegmentContainer == "" {
+		input.SegmentContainer = input.Container + segmentContainerSuffix
+	}
+	if input.ManifestType == "" {
+		input.ManifestType = StaticLargeObject
+	}
+	if input.ManifestType != StaticLargeObject && input.ManifestType != DynamicLargeObject {
+		return nil, fmt.Errorf("Unknown ManifestType: %s", input.ManifestType)
+	}
+	if input.Concurrency <= 0 {
+		input.Concurrency = DefaultUploadConcurrency
+	}
+	if input.SegmentRetries == nil {
+		input.SegmentRetries = opc.Int(DefaultSegmentRetries)
+	}
+	if *input.SegmentRetries < 0 {
+		return nil, fmt.Errorf("SegmentRetries cannot be negative")
+	}
+	if input.UploadID == "" {
+		if input.Resume {
+			return nil, fmt.Errorf("UploadID must be set to resume an upload")
+		}
+		input.UploadID = strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+
+	first, err := readSegment(input.Body, input.SegmentSize)
+	if err != nil {
+		return nil, err
+	}
+	second, err := readSegment(input.Body, input.SegmentSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// The object fits in a single segment, so upload it directly
+	if len(second) == 0 {
+		createInput := c.manifestInput(input)
+		createInput.Body = bytes.NewReader(first)
+		createInput.ETag = md5Hex(first)
+		return c.createObject(createInput, "")
+	}
+
+	if err := c.createSegmentContainer(input.SegmentContainer); err != nil {
+		return nil, err
+	}
+
+	segments, err := c.uploadSegments(input, first, second)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.createManifest(input, segments)
+}
+
+// uploadSegments uploads the segments read from the input's body in parallel,
+// starting with the given segments which have already been read
+func (c *ObjectClient) uploadSegments(input *UploadObjectInput, read ...[]byte) ([]segment, error) {
+	type job struct {
+		index int
+		data  []byte
+	}
+
+	var (
+		jobs     = make(chan job, input.Concurrency)
+		uploaded = map[int]segment{}
+		firstErr error
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+	)
+	failed := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return firstErr != nil
+	}
+
+	for i := 0; i < input.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				if failed() {
+					continue
+				}
+				seg, err := c.uploadSegment(input, j.index, j.data)
+
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = err
+				} else if err == nil {
+					uploaded[j.index] = *seg
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	count := 0
+	for ; !failed(); count++ {
+		var data []byte
+		if count < len(read) {
+			data = read[count]
+		} else {
+			var err error
+			if data, err = readSegment(input.Body, input.SegmentSize); err != nil {
+				mu.Lock()
+				firstErr = &UploadError{UploadID: input.UploadID, Segment: count, Err: err}
+				mu.Unlock()
+				break
+			}
+		}
+		if len(data) == 0 {
+			break
+		}
+		jobs <- job{index: count, data: data}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	segments := make([]segment, count)
+	for i := range segments {
+		segments[i] = uploaded[i]
+	}
+	return segments, nil
+}
+
+// uploadSegment uploads a single segment, retrying on failure. When resuming,
+// segments which already exist with the expected ETag are skipped.
+func (c *ObjectClient) uploadSegment(input *UploadObjectInput, index int, data []byte) (*segment, error) {
+	segmentName := fmt.Sprintf("%s/%s/%08d", input.Name, input.UploadID, index)
+	seg := &segment{
+		Path: fmt.Sprintf("/%s/%s", input.SegmentContainer, segmentName),
+		ETag: md5Hex(data),
+		Size: int64(len(data)),
+	}
+	name := c.getQualifiedName(fmt.Sprintf("%s/%s", input.SegmentContainer, segmentName))
+
+	if input.Resume {
+		if etag, err := c.getETag(name); err == nil && etag == seg.ETag {
+			c.client.DebugLogString(fmt.Sprintf("Segment %d of %s already uploaded", index, input.Name))
+			return seg, nil
+		}
+	}
+
+	var err error
+	for attempt := 0; attempt <= *input.SegmentRetries; attempt++ {
+		if attempt > 0 {
+			if sleepErr := c.client.Sleep(segmentBackoff(attempt)); sleepErr != nil {
+				return nil, &UploadError{UploadID: input.UploadID, Segment: index, Err: sleepErr}
+			}
+		}
+		if err = c.putSegment(name, data, seg.ETag); err == nil {
+			return seg, nil
+		}
+		c.client.DebugLogString(fmt.Sprintf("Error uploading segment %d of %s (attempt %d): %s", index, input.Name, attempt+1, err))
+		if !retrySegment(err) {
+			break
+		}
+	}
+
+	return nil, &UploadError{UploadID: input.UploadID, Segment: index, Err: err}
+}
+
+func (c *ObjectClient) putSegment(name string, data []byte, etag string) error {
+	headers := map[string]string{
+		hETag:        etag,
+		hContentType: "application/octet-stream",
+	}
+	resp, err := c.executeRequestBody("PUT", name, headers, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if got := strings.Trim(resp.Header.Get(hETag), `"`); got != "" && got != etag {
+		return fmt.Errorf("ETag mismatch: expected %s, got %s", etag, got)
+	}
+	return nil
+}
+
+// getETag returns the unquoted ETag of the object with the given qualified name
+func (c *ObjectClient) getETag(name string) (string, error) {
+	resp, err := c.executeRequest("HEAD", name, nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	return strings.Trim(resp.Header.Get(hETag), `"`), nil
+}
+
+// retrySegment returns false for client errors which won't succeed on retry.
+// Checksum mismatches (422) are retried, as the segment may have been corrupted in transit.
+func retrySegment(err error) bool {
+	var oracleErr *opc.OracleError
+	if !errors.As(err, &oracleErr) {
+		return true
+	}
+	if oracleErr.StatusCode == http.StatusUnprocessableEntity {
+		return true
+	}
+	return oracleErr.StatusCode < 400 || oracleErr.StatusCode >= 500 || oracleErr.Retryable()
+}
+
+func (c *ObjectClient) createSegmentContainer(name string) error {
+	_, err := c.GetContainer(&GetContainerInput{Name: name})
+	if err == nil {
+		return nil
+	}
+	if !opc.IsNotFound(err) {
+		return err
+	}
+	_, err = c.CreateContainer(&CreateContainerInput{Name: name})
+	return err
+}
+
+// createManifest creates the large object manifest joining the uploaded segments
+func (c *ObjectClient) createManifest(input *UploadObjectInput, segments []segment) (*ObjectInfo, error) {
+	manifestInput := c.manifestInput(input)
+
+	if input.ManifestType == DynamicLargeObject {
+		manifestInput.ObjectManifest = fmt.Sprintf("%s/%s/%s/", input.SegmentContainer, input.Name, input.UploadID)
+		manifestInput.Body = bytes.NewReader(nil)
+		return c.createObject(manifestInput, "")
+	}
+
+	body, err := json.Marshal(segments)
+	if err != nil {
+		return nil, err
+	}
+	manifestInput.Body = bytes.NewReader(body)
+	return c.createObject(manifestInput, "?multipart-manifest=put")
+}
+
+// createObject creates an object with the given query string, returning its details from a
+// HEAD request rather than CreateObject's GET, which would download a large object's segments
+func (c *ObjectClient) createObject(input *CreateObjectInput, query string) (*ObjectInfo, error) {
+	name := c.getQualifiedName(fmt.Sprintf("%s/%s", input.Container, input.Name))
+	resp, err := c.executeRequestBody("PUT", name+query, c.createObjectHeaders(input), input.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	resp, err = c.executeRequest("HEAD", name, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return c.success(resp, &ObjectInfo{
+		ID:        fmt.Sprintf("%s/%s", input.Container, input.Name),
+		Name:      input.Name,
+		Container: input.Container,
+	})
+}
+
+// manifestInput returns the input used to create the uploaded object or its manifest
+func (c *ObjectClient) manifestInput(input *UploadObjectInput) *CreateObjectInput {
+	return &CreateObjectInput{
+		Name:               input.Name,
+		Container:          input.Container,
+		ContentDisposition: input.ContentDisposition,
+		ContentEncoding:    input.ContentEncoding,
+		ContentType:        input.ContentType,
+		DeleteAt:           input.DeleteAt,
+		ObjectMetadata:     input.ObjectMetadata,
+	}
+}
+
+// readSegment reads up to size bytes from the reader, returning an empty slice at the end of the stream
+func readSegment(r io.Reader, size int64) ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, r, size); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func md5Hex(data []byte) string {
+	sum := md5.Sum(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,243 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/go-oracle-terraform/opc"
+	"github.com/stretchr/testify/assert"
+)
+
+const _TestUploadContainer = "upload-container"
+
+func getUploadTestData(size int) []byte {
+	data := make([]byte, size)
+	rand.New(rand.NewSource(1)).Read(data)
+	return data
+}
+
+func getUploadTestServer(t *testing.T) (*testServer, *ObjectClient) {
+	server := newTestServer()
+	client := server.getClient(t)
+	if _, err := client.CreateContainer(&CreateContainerInput{Name: _TestUploadContainer}); err != nil {
+		server.Close()
+		t.Fatalf("Error creating container: %s", err)
+	}
+	return server, client.Objects()
+}
+
+func segmentPath(uploadID string, index int) string {
+	return fmt.Sprintf("/v1/Storage-%s/%s%s/large-object/%s/%08d",
+		_TestServerDomain, _TestUploadContainer, segmentContainerSuffix, uploadID, index)
+}
+
+func TestObjectClient_UploadObjectSingleSegment(t *testing.T) {
+	server, client := getUploadTestServer(t)
+	defer server.Close()
+
+	data := getUploadTestData(512)
+	info, err := client.UploadObject(&UploadObjectInput{
+		Name:        "small-object",
+		Container:   _TestUploadContainer,
+		Body:        bytes.NewReader(data),
+		SegmentSize: 1024,
+		ContentType: "application/octet-stream",
+	})
+	if err != nil {
+		t.Fatalf("Error uploading object: %s", err)
+	}
+
+	assert.Equal(t, 512, info.ContentLength)
+	assert.Equal(t, testMD5(data), info.Etag)
+	assert.False(t, info.StaticLargeObject)
+	_, ok := server.containers[_TestUploadContainer+segmentContainerSuffix]
+	assert.False(t, ok, "Expected no segment container to be created")
+}
+
+func TestObjectClient_UploadObjectStatic(t *testing.T) {
+	server, client := getUploadTestServer(t)
+	defer server.Close()
+
+	data := getUploadTestData(10*1024 + 100)
+	info, err := client.UploadObject(&UploadObjectInput{
+		Name:           "large-object",
+		Container:      _TestUploadContainer,
+		Body:           bytes.NewReader(data),
+		SegmentSize:    1024,
+		Concurrency:    3,
+		UploadID:       "1",
+		ObjectMetadata: map[string]string{"Foo": "bar"},
+	})
+	if err != nil {
+		t.Fatalf("Error uploading object: %s", err)
+	}
+
+	assert.True(t, info.StaticLargeObject)
+	assert.Equal(t, len(data), info.ContentLength)
+	assert.Equal(t, "bar", info.ObjectMetadata["Foo"])
+
+	// The object's details are read without downloading its segments
+	objectPath := fmt.Sprintf("/v1/Storage-%s/%s/large-object", _TestServerDomain, _TestUploadContainer)
+	assert.Equal(t, 0, server.requestCount("GET", objectPath))
+	assert.Equal(t, 1, server.requestCount("HEAD", objectPath))
+
+	object := server.containers[_TestUploadContainer].objects["large-object"]
+	assert.Len(t, object.segments, 11)
+	assert.Equal(t, data, server.objectContent(object))
+}
+
+func TestObjectClient_UploadObjectDynamic(t *testing.T) {
+	server, client := getUploadTestServer(t)
+	defer server.Close()
+
+	data := getUploadTestData(4096)
+	info, err := client.UploadObject(&UploadObjectInput{
+		Name:         "large-object",
+		Container:    _TestUploadContainer,
+		Body:         bytes.NewReader(data),
+		SegmentSize:  1024,
+		ManifestType: DynamicLargeObject,
+		UploadID:     "1",
+	})
+	if err != nil {
+		t.Fatalf("Error uploading object: %s", err)
+	}
+
+	assert.False(t, info.StaticLargeObject)
+	assert.Equal(t, _TestUploadContainer+segmentContainerSuffix+"/large-object/1/", info.ObjectManifest)
+	assert.Equal(t, len(data), info.ContentLength)
+
+	object := server.containers[_TestUploadContainer].objects["large-object"]
+	assert.Equal(t, data, server.objectContent(object))
+}
+
+func TestObjectClient_UploadObjectRetriesSegments(t *testing.T) {
+	server, client := getUploadTestServer(t)
+	defer server.Close()
+
+	// Fail the first attempt to upload segment 1 with a server error, and
+	// segment 2 with a checksum mismatch
+	failures := map[string]int{
+		segmentPath("1", 1): http.StatusInternalServerError,
+		segmentPath("1", 2): http.StatusUnprocessableEntity,
+	}
+	server.intercept = func(r *http.Request) int {
+		status := failures[r.URL.Path]
+		if r.Method == "PUT" {
+			delete(failures, r.URL.Path)
+		}
+		return status
+	}
+
+	data := getUploadTestData(4096)
+	_, err := client.UploadObject(&UploadObjectInput{
+		Name:        "large-object",
+		Container:   _TestUploadContainer,
+		Body:        bytes.NewReader(data),
+		SegmentSize: 1024,
+		UploadID:    "1",
+	})
+	if err != nil {
+		t.Fatalf("Error uploading object: %s", err)
+	}
+
+	assert.Equal(t, 1, server.requestCount("PUT", segmentPath("1", 0)))
+	assert.Equal(t, 2, server.requestCount("PUT", segmentPath("1", 1)))
+	assert.Equal(t, 2, server.requestCount("PUT", segmentPath("1", 2)))
+	object := server.containers[_TestUploadContainer].objects["large-object"]
+	assert.Equal(t, data, server.objectContent(object))
+}
+
+func TestObjectClient_UploadObjectSegmentRetries(t *testing.T) {
+	server, client := getUploadTestServer(t)
+	defer server.Close()
+
+	server.intercept = func(r *http.Request) int {
+		if r.Method == "PUT" && r.URL.Path == segmentPath("1", 1) {
+			return http.StatusInternalServerError
+		}
+		return 0
+	}
+
+	data := getUploadTestData(4096)
+	_, err := client.UploadObject(&UploadObjectInput{
+		Name:           "large-object",
+		Container:      _TestUploadContainer,
+		Body:           bytes.NewReader(data),
+		SegmentSize:    1024,
+		SegmentRetries: opc.Int(0),
+		UploadID:       "1",
+	})
+	var uploadErr *UploadError
+	if !errors.As(err, &uploadErr) {
+		t.Fatalf("Expected UploadError, got: %v", err)
+	}
+	assert.Equal(t, 1, server.requestCount("PUT", segmentPath("1", 1)))
+
+	_, err = client.UploadObject(&UploadObjectInput{
+		Name:           "large-object",
+		Container:      _TestUploadContainer,
+		Body:           bytes.NewReader(data),
+		SegmentRetries: opc.Int(-1),
+	})
+	if err == nil {
+		t.Fatal("Expected an error uploading with negative SegmentRetries")
+	}
+}
+
+func TestObjectClient_UploadObjectResume(t *testing.T) {
+	server, client := getUploadTestServer(t)
+	defer server.Close()
+
+	// Client errors aren't retried, so the upload fails on the first attempt at segment 3
+	server.intercept = func(r *http.Request) int {
+		if r.Method == "PUT" && r.URL.Path == segmentPath("1", 3) {
+			return http.StatusBadRequest
+		}
+		return 0
+	}
+
+	data := getUploadTestData(5000)
+	input := &UploadObjectInput{
+		Name:        "large-object",
+		Container:   _TestUploadContainer,
+		Body:        bytes.NewReader(data),
+		SegmentSize: 1024,
+		Concurrency: 1,
+		UploadID:    "1",
+	}
+	_, err := client.UploadObject(input)
+
+	var uploadErr *UploadError
+	if !errors.As(err, &uploadErr) {
+		t.Fatalf("Expected UploadError, got: %v", err)
+	}
+	assert.Equal(t, "1", uploadErr.UploadID)
+	assert.Equal(t, 3, uploadErr.Segment)
+	assert.Equal(t, 1, server.requestCount("PUT", segmentPath("1", 3)))
+
+	server.intercept = nil
+	_, err = client.UploadObject(&UploadObjectInput{
+		Name:        "large-object",
+		Container:   _TestUploadContainer,
+		Body:        bytes.NewReader(data),
+		SegmentSize: 1024,
+		UploadID:    uploadErr.UploadID,
+		Resume:      true,
+	})
+	if err != nil {
+		t.Fatalf("Error resuming upload: %s", err)
+	}
+
+	// Segments uploaded by the first attempt aren't uploaded again
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, 1, server.requestCount("PUT", segmentPath("1", i)))
+	}
+	assert.Equal(t, 2, server.requestCount("PUT", segmentPath("1", 3)))
+	object := server.containers[_TestUploadContainer].objects["large-object"]
+	assert.Equal(t, data, server.objectContent(object))
+}